@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"asset-measurements-assignment/internal/platform"
+	"github.com/GLCharge/otelzap"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	devxCfg "github.com/xBlaz3kx/DevX/configuration"
+	"go.uber.org/zap"
+)
+
+const serviceName = "platform"
+
+var (
+	// Configuration
+	configurationFilePathFlag string
+
+	rootCmd = &cobra.Command{
+		Use:   "asset-platform",
+		Short: "asset service and simulator",
+		Long:  `Runs the asset service and the simulator in one process. Use the subcommands to run only one of them.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			devxCfg.InitConfig(configurationFilePathFlag, "./config", ".")
+			devxCfg.SetupEnv(serviceName)
+			devxCfg.SetDefaults(serviceName)
+			viper.SetDefault("environment", "development")
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), platform.Services{AssetService: true, Simulator: true})
+		},
+	}
+
+	assetServiceCmd = &cobra.Command{
+		Use:   "asset-service",
+		Short: "asset service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), platform.Services{AssetService: true})
+		},
+	}
+
+	simulatorCmd = &cobra.Command{
+		Use:   "simulator",
+		Short: "simulator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), platform.Services{Simulator: true})
+		},
+	}
+)
+
+func run(ctx context.Context, services platform.Services) error {
+	// Get configuration from Viper
+	otelzap.L().Info("Parsing configuration")
+
+	cfg := platform.Config{}
+	devxCfg.GetConfiguration(viper.GetViper(), &cfg)
+
+	return platform.Run(ctx, cfg, services)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&configurationFilePathFlag, "config", "c", "", "config file path")
+
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
+
+	rootCmd.AddCommand(assetServiceCmd, simulatorCmd)
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		otelzap.L().Fatal("Unable to run", zap.Error(err))
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os/signal"
 
 	"asset-measurements-assignment/internal/asset-service"
+	"asset-measurements-assignment/internal/embedded"
+	"asset-measurements-assignment/internal/simulator"
 	"github.com/GLCharge/otelzap"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +21,9 @@ var (
 	// Configuration
 	configurationFilePathFlag string
 
+	// Run with in-memory infrastructure, together with the simulator
+	embeddedFlag bool
+
 	rootCmd = &cobra.Command{
 		Use:   "asset-service",
 		Short: "asset service",
@@ -36,6 +41,13 @@ var (
 			cfg := asset_service.Config{}
 			devxCfg.GetConfiguration(viper.GetViper(), &cfg)
 
+			if embeddedFlag {
+				return embedded.Run(cmd.Context(), embedded.Config{
+					AssetService: cfg,
+					Simulator:    simulator.Config{Observability: cfg.Observability},
+				})
+			}
+
 			// Run the device service
 			return asset_service.Run(cmd.Context(), cfg)
 		},
@@ -45,6 +57,8 @@ var (
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configurationFilePathFlag, "config", "c", "", "config file path")
 
+	rootCmd.PersistentFlags().BoolVar(&embeddedFlag, "embedded", false, "run together with the simulator, without Postgres, MongoDB and RabbitMQ")
+
 	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 }
 
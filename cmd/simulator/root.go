@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/signal"
 
+	asset_service "asset-measurements-assignment/internal/asset-service"
+	"asset-measurements-assignment/internal/embedded"
 	"asset-measurements-assignment/internal/simulator"
 	"github.com/GLCharge/otelzap"
 	"github.com/spf13/cobra"
@@ -19,6 +21,9 @@ var (
 	// Configuration
 	configurationFilePathFlag string
 
+	// Run with in-memory infrastructure, together with the asset service
+	embeddedFlag bool
+
 	rootCmd = &cobra.Command{
 		Use:   "simulator",
 		Short: "simulator",
@@ -36,6 +41,13 @@ var (
 			cfg := &simulator.Config{}
 			devxCfg.GetConfiguration(viper.GetViper(), cfg)
 
+			if embeddedFlag {
+				return embedded.Run(cmd.Context(), embedded.Config{
+					AssetService: asset_service.Config{Observability: cfg.Observability},
+					Simulator:    *cfg,
+				})
+			}
+
 			// Run the simulator
 			return simulator.Run(cmd.Context(), *cfg)
 		},
@@ -45,6 +57,8 @@ var (
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configurationFilePathFlag, "config", "c", "", "config file path")
 
+	rootCmd.PersistentFlags().BoolVar(&embeddedFlag, "embedded", false, "run together with the asset service, without Postgres, MongoDB and RabbitMQ")
+
 	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 }
 
@@ -2,6 +2,7 @@ package asset_service
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	http2 "asset-measurements-assignment/internal/asset-service/http"
@@ -9,16 +10,23 @@ import (
 	postgres2 "asset-measurements-assignment/internal/asset-service/postgres"
 	"asset-measurements-assignment/internal/asset-service/rabbitmq"
 	"asset-measurements-assignment/internal/domain/assets"
+	domainMeasurements "asset-measurements-assignment/internal/domain/measurements"
 	measurements "asset-measurements-assignment/internal/domain/measurements/service"
-	"asset-measurements-assignment/internal/pkg/infrastructure/mongo"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/pkg/health"
 	"asset-measurements-assignment/internal/pkg/infrastructure/postgres"
+	rmq "asset-measurements-assignment/internal/pkg/infrastructure/rabbitmq"
+	"asset-measurements-assignment/internal/pkg/metrics"
+	"asset-measurements-assignment/internal/pkg/server"
+	"asset-measurements-assignment/internal/pkg/shutdown"
 	"github.com/GLCharge/otelzap"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
-	goRabbit "github.com/wagslane/go-rabbitmq"
 	devxHttp "github.com/xBlaz3kx/DevX/http"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type Config struct {
@@ -34,9 +42,20 @@ type Config struct {
 	// Example: mongodb://localhost:27017
 	Mongo string `yaml:"mongo" mapstructure:"mongo" json:"mongo"`
 
+	// Measurement storage backend: mongo (default), timescale or memory.
+	// The timescale backend stores the measurements in the Postgres database.
+	Storage string `yaml:"storage" mapstructure:"storage" json:"storage" validate:"omitempty,oneof=mongo timescale memory"`
+
+	// Retention of the measurements and rollup settings. Rollups are supported by the mongo backend only
+	Retention mongodb.RetentionConfig `yaml:"retention" mapstructure:"retention" json:"retention"`
+
 	// HTTP server settings
 	Http devxHttp.Configuration `yaml:"http" mapstructure:"http" json:"http"`
 
+	// Time to drain the in-flight requests and measurements on shutdown
+	// Default: 15s
+	DrainTimeout time.Duration `yaml:"drainTimeout" mapstructure:"drainTimeout" json:"drainTimeout"`
+
 	// Observability settings
 	Observability observability.Config `yaml:"observability" mapstructure:"observability" json:"observability"`
 }
@@ -46,24 +65,26 @@ var serviceInfo = observability.ServiceInfo{
 	Version: "0.0.1",
 }
 
-func Run(ctx context.Context, cfg Config) error {
-	// Initialize observability
-	obs, err := observability.NewObservability(ctx, serviceInfo, cfg.Observability)
-	if err != nil {
-		otelzap.L().With(zap.Error(err)).Fatal("Could not initialize observability")
-	}
-	defer func() {
-		shutdownCtx, cancel2 := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel2()
-		_ = obs.Shutdown(shutdownCtx)
-	}()
+// Infrastructure holds the repositories the asset service runs on, and starts consuming the measurements.
+type Infrastructure struct {
+	AssetRepository         assets.Repository
+	MeasurementsRepository  domainMeasurements.Repository
+	ConfigurationRepository simulator.ConfigurationReader
 
-	obs.Log().Info("Starting asset service", zap.Any("config", cfg))
+	// HealthChecks of the dependencies, reported by the health and readiness endpoints
+	HealthChecks []health.Check
 
-	env := viper.GetString("environment")
-	if env != "development" {
-		gin.SetMode(gin.ReleaseMode)
-	}
+	// StartConsumer starts passing the consumed measurements to the consumer service
+	StartConsumer func(ctx context.Context, obs observability.Observability, consumerService measurements.ConsumerService) error
+
+	// Close stops consuming, drains the in-flight measurements and releases the storage, until the context is done
+	Close func(ctx context.Context) error
+}
+
+// Run runs the asset service on Postgres, the configured measurement storage and RabbitMQ.
+func Run(ctx context.Context, cfg Config) error {
+	obs, shutdownObservability := initObservability(ctx, cfg)
+	defer shutdownObservability()
 
 	// Connect to Postgres
 	postgresDb, err := postgres.Connect(obs, cfg.Postgres)
@@ -71,44 +92,138 @@ func Run(ctx context.Context, cfg Config) error {
 		return err
 	}
 
-	// Create asset repository
-	assetRepository := postgres2.NewAssetRepository(obs, postgresDb)
-
-	// Connect to MongoDB
-	mongoClient, err := mongo.NewClient(cfg.Mongo)
+	rabbitMqConn, err := rmq.Connect(obs, cfg.Rabbitmq, time.Second*5)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = mongoClient.Disconnect(ctx)
-	}()
-	mongoDb := mongoClient.Database(mongo.Database)
 
-	measurementsRepository, err := mongodb.NewMeasurementsRepository(obs, mongoDb)
+	registry := metrics.NewRegistry(cfg.Observability.Metrics)
+	infrastructure, err := NewInfrastructure(ctx, obs, cfg, postgresDb, rabbitMqConn, registry)
 	if err != nil {
 		return err
 	}
 
-	// Create consumer service
-	consumerService := measurements.NewConsumerService(obs, measurementsRepository, assetRepository)
-
-	rabbitMqConn, err := goRabbit.NewConn(cfg.Rabbitmq,
-		goRabbit.WithConnectionOptionsLogging,
-		goRabbit.WithConnectionOptionsReconnectInterval(time.Second*5),
+	// The connections are closed after the in-flight measurements are drained
+	return run(ctx, obs, cfg, registry, infrastructure,
+		shutdown.Step{Name: "rabbitmq", Shutdown: func(context.Context) error { return rabbitMqConn.Close() }},
+		shutdown.Step{Name: "postgres", Shutdown: func(context.Context) error { return postgres.Close(postgresDb) }},
 	)
+}
+
+// NewInfrastructure creates the repositories on the given connections and the configured measurement storage,
+// and consumes the measurements from RabbitMQ. The consumer metrics are registered on the registry, if not nil.
+func NewInfrastructure(ctx context.Context, obs observability.Observability, cfg Config, postgresDb *gorm.DB, rabbitMqConn *rmq.Connection, registry *prometheus.Registry) (Infrastructure, error) {
+	// Create the measurements repository of the configured storage backend
+	measurementsRepository, storageChecks, closeStorage, err := newMeasurementsRepository(ctx, obs, cfg, postgresDb)
 	if err != nil {
-		return err
+		return Infrastructure{}, err
+	}
+
+	var consumer *rabbitmq.Handler
+	consumerMetrics := metrics.NewConsumerMetrics(registry)
+	consumerState := rmq.NewState("rabbitmq-consumer")
+	infrastructure := Infrastructure{
+		AssetRepository:        postgres2.NewAssetRepository(obs, postgresDb),
+		MeasurementsRepository: measurementsRepository,
+		// Simulator configurations are written by the simulator, and read to know the measurement interval of the assets
+		ConfigurationRepository: postgres2.NewSimulatorConfigurationRepository(obs, postgresDb),
+		HealthChecks: append([]health.Check{
+			health.PostgresCheck(postgresDb),
+			health.RabbitMQCheck(rabbitMqConn.State),
+			health.RabbitMQCheck(consumerState),
+		}, storageChecks...),
+		StartConsumer: func(ctx context.Context, obs observability.Observability, consumerService measurements.ConsumerService) error {
+			// Create rabbitmq consumer
+			handler, err := rabbitmq.NewHandler(obs, rabbitMqConn.Conn, consumerState, consumerService, consumerMetrics)
+			if err != nil {
+				return err
+			}
+			consumer = handler
+
+			return handler.Start(ctx)
+		},
+		Close: func(ctx context.Context) error {
+			var err error
+			if consumer != nil {
+				err = consumer.Close(ctx)
+			}
+
+			return errors.Join(err, closeStorage(ctx))
+		},
+	}
+
+	return infrastructure, nil
+}
+
+// RunWithInfrastructure runs the asset service on the given infrastructure, e.g. in-memory repositories.
+func RunWithInfrastructure(ctx context.Context, cfg Config, infrastructure Infrastructure) error {
+	obs, shutdownObservability := initObservability(ctx, cfg)
+	defer shutdownObservability()
+
+	return run(ctx, obs, cfg, metrics.NewRegistry(cfg.Observability.Metrics), infrastructure)
+}
+
+func initObservability(ctx context.Context, cfg Config) (observability.Observability, func()) {
+	obs, err := observability.NewObservability(ctx, serviceInfo, cfg.Observability)
+	if err != nil {
+		otelzap.L().With(zap.Error(err)).Fatal("Could not initialize observability")
+	}
+
+	obs.Log().Info("Starting asset service", zap.Any("config", cfg))
+
+	env := viper.GetString("environment")
+	if env != "development" {
+		gin.SetMode(gin.ReleaseMode)
 	}
-	defer rabbitMqConn.Close()
 
-	// Create rabbitmq consumer
-	consumer, err := rabbitmq.NewHandler(obs, rabbitMqConn, consumerService)
+	return obs, func() {
+		shutdownCtx, cancel2 := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel2()
+		_ = obs.Shutdown(shutdownCtx)
+	}
+}
+
+// run runs the asset service until the context is done, then shuts it down in order: it stops accepting requests and
+// measurements, drains the in-flight ones and closes the connections, all within the drain timeout.
+func run(ctx context.Context, obs observability.Observability, cfg Config, registry *prometheus.Registry, infrastructure Infrastructure, connections ...shutdown.Step) error {
+	// Create HTTP server
+	router := devxHttp.NewServer(cfg.Http, obs).Router()
+	router.Use(metrics.NewHttpMetrics(registry).Middleware())
+	metrics.RegisterRoutes(router, registry)
+
+	healthHandler := health.NewHandler(infrastructure.HealthChecks...)
+	healthHandler.RegisterRoutes(router)
+
+	err := Start(ctx, obs, infrastructure, router)
 	if err != nil {
 		return err
 	}
-	defer consumer.Close()
 
-	err = consumer.Start(ctx)
+	httpServer := server.New(obs, cfg.Http, router)
+	httpServer.Run()
+	healthHandler.SetReady(true)
+
+	<-ctx.Done()
+	obs.Log().Info("Shutting down asset service")
+	healthHandler.SetReady(false)
+
+	steps := append([]shutdown.Step{
+		{Name: "http", Shutdown: httpServer.Shutdown},
+		{Name: "consumer", Shutdown: infrastructure.Close},
+	}, connections...)
+
+	return shutdown.Run(obs, cfg.DrainTimeout, steps...)
+}
+
+// Start starts consuming the measurements and registers the asset and measurement routes on the router.
+func Start(ctx context.Context, obs observability.Observability, infrastructure Infrastructure, router gin.IRouter) error {
+	assetRepository := infrastructure.AssetRepository
+	measurementsRepository := infrastructure.MeasurementsRepository
+
+	// Create consumer service
+	consumerService := measurements.NewConsumerService(obs, measurementsRepository, assetRepository)
+
+	err := infrastructure.StartConsumer(ctx, obs, consumerService)
 	if err != nil {
 		return err
 	}
@@ -117,11 +232,7 @@ func Run(ctx context.Context, cfg Config) error {
 	assetService := assets.NewService(obs, assetRepository)
 
 	// Create measurements service
-	measurementsService := measurements.NewMeasurementsService(obs, assetRepository, measurementsRepository)
-
-	// Create HTTP server
-	httpServer := devxHttp.NewServer(cfg.Http, obs)
-	router := httpServer.Router()
+	measurementsService := measurements.NewMeasurementsService(obs, assetRepository, measurementsRepository, infrastructure.ConfigurationRepository)
 
 	// Asset handler
 	handler := http2.NewAssetGinHandler(assetService)
@@ -131,14 +242,5 @@ func Run(ctx context.Context, cfg Config) error {
 	measurementsGinHandler := http2.NewMeasurementsGinHandler(measurementsService)
 	measurementsGinHandler.RegisterRoutes(router)
 
-	go func() {
-		// Todo define health checks
-		httpServer.Run()
-	}()
-
-	<-ctx.Done()
-	obs.Log().Info("Shutting down asset service")
-	httpServer.Shutdown()
-
 	return nil
 }
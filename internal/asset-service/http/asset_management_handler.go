@@ -15,7 +15,7 @@ func NewAssetGinHandler(service assets.Service) *AssetGinHandler {
 	return &AssetGinHandler{service: service}
 }
 
-func (d *AssetGinHandler) RegisterRoutes(router *gin.Engine) {
+func (d *AssetGinHandler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/assets", d.CreateAsset)
 	router.GET("/assets", d.GetAssets)
 	router.GET("/assets/:assetId", d.GetAssetById)
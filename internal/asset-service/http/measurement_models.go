@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"asset-measurements-assignment/internal/domain/measurements"
+	devxHttp "github.com/xBlaz3kx/DevX/http"
 )
 
 // swagger:model
@@ -14,8 +15,32 @@ type Measurement struct {
 	// Power represents the power of the asset.
 	Power Power `json:"power"`
 
-	// StateOfEnergy represents the current state of energy of the asset.
-	StateOfEnergy float64 `json:"stateOfEnergy"`
+	// StateOfEnergy represents the current state of energy of the asset, null for assets which don't store energy.
+	StateOfEnergy *float64 `json:"stateOfEnergy"`
+
+	// Metrics represents additional metrics of the asset (voltage, current, frequency, temperature, reactive power), keyed by the metric name.
+	Metrics map[string]Metric `json:"metrics,omitempty"`
+}
+
+// swagger:model
+type AveragedMeasurement struct {
+	// Start of the bucket.
+	// swagger:type string
+	Start time.Time `json:"start"`
+
+	// End of the bucket, exclusive.
+	// swagger:type string
+	End time.Time `json:"end"`
+
+	// Power represents the average power of the asset, null if the bucket had no measurements.
+	Power *Power `json:"power"`
+
+	// StateOfEnergy represents the average state of energy of the asset, null if the bucket had no measurements
+	// or the asset doesn't store energy.
+	StateOfEnergy *float64 `json:"stateOfEnergy"`
+
+	// Metrics represents the averages of the additional metrics of the asset, keyed by the metric name.
+	Metrics map[string]Metric `json:"metrics,omitempty"`
 }
 
 // swagger:model
@@ -27,22 +52,193 @@ type Power struct {
 	Unit string `json:"unit"`
 }
 
+// swagger:model
+type Metric struct {
+	// Value represents the value of the metric.
+	Value float64 `json:"value"`
+
+	// Unit represents the unit of the metric (W, kW, MW, Wh, kWh, MWh, V, A, Hz, °C, var, %).
+	Unit string `json:"unit"`
+}
+
+// swagger:parameters getLatestMeasurement
+type UnitParams struct {
+	// Unit the response is converted to. A unit of power converts the power and the power metrics,
+	// a unit of energy converts the energy metrics.
+	// required: false
+	// enum: W,kW,MW,Wh,kWh,MWh
+	Unit string `form:"unit" binding:"omitempty,oneof=W kW MW Wh kWh MWh"`
+}
+
 // swagger:parameters getMeasurementsAvgWithinTimeInterval
 type AssetMeasurementAveragedParams struct {
 	TimeRange
+	UnitParams
 	GroupBy string `form:"groupBy" binding:"required,oneof=minute hour 15min"`
-	Sort    string `form:"sort" binding:"required,oneof=asc desc"`
+
+	// Value the buckets are sorted by, defaults to the bucket start time
+	// required: false
+	// enum: time,power,stateOfEnergy
+	SortBy string `form:"sortBy" binding:"omitempty,oneof=time power stateOfEnergy"`
+
+	// Sort direction
+	// enum: asc,desc
+	Sort string `form:"sort" binding:"required,oneof=asc desc"`
+
+	// Fill the buckets without measurements. Unless none, every bucket within the time range is returned.
+	// required: false
+	// enum: none,null,previous,linear,zero
+	Fill string `form:"fill" binding:"omitempty,oneof=none null previous linear zero"`
 }
 
 func (a *AssetMeasurementAveragedParams) toDomainModel() measurements.AssetMeasurementAveragedParams {
 	return measurements.AssetMeasurementAveragedParams{
 		TimeRange: a.TimeRange.toDomainModel(),
 		GroupBy:   a.GroupBy,
+		SortBy:    measurements.SortKey(a.SortBy),
 		Sort:      a.Sort,
+		Fill:      measurements.FillMode(a.Fill),
 	}
 }
 
 // swagger:parameters getMeasurementsWithinTimeInterval
+type MeasurementsQuery struct {
+	TimeRange
+	UnitParams
+}
+
+// swagger:parameters importMeasurements
+type ImportParams struct {
+	// Content type of the import, application/x-ndjson or text/csv
+	// in: header
+	// required: true
+	ContentType string `json:"Content-Type"`
+
+	// NDJSON with a measurement per line, or CSV with a header row. The CSV columns are time (RFC 3339), id, power,
+	// powerUnit, stateOfEnergy and any additional metric with the unit in brackets, e.g. voltage[V].
+	// in: body
+	// required: true
+	Body string
+}
+
+// swagger:model
+type ImportResult struct {
+	// Accepted is the number of stored measurements.
+	Accepted int `json:"accepted"`
+
+	// Duplicates is the number of measurements that were already stored.
+	Duplicates int `json:"duplicates"`
+
+	// Rejected lists the rows that could not be imported.
+	Rejected []RejectedRow `json:"rejected"`
+}
+
+// ImportFailedResponse is returned when storing the import failed partway through. The result reports the
+// measurements stored before the failure, which are not rolled back.
+// swagger:model
+type ImportFailedResponse struct {
+	devxHttp.ErrorPayload
+
+	// Result of the batches stored before the failure
+	Result *measurements.ImportResult `json:"result"`
+}
+
+// swagger:model
+type RejectedRow struct {
+	// Line of the rejected row in the imported file.
+	Line int `json:"line"`
+
+	// Reason the row was rejected.
+	Reason string `json:"reason"`
+}
+
+// swagger:parameters getMeasurementCompleteness
+type CompletenessQuery struct {
+	TimeRange
+
+	// Size of the buckets, defaults to hour
+	// required: false
+	// enum: minute,15min,hour
+	GroupBy string `form:"groupBy" binding:"omitempty,oneof=minute hour 15min"`
+
+	// Gaps longer than the number of measurement intervals are reported, defaults to 3
+	// required: false
+	GapIntervals int `form:"gapIntervals" binding:"omitempty,min=1"`
+}
+
+func (c *CompletenessQuery) toDomainModel() measurements.CompletenessParams {
+	return measurements.CompletenessParams{
+		TimeRange:    c.TimeRange.toDomainModel(),
+		GroupBy:      c.GroupBy,
+		GapIntervals: c.GapIntervals,
+	}
+}
+
+// swagger:parameters getAssetActivity
+type AssetActivityQuery struct {
+	// Assets without a measurement for the number of measurement intervals are flagged as silent, defaults to 3
+	// required: false
+	GapIntervals int `form:"gapIntervals" binding:"omitempty,min=1"`
+}
+
+// swagger:model
+type CompletenessReport struct {
+	AssetId string `json:"assetId"`
+
+	// MeasurementIntervalSeconds is the measurement interval of the asset's simulator configuration in seconds.
+	MeasurementIntervalSeconds float64 `json:"measurementIntervalSeconds"`
+
+	// Expected is the number of measurements expected within the time range.
+	Expected int `json:"expected"`
+
+	// Received is the number of measurements received within the time range.
+	Received int `json:"received"`
+
+	Buckets []CompletenessBucket `json:"buckets"`
+
+	// Gaps lists the periods without measurements longer than the requested number of intervals.
+	Gaps []Gap `json:"gaps"`
+}
+
+// swagger:model
+type CompletenessBucket struct {
+	// swagger:type string
+	Start time.Time `json:"start"`
+
+	// swagger:type string
+	End time.Time `json:"end"`
+
+	Expected int `json:"expected"`
+	Received int `json:"received"`
+}
+
+// swagger:model
+type Gap struct {
+	// swagger:type string
+	From time.Time `json:"from"`
+
+	// swagger:type string
+	To time.Time `json:"to"`
+
+	// MissingSamples is the number of measurements expected within the gap.
+	MissingSamples int `json:"missingSamples"`
+}
+
+// swagger:model
+type AssetActivity struct {
+	AssetId string `json:"assetId"`
+
+	// MeasurementIntervalSeconds is the measurement interval of the asset's simulator configuration in seconds.
+	MeasurementIntervalSeconds float64 `json:"measurementIntervalSeconds"`
+
+	// LastMeasurement is the time of the latest measurement received within the last day.
+	// swagger:type string
+	LastMeasurement *time.Time `json:"lastMeasurement"`
+
+	// Silent is set if no measurement was received for the requested number of intervals.
+	Silent bool `json:"silent"`
+}
+
 type TimeRange struct {
 	From *time.Time `form:"from" binding:"required"`
 	To   *time.Time `form:"to" binding:"required"`
@@ -3,8 +3,11 @@ package http
 import (
 	"net/http"
 
+	"github.com/pkg/errors"
+
 	"asset-measurements-assignment/internal/domain/measurements"
 	"github.com/gin-gonic/gin"
+	devxHttp "github.com/xBlaz3kx/DevX/http"
 )
 
 type MeasurementsGinHandler struct {
@@ -15,11 +18,36 @@ func NewMeasurementsGinHandler(service measurements.Service) *MeasurementsGinHan
 	return &MeasurementsGinHandler{service: service}
 }
 
-func (d *MeasurementsGinHandler) RegisterRoutes(router *gin.Engine) {
+func (d *MeasurementsGinHandler) RegisterRoutes(router gin.IRouter) {
 	rg := router.Group("/assets/:assetId/measurements")
 	rg.GET("/latest", d.GetLatest)
 	rg.GET("/avg", d.GetAvgWithinTimeInterval)
 	rg.GET("", d.GetWithinTimeInterval)
+	rg.GET("/completeness", d.GetCompleteness)
+
+	// Gin can't escape the colon of the custom method, so it starts a parameter holding the method
+	router.POST("/assets/:assetId/measurements:method", d.customMethod(importMethod, d.Import))
+
+	// Fleet-wide measurement activity
+	router.GET("/measurements/activity", d.GetAssetActivity)
+}
+
+// maxImportSize limits the size of the import request body.
+const maxImportSize = 64 << 20
+
+// importMethod is the custom method importing the measurements, /assets/:assetId/measurements:import.
+const importMethod = ":import"
+
+// customMethod serves the custom method of the measurements, and responds with not found to any other path.
+func (d *MeasurementsGinHandler) customMethod(method string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Param("method") != method {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		handler(ctx)
+	}
 }
 
 // swagger:route GET /assets/{assetId}/measurements/latest measurements getLatestMeasurement
@@ -34,12 +62,27 @@ func (d *MeasurementsGinHandler) GetLatest(ctx *gin.Context) {
 	reqCtx := ctx.Request.Context()
 	assetId := ctx.Param("assetId")
 
+	var query UnitParams
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
 	assetMeasurement, err := d.service.GetLatestAssetMeasurement(reqCtx, assetId)
 	if err != nil {
 		_ = ctx.Error(err)
 		return
 	}
 
+	if query.Unit != "" {
+		converted, err := assetMeasurement.ConvertTo(measurements.Unit(query.Unit))
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		assetMeasurement = &converted
+	}
+
 	ctx.JSON(http.StatusOK, assetMeasurement)
 }
 
@@ -48,7 +91,7 @@ func (d *MeasurementsGinHandler) GetLatest(ctx *gin.Context) {
 // ---
 // responses:
 //
-//	200: []Measurement
+//	200: []AveragedMeasurement
 //	400: errorResponse
 //	500: errorResponse
 func (d *MeasurementsGinHandler) GetAvgWithinTimeInterval(ctx *gin.Context) {
@@ -67,6 +110,12 @@ func (d *MeasurementsGinHandler) GetAvgWithinTimeInterval(ctx *gin.Context) {
 		return
 	}
 
+	assetMeasurementsAveraged, err = measurements.ConvertAveragedMeasurements(assetMeasurementsAveraged, measurements.Unit(query.Unit))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, assetMeasurementsAveraged)
 }
 
@@ -82,13 +131,19 @@ func (d *MeasurementsGinHandler) GetWithinTimeInterval(ctx *gin.Context) {
 	reqCtx := ctx.Request.Context()
 	assetId := ctx.Param("assetId")
 
-	var query TimeRange
+	var query MeasurementsQuery
 	if err := ctx.ShouldBindQuery(&query); err != nil {
 		ctx.JSON(badRequest(err))
 		return
 	}
 
-	assetMeasurements, err := d.service.GetAssetMeasurements(reqCtx, assetId, query.toDomainModel())
+	assetMeasurements, err := d.service.GetAssetMeasurements(reqCtx, assetId, query.TimeRange.toDomainModel())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	assetMeasurements, err = measurements.ConvertMeasurements(assetMeasurements, measurements.Unit(query.Unit))
 	if err != nil {
 		_ = ctx.Error(err)
 		return
@@ -96,3 +151,113 @@ func (d *MeasurementsGinHandler) GetWithinTimeInterval(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, assetMeasurements)
 }
+
+// swagger:route POST /assets/{assetId}/measurements:import measurements importMeasurements
+// Import historical measurements for a given asset.
+// ---
+// consumes:
+//   - application/x-ndjson
+//   - text/csv
+//
+// responses:
+//
+//	200: ImportResult
+//	400: errorResponse
+//	404: errorResponse
+//	413: errorResponse
+//	415: errorResponse
+//	500: ImportFailedResponse
+func (d *MeasurementsGinHandler) Import(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	assetId := ctx.Param("assetId")
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	rows, err := measurements.ParseImport(ctx.GetHeader("Content-Type"), body)
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		_ = ctx.Error(measurements.ErrImportTooLarge)
+		return
+	case errors.Is(err, measurements.ErrUnsupportedImportFormat), errors.Is(err, measurements.ErrImportTooLarge):
+		_ = ctx.Error(err)
+		return
+	case err != nil:
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	result, err := d.service.ImportAssetMeasurements(reqCtx, assetId, rows)
+	switch {
+	case err != nil && result != nil:
+		// The batches stored before the failure are kept, so the client has to know which ones they were
+		ctx.JSON(http.StatusInternalServerError, ImportFailedResponse{
+			ErrorPayload: devxHttp.ErrorPayload{
+				Error:       "import failed",
+				Code:        00002,
+				Description: "The measurements in the result were stored before the failure, import the remaining rows again",
+			},
+			Result: result,
+		})
+		return
+	case err != nil:
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /assets/{assetId}/measurements/completeness measurements getMeasurementCompleteness
+// Get the expected and received number of measurements per bucket and the gaps in the measurements of a given asset.
+// ---
+// responses:
+//
+//	200: CompletenessReport
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+func (d *MeasurementsGinHandler) GetCompleteness(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	assetId := ctx.Param("assetId")
+
+	var query CompletenessQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	report, err := d.service.GetAssetCompleteness(reqCtx, assetId, query.toDomainModel())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// swagger:route GET /measurements/activity measurements getAssetActivity
+// Get the latest measurement of the enabled assets and flag the assets that went silent.
+// ---
+// responses:
+//
+//	200: []AssetActivity
+//	400: errorResponse
+//	500: errorResponse
+func (d *MeasurementsGinHandler) GetAssetActivity(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	var query AssetActivityQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	activity, err := d.service.GetAssetActivity(reqCtx, query.GapIntervals)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, activity)
+}
@@ -1,14 +1,24 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	domainMeasurements "asset-measurements-assignment/internal/domain/measurements"
 	measurements "asset-measurements-assignment/internal/domain/measurements/mocks"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	devxHttp "github.com/xBlaz3kx/DevX/http"
+	"github.com/xBlaz3kx/DevX/observability"
 )
 
 type measurementsHandlerTestSuite struct {
@@ -210,7 +220,217 @@ func (s *measurementsHandlerTestSuite) TestGetWithinTimeInterval() {
 	}
 }
 
+func TestMeasurementsHandler_UnitConversion(t *testing.T) {
+	stateOfEnergy := 50.0
+	tests := []struct {
+		name         string
+		unit         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Default unit",
+			unit:         "",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"power":{"value":1500,"unit":"W"},"stateOfEnergy":50,` +
+				`"metrics":{"energy":{"value":2500,"unit":"Wh"}},"time":"2024-10-01T12:00:00Z"}`,
+		},
+		{
+			name:         "Kilowatts",
+			unit:         "kW",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"power":{"value":1.5,"unit":"kW"},"stateOfEnergy":50,` +
+				`"metrics":{"energy":{"value":2500,"unit":"Wh"}},"time":"2024-10-01T12:00:00Z"}`,
+		},
+		{
+			name:         "Kilowatt hours",
+			unit:         "kWh",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"power":{"value":1500,"unit":"W"},"stateOfEnergy":50,` +
+				`"metrics":{"energy":{"value":2.5,"unit":"kWh"}},"time":"2024-10-01T12:00:00Z"}`,
+		},
+		{
+			name:         "Unsupported unit",
+			unit:         "hp",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := measurements.NewMockService(t)
+			router := gin.New()
+			NewMeasurementsGinHandler(service).RegisterRoutes(router)
+
+			if tt.expectedCode == http.StatusOK {
+				service.EXPECT().GetLatestAssetMeasurement(mock.Anything, "1").Return(&domainMeasurements.Measurement{
+					Power:         domainMeasurements.Power{Value: 1500, Unit: domainMeasurements.UnitWatt},
+					StateOfEnergy: &stateOfEnergy,
+					Metrics: domainMeasurements.Metrics{
+						"energy": {Value: 2500, Unit: domainMeasurements.UnitWattHour},
+					},
+					Time: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+				}, nil).Once()
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/assets/1/measurements/latest?unit="+tt.unit, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestMeasurementsHandler(t *testing.T) {
 	t.Skip("Skip test")
 	suite.Run(t, new(measurementsHandlerTestSuite))
 }
+
+func TestMeasurementsHandler_Import(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		contentType  string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "Import NDJSON",
+			path:         "/assets/1/measurements:import",
+			contentType:  "application/x-ndjson",
+			body:         `{"time":"2024-10-01T12:00:00Z","power":{"value":1500,"unit":"W"},"stateOfEnergy":50}` + "\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Import CSV",
+			path:         "/assets/1/measurements:import",
+			contentType:  "text/csv",
+			body:         "time,power,stateOfEnergy\n2024-10-01T12:00:00Z,1500,50\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid CSV header",
+			path:         "/assets/1/measurements:import",
+			contentType:  "text/csv",
+			body:         "power,stateOfEnergy\n1500,50\n",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unsupported content type",
+			path:         "/assets/1/measurements:import",
+			contentType:  "application/xml",
+			body:         "<measurements/>",
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:         "Unknown custom method",
+			path:         "/assets/1/measurements:export",
+			contentType:  "text/csv",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Import is a custom method",
+			path:         "/assets/1/measurements/import",
+			contentType:  "text/csv",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Import failed partway through",
+			path:         "/assets/1/measurements:import",
+			contentType:  "text/csv",
+			body:         "time,power,stateOfEnergy\n2024-10-01T12:00:00Z,1500,50\n",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := measurements.NewMockService(t)
+			// The server router maps the API errors to responses
+			router := devxHttp.NewServer(devxHttp.Configuration{}, observability.NewNoopObservability()).Router()
+			NewMeasurementsGinHandler(service).RegisterRoutes(router)
+
+			if tt.expectedCode == http.StatusOK {
+				service.EXPECT().ImportAssetMeasurements(mock.Anything, "1", mock.Anything).
+					RunAndReturn(func(_ context.Context, _ string, rows []domainMeasurements.ImportRow) (*domainMeasurements.ImportResult, error) {
+						assert.Len(t, rows, 1)
+						assert.NoError(t, rows[0].Err)
+						assert.Equal(t, 1500.0, rows[0].Measurement.Power.Value)
+						return &domainMeasurements.ImportResult{Accepted: 1, Rejected: []domainMeasurements.RejectedRow{}}, nil
+					}).Once()
+			}
+
+			if tt.name == "Import failed partway through" {
+				service.EXPECT().ImportAssetMeasurements(mock.Anything, "1", mock.Anything).
+					Return(&domainMeasurements.ImportResult{Accepted: 1, Rejected: []domainMeasurements.RejectedRow{}}, errors.New("db error")).Once()
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			switch tt.expectedCode {
+			case http.StatusOK:
+				assert.JSONEq(t, `{"accepted":1,"duplicates":0,"rejected":[]}`, w.Body.String())
+			case http.StatusInternalServerError:
+				// The partial result is returned with the error
+				var response ImportFailedResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 1, response.Result.Accepted)
+			}
+		})
+	}
+}
+
+func TestMeasurementsHandler_Completeness(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "Asset completeness",
+			path:         "/assets/1/measurements/completeness?from=2024-10-01T10:00:00Z&to=2024-10-01T12:00:00Z&gapIntervals=5",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid groupBy",
+			path:         "/assets/1/measurements/completeness?from=2024-10-01T10:00:00Z&to=2024-10-01T12:00:00Z&groupBy=day",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Asset activity",
+			path:         "/measurements/activity?gapIntervals=5",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := measurements.NewMockService(t)
+			router := gin.New()
+			NewMeasurementsGinHandler(service).RegisterRoutes(router)
+
+			switch tt.name {
+			case "Asset completeness":
+				service.EXPECT().GetAssetCompleteness(mock.Anything, "1", mock.MatchedBy(func(params domainMeasurements.CompletenessParams) bool {
+					return params.GapIntervals == 5 && params.From != nil && params.To != nil
+				})).Return(&domainMeasurements.CompletenessReport{AssetId: "1"}, nil).Once()
+			case "Asset activity":
+				service.EXPECT().GetAssetActivity(mock.Anything, 5).Return([]domainMeasurements.AssetActivity{}, nil).Once()
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package inprocess
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements/service"
+	"asset-measurements-assignment/internal/pkg/infrastructure/bus"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const measurementRoutingKey = "measurement"
+
+// Handler consumes the measurements from the in-process bus.
+type Handler struct {
+	obs     observability.Observability
+	bus     *bus.Bus
+	service service.ConsumerService
+}
+
+func NewHandler(obs observability.Observability, bus *bus.Bus, service service.ConsumerService) *Handler {
+	return &Handler{
+		obs:     obs.WithSpanKind(trace.SpanKindConsumer),
+		bus:     bus,
+		service: service,
+	}
+}
+
+// Start subscribes to the measurements. Messages are consumed until the bus is closed.
+func (h *Handler) Start(ctx context.Context) error {
+	return h.bus.Subscribe(ctx, measurementRoutingKey, h.handleMeasurement, func(err error) {
+		h.obs.Log().Warn("Failed to consume measurement", zap.Error(err))
+	})
+}
+
+// handleMeasurement decodes the message and stores the measurement. Invalid messages are discarded and measurements
+// of disabled assets are skipped.
+func (h *Handler) handleMeasurement(ctx context.Context, message messages.Message) error {
+	consumeCtx, cancel, logger := h.obs.LogSpanWithTimeout(ctx, "measurement.consumer.Handle", time.Second*10)
+	defer cancel()
+	logger.Debug("Consuming measurement")
+
+	assetID, measurement, err := messages.DecodeMessage(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.service.AddMeasurement(consumeCtx, assetID, *measurement)
+	return err
+}
@@ -0,0 +1,58 @@
+package inprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/service"
+	serviceMock "asset-measurements-assignment/internal/domain/measurements/service/mocks"
+	"asset-measurements-assignment/internal/pkg/infrastructure/bus"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestHandler_Start(t *testing.T) {
+	obs := observability.NewNoopObservability()
+	ctx := context.Background()
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stateOfEnergy := 50.0
+
+	for _, encoding := range []messages.Encoding{messages.EncodingJSON, messages.EncodingProtobuf} {
+		t.Run(string(encoding), func(t *testing.T) {
+			measurementBus := bus.New()
+
+			consumerService := serviceMock.NewMockConsumerService(t)
+			consumerService.EXPECT().AddMeasurement(mock.Anything, "1", mock.MatchedBy(func(m measurements.Measurement) bool {
+				return m.Id != "" && m.Time.Equal(at) && m.Power.Value == 1000 && *m.StateOfEnergy == 50
+			})).Return(service.OutcomeStored, nil).Twice()
+
+			handler := &Handler{
+				obs:     obs,
+				bus:     measurementBus,
+				service: consumerService,
+			}
+			assert.NoError(t, handler.Start(ctx))
+
+			encoder := messages.NewMeasurementEncoder(encoding, messages.Producer{Name: "simulator"})
+			measurement := measurements.Measurement{
+				Time:          at,
+				Power:         measurements.Power{Value: 1000, Unit: measurements.UnitWatt},
+				StateOfEnergy: &stateOfEnergy,
+			}
+			for i := 0; i < 2; i++ {
+				message, err := encoder.Encode("1", measurement)
+				assert.NoError(t, err)
+				assert.NoError(t, measurementBus.Publish(ctx, measurementRoutingKey, *message))
+			}
+
+			// Invalid messages are discarded
+			assert.NoError(t, measurementBus.Publish(ctx, measurementRoutingKey, messages.Message{ContentType: "text/plain"}))
+
+			measurementBus.Close()
+		})
+	}
+}
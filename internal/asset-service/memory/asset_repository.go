@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"asset-measurements-assignment/internal/domain/assets"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// AssetRepository keeps the assets in memory. It is meant for tests and demos.
+type AssetRepository struct {
+	obs observability.Observability
+
+	mu     sync.RWMutex
+	assets map[string]assets.Asset
+}
+
+func NewAssetRepository(obs observability.Observability) *AssetRepository {
+	return &AssetRepository{
+		obs:    obs,
+		assets: map[string]assets.Asset{},
+	}
+}
+
+// CreateAsset stores the asset with a new ID. Asset names are unique.
+func (a *AssetRepository) CreateAsset(ctx context.Context, asset assets.Asset) (*assets.Asset, error) {
+	_, cancel := a.obs.Span(ctx, "asset.repository.CreateAsset", zap.Any("asset", asset))
+	defer cancel()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.nameTaken(asset.Name, "") {
+		return nil, assets.ErrAssetAlreadyExists
+	}
+
+	asset.ID = uuid.New().String()
+	a.assets[asset.ID] = asset
+
+	return &asset, nil
+}
+
+// UpdateAsset replaces the asset.
+func (a *AssetRepository) UpdateAsset(ctx context.Context, assetId string, asset assets.Asset) (*assets.Asset, error) {
+	_, cancel := a.obs.Span(ctx, "asset.repository.UpdateAsset", zap.String("assetId", assetId))
+	defer cancel()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.assets[assetId]; !ok {
+		return nil, assets.ErrAssetNotFound
+	}
+
+	if a.nameTaken(asset.Name, assetId) {
+		return nil, assets.ErrAssetAlreadyExists
+	}
+
+	asset.ID = assetId
+	a.assets[assetId] = asset
+
+	return &asset, nil
+}
+
+// nameTaken checks if another asset than the excluded one has the name.
+func (a *AssetRepository) nameTaken(name, excludedId string) bool {
+	for id, asset := range a.assets {
+		if id != excludedId && asset.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AssetRepository) DeleteAsset(ctx context.Context, assetId string) error {
+	_, cancel := a.obs.Span(ctx, "asset.repository.DeleteAsset", zap.String("assetId", assetId))
+	defer cancel()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.assets[assetId]; !ok {
+		return assets.ErrAssetNotFound
+	}
+
+	delete(a.assets, assetId)
+	return nil
+}
+
+func (a *AssetRepository) GetAsset(ctx context.Context, assetId string) (*assets.Asset, error) {
+	_, cancel := a.obs.Span(ctx, "asset.repository.GetAsset", zap.String("assetId", assetId))
+	defer cancel()
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	asset, ok := a.assets[assetId]
+	if !ok {
+		return nil, assets.ErrAssetNotFound
+	}
+
+	return &asset, nil
+}
+
+// GetAssets returns the assets matching the query, ordered by name.
+func (a *AssetRepository) GetAssets(ctx context.Context, query assets.AssetQuery) ([]assets.Asset, error) {
+	_, cancel := a.obs.Span(ctx, "asset.repository.GetAssets", zap.Any("query", query))
+	defer cancel()
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var result []assets.Asset
+	for _, asset := range a.assets {
+		if query.Enabled != nil && asset.Enabled != *query.Enabled {
+			continue
+		}
+
+		if query.Type != nil && string(asset.Type) != *query.Type {
+			continue
+		}
+
+		result = append(result, asset)
+	}
+
+	slices.SortFunc(result, func(a, b assets.Asset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
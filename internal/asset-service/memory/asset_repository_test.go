@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/assets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestAssetRepository(t *testing.T) {
+	repository := NewAssetRepository(observability.NewNoopObservability())
+	ctx := context.Background()
+
+	solar, err := repository.CreateAsset(ctx, assets.Asset{Name: "solar", Type: domain.AssetTypeSolar, Enabled: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, solar.ID)
+
+	battery, err := repository.CreateAsset(ctx, assets.Asset{Name: "battery", Type: domain.AssetTypeBattery})
+	require.NoError(t, err)
+
+	_, err = repository.CreateAsset(ctx, assets.Asset{Name: "solar", Type: domain.AssetTypeSolar})
+	assert.ErrorIs(t, err, assets.ErrAssetAlreadyExists)
+
+	_, err = repository.UpdateAsset(ctx, battery.ID, assets.Asset{Name: "solar", Type: domain.AssetTypeBattery})
+	assert.ErrorIs(t, err, assets.ErrAssetAlreadyExists)
+
+	_, err = repository.UpdateAsset(ctx, "missing", assets.Asset{Name: "missing"})
+	assert.ErrorIs(t, err, assets.ErrAssetNotFound)
+
+	updated, err := repository.UpdateAsset(ctx, battery.ID, assets.Asset{Name: "battery", Type: domain.AssetTypeBattery, Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, battery.ID, updated.ID)
+
+	all, err := repository.GetAssets(ctx, assets.AssetQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []assets.Asset{*updated, *solar}, all)
+
+	solarType := string(domain.AssetTypeSolar)
+	filtered, err := repository.GetAssets(ctx, assets.AssetQuery{Type: &solarType})
+	require.NoError(t, err)
+	assert.Equal(t, []assets.Asset{*solar}, filtered)
+
+	require.NoError(t, repository.DeleteAsset(ctx, solar.ID))
+	assert.ErrorIs(t, repository.DeleteAsset(ctx, solar.ID), assets.ErrAssetNotFound)
+
+	_, err = repository.GetAsset(ctx, solar.ID)
+	assert.ErrorIs(t, err, assets.ErrAssetNotFound)
+}
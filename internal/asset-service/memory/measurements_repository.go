@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// MeasurementsRepository keeps the measurements in memory. It is meant for tests and demos,
+// as the measurements are lost when the service stops.
+type MeasurementsRepository struct {
+	obs observability.Observability
+
+	mu sync.RWMutex
+	// Measurements of each asset, ordered by time
+	measurements map[string][]measurements.Measurement
+	ids          map[string]struct{}
+}
+
+func NewMeasurementsRepository(obs observability.Observability) *MeasurementsRepository {
+	return &MeasurementsRepository{
+		obs:          obs,
+		measurements: map[string][]measurements.Measurement{},
+		ids:          map[string]struct{}{},
+	}
+}
+
+// AddMeasurement stores the measurement. If a measurement with the same ID was already stored, ErrDuplicateMeasurement is returned.
+func (m *MeasurementsRepository) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurement", zap.Any("measurement", measurement))
+	defer cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.addMeasurement(assetId, measurement)
+}
+
+// AddMeasurements stores the measurements, skipping the duplicates. It returns the number of skipped duplicates.
+func (m *MeasurementsRepository) AddMeasurements(ctx context.Context, assetId string, measurementsToAdd []measurements.Measurement) (int, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurements", zap.String("assetId", assetId), zap.Int("measurements", len(measurementsToAdd)))
+	defer cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	duplicates := 0
+	for _, measurement := range measurementsToAdd {
+		if m.addMeasurement(assetId, measurement) != nil {
+			duplicates++
+		}
+	}
+
+	return duplicates, nil
+}
+
+// addMeasurement stores the measurement, the lock must be held.
+func (m *MeasurementsRepository) addMeasurement(assetId string, measurement measurements.Measurement) error {
+	if measurement.Id != "" {
+		if _, ok := m.ids[measurement.Id]; ok {
+			return measurements.ErrDuplicateMeasurement
+		}
+		m.ids[measurement.Id] = struct{}{}
+	}
+
+	// Keep the measurements ordered by time, measurements with the same time in the order they were added
+	assetMeasurements := m.measurements[assetId]
+	i, _ := slices.BinarySearchFunc(assetMeasurements, measurement.Time, func(stored measurements.Measurement, t time.Time) int {
+		if stored.Time.After(t) {
+			return 1
+		}
+		return -1
+	})
+	m.measurements[assetId] = slices.Insert(assetMeasurements, i, measurement)
+
+	return nil
+}
+
+func (m *MeasurementsRepository) GetLatestAssetMeasurement(ctx context.Context, assetID string) (*measurements.Measurement, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetLatestAssetMeasurement", zap.String("assetID", assetID))
+	defer cancel()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	assetMeasurements := m.measurements[assetID]
+	if len(assetMeasurements) == 0 {
+		return nil, measurements.ErrMeasurementNotFound
+	}
+
+	latest := assetMeasurements[len(assetMeasurements)-1]
+	return &latest, nil
+}
+
+// GetAssetMeasurements returns the measurements of the asset within the time range, latest first.
+func (m *MeasurementsRepository) GetAssetMeasurements(ctx context.Context, assetID string, timeRange measurements.TimeRange) ([]measurements.Measurement, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurements", zap.String("assetID", assetID))
+	defer cancel()
+
+	result := m.inTimeRange(assetID, timeRange)
+	slices.Reverse(result)
+
+	return result, nil
+}
+
+// inTimeRange returns a copy of the measurements of the asset within the time range, ordered by time.
+func (m *MeasurementsRepository) inTimeRange(assetID string, timeRange measurements.TimeRange) []measurements.Measurement {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []measurements.Measurement{}
+	for _, measurement := range m.measurements[assetID] {
+		if timeRange.From != nil && measurement.Time.Before(*timeRange.From) {
+			continue
+		}
+
+		if timeRange.To != nil && measurement.Time.After(*timeRange.To) {
+			continue
+		}
+
+		result = append(result, measurement)
+	}
+
+	return result
+}
+
+// GetAssetMeasurementsAveraged averages the power, state of energy and any additional metrics of the asset
+// within the time buckets. Each metric is averaged only over the measurements that contain it.
+func (m *MeasurementsRepository) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementsAveraged", zap.String("assetID", assetID), zap.Any("params", params))
+	defer cancel()
+
+	interval, err := measurements.GroupByInterval(params.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = measurements.ValidateSort(params.SortBy, params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	type sum struct {
+		value float64
+		count int
+		unit  measurements.Unit
+	}
+
+	buckets := []time.Time{}
+	sums := map[time.Time]map[string]*sum{}
+	for _, measurement := range m.inTimeRange(assetID, params.TimeRange) {
+		bucket := measurement.Time.UTC().Truncate(interval)
+		if sums[bucket] == nil {
+			sums[bucket] = map[string]*sum{}
+			buckets = append(buckets, bucket)
+		}
+
+		for name, metric := range measurements.FlattenMetrics(measurement) {
+			metricSum, ok := sums[bucket][name]
+			if !ok {
+				metricSum = &sum{unit: metric.Unit}
+				sums[bucket][name] = metricSum
+			}
+
+			metricSum.value += metric.Value
+			metricSum.count++
+		}
+	}
+
+	result := make([]measurements.Measurement, len(buckets))
+	for i, bucket := range buckets {
+		metrics := measurements.Metrics{}
+		for name, metricSum := range sums[bucket] {
+			metrics[name] = measurements.Metric{Value: metricSum.value / float64(metricSum.count), Unit: metricSum.unit}
+		}
+
+		result[i] = measurements.MeasurementFromMetrics(bucket, metrics)
+	}
+
+	measurements.SortMeasurements(result, params.SortBy, params.Sort)
+	return result, nil
+}
+
+// GetAssetMeasurementCounts counts the measurements of the asset within the time buckets. Buckets without measurements are omitted.
+func (m *MeasurementsRepository) GetAssetMeasurementCounts(ctx context.Context, assetID string, timeRange measurements.TimeRange, groupBy string) ([]measurements.BucketCount, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementCounts", zap.String("assetID", assetID))
+	defer cancel()
+
+	interval, err := measurements.GroupByInterval(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Measurements are ordered by time, so are the buckets
+	counts := []measurements.BucketCount{}
+	for _, measurement := range m.inTimeRange(assetID, timeRange) {
+		bucket := measurement.Time.UTC().Truncate(interval)
+		if len(counts) == 0 || !counts[len(counts)-1].Time.Equal(bucket) {
+			counts = append(counts, measurements.BucketCount{Time: bucket})
+		}
+		counts[len(counts)-1].Count++
+	}
+
+	return counts, nil
+}
+
+// GetAssetMeasurementGaps returns the periods within the time range longer than minGap without measurements of the asset,
+// including the periods between the start of the range and the first measurement and between the last measurement and the end of the range.
+func (m *MeasurementsRepository) GetAssetMeasurementGaps(ctx context.Context, assetID string, timeRange measurements.TimeRange, minGap time.Duration) ([]measurements.Gap, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementGaps", zap.String("assetID", assetID))
+	defer cancel()
+
+	assetMeasurements := m.inTimeRange(assetID, timeRange)
+
+	gaps := []measurements.Gap{}
+	for i := 1; i < len(assetMeasurements); i++ {
+		from, to := assetMeasurements[i-1].Time, assetMeasurements[i].Time
+		if to.Sub(from) > minGap {
+			gaps = append(gaps, measurements.Gap{From: from, To: to})
+		}
+	}
+
+	if len(assetMeasurements) == 0 {
+		return measurements.AddEdgeGaps(gaps, timeRange, nil, nil, minGap), nil
+	}
+
+	first := assetMeasurements[0].Time
+	last := assetMeasurements[len(assetMeasurements)-1].Time
+	return measurements.AddEdgeGaps(gaps, timeRange, &first, &last, minGap), nil
+}
+
+// GetLatestMeasurementTimes returns the time of the latest measurement of each asset with measurements since the given time.
+func (m *MeasurementsRepository) GetLatestMeasurementTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	_, cancel := m.obs.Span(ctx, "measurements.repository.GetLatestMeasurementTimes", zap.Time("since", since))
+	defer cancel()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := map[string]time.Time{}
+	for assetID, assetMeasurements := range m.measurements {
+		if len(assetMeasurements) == 0 {
+			continue
+		}
+
+		latest := assetMeasurements[len(assetMeasurements)-1].Time
+		if !latest.Before(since) {
+			result[assetID] = latest
+		}
+	}
+
+	return result, nil
+}
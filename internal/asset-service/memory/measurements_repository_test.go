@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/repositorytest"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestMeasurementsRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) measurements.Repository {
+		return NewMeasurementsRepository(observability.NewNoopObservability())
+	})
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"asset-measurements-assignment/internal/domain/measurements"
@@ -14,49 +15,259 @@ import (
 	"go.uber.org/zap"
 )
 
-const collectionName = "asset_measurements"
+const (
+	collectionName           = "asset_measurements"
+	measurementIdsCollection = "asset_measurement_ids"
+
+	// releaseTimeout limits releasing the ID of a measurement that failed to be stored
+	releaseTimeout = time.Second * 5
+)
 
 // Measurement mongo entity
 type Measurement struct {
-	AssetID       string             `bson:"assetId"`
-	Timestamp     time.Time          `bson:"timestamp"`
-	Power         measurements.Power `bson:"power"`
-	StateOfEnergy float64            `bson:"stateOfEnergy"`
+	MeasurementID string               `bson:"measurementId,omitempty"`
+	AssetID       string               `bson:"assetId"`
+	Timestamp     time.Time            `bson:"timestamp"`
+	Power         measurements.Power   `bson:"power"`
+	StateOfEnergy *float64             `bson:"stateOfEnergy,omitempty"`
+	Metrics       measurements.Metrics `bson:"metrics,omitempty"`
+}
+
+// measurementId mongo entity, used to enforce uniqueness of measurements,
+// as time series collections do not support unique indexes.
+type measurementId struct {
+	ID        string    `bson:"_id"`
+	AssetID   string    `bson:"assetId"`
+	Timestamp time.Time `bson:"timestamp"`
+	CreatedAt time.Time `bson:"createdAt"`
 }
 
 type MeasurementsRepository struct {
-	obs        observability.Observability
-	collection *mongo.Collection
+	obs             observability.Observability
+	retention       RetentionConfig
+	collection      *mongo.Collection
+	idsCollection   *mongo.Collection
+	stateCollection *mongo.Collection
+	rollups         *rollupJob
 }
 
-func NewMeasurementsRepository(obs observability.Observability, client *mongo.Database) (*MeasurementsRepository, error) {
+func NewMeasurementsRepository(obs observability.Observability, client *mongo.Database, retention RetentionConfig) (*MeasurementsRepository, error) {
+	err := retention.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	tso := options.TimeSeries().
 		SetTimeField("timestamp").
 		SetMetaField("assetId").
 		SetGranularity("seconds")
 	opts := options.CreateCollection().SetTimeSeriesOptions(tso)
+	if retention.Measurements > 0 {
+		opts.SetExpireAfterSeconds(int64(retention.Measurements.Seconds()))
+	}
 
 	// We will just ignore the error for now :)
 	_ = client.CreateCollection(context.Background(), collectionName, opts)
 
+	// Apply the retention in case the collection already existed
+	err = setMeasurementsRetention(context.Background(), client, retention.Measurements)
+	if err != nil {
+		return nil, err
+	}
+
+	// Measurement IDs expire after the deduplication window
+	idsCollection := client.Collection(measurementIdsCollection)
+	_, err = idsCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(measurements.DeduplicationWindow.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if retention.Rollups.Enabled {
+		err = createRollupCollections(context.Background(), client, retention.Rollups.Retention)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &MeasurementsRepository{
-		obs:        obs,
-		collection: client.Collection(collectionName),
+		obs:             obs,
+		retention:       retention,
+		collection:      client.Collection(collectionName),
+		idsCollection:   idsCollection,
+		stateCollection: client.Collection(rollupStateCollection),
 	}, nil
 }
 
+// AddMeasurement stores the measurement. If the measurement has an ID, the ID is first reserved in the
+// measurement IDs collection, which has a unique _id. If the ID was already reserved, the measurement is
+// a duplicate and ErrDuplicateMeasurement is returned.
+//
+// Time series collections support neither unique indexes nor writes in transactions, so the reservation and the
+// insert can't be done atomically. If the insert fails, the ID is released even if the context was cancelled,
+// so the measurement is stored when it is redelivered.
+//
+// A measurement arriving after its buckets may have been rolled up is rolled up again.
 func (m *MeasurementsRepository) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error {
 	ctx, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurement", zap.Any("measurement", measurement))
 	defer cancel()
 
+	if measurement.Id != "" {
+		err := m.reserveMeasurementId(ctx, assetId, measurement)
+		if err != nil {
+			return err
+		}
+	}
+
 	dbMeasurement := fromMeasurement(assetId, &measurement)
 	res, err := m.collection.InsertOne(ctx, dbMeasurement)
+	if err == nil && !res.Acknowledged {
+		err = errors.New("insert not acknowledged")
+	}
+
 	if err != nil {
+		if measurement.Id != "" {
+			return errors.Join(err, m.releaseMeasurementId(ctx, measurement.Id))
+		}
 		return err
 	}
 
-	if !res.Acknowledged {
-		return errors.New("insert not acknowledged")
+	m.refreshLateRollups(ctx, assetId, measurement.Time)
+	return nil
+}
+
+// releaseMeasurementId removes the reserved measurement ID. The context is detached from the cancellation of the
+// caller, as the insert usually fails because the caller's context was cancelled.
+func (m *MeasurementsRepository) releaseMeasurementId(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	_, err := m.idsCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to release measurement id %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// releaseMeasurementIds removes the reserved measurement IDs, see releaseMeasurementId.
+func (m *MeasurementsRepository) releaseMeasurementIds(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	_, err := m.idsCollection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return fmt.Errorf("failed to release %d measurement ids: %w", len(ids), err)
+	}
+
+	return nil
+}
+
+// AddMeasurements stores the measurements in a batch. The IDs of the measurements are reserved with one unordered insert,
+// and the measurements whose IDs were already reserved are skipped as duplicates. If storing the measurements fails,
+// the reserved IDs are released, as in AddMeasurement.
+func (m *MeasurementsRepository) AddMeasurements(ctx context.Context, assetId string, measurementsToAdd []measurements.Measurement) (int, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurements", zap.String("assetId", assetId), zap.Int("measurements", len(measurementsToAdd)))
+	defer cancel()
+
+	distinct, duplicates := measurements.DistinctMeasurements(measurementsToAdd)
+
+	reserved, err := m.reserveMeasurementIds(ctx, assetId, distinct)
+	if err != nil {
+		return 0, err
+	}
+
+	dbMeasurements := make([]*Measurement, 0, len(distinct))
+	for _, measurement := range distinct {
+		if _, ok := reserved[measurement.Id]; measurement.Id != "" && !ok {
+			duplicates++
+			continue
+		}
+
+		dbMeasurements = append(dbMeasurements, fromMeasurement(assetId, &measurement))
+	}
+
+	if len(dbMeasurements) == 0 {
+		return duplicates, nil
+	}
+
+	_, err = m.collection.InsertMany(ctx, dbMeasurements)
+	if err != nil {
+		ids := make([]string, 0, len(reserved))
+		for id := range reserved {
+			ids = append(ids, id)
+		}
+		return 0, errors.Join(err, m.releaseMeasurementIds(ctx, ids))
+	}
+
+	return duplicates, nil
+}
+
+// reserveMeasurementIds inserts the IDs of the measurements into the measurement IDs collection and returns the
+// reserved IDs. The IDs which were already reserved are left out.
+func (m *MeasurementsRepository) reserveMeasurementIds(ctx context.Context, assetId string, measurementsToReserve []measurements.Measurement) (map[string]struct{}, error) {
+	ids := []measurementId{}
+	for _, measurement := range measurementsToReserve {
+		if measurement.Id != "" {
+			ids = append(ids, measurementId{
+				ID:        measurement.Id,
+				AssetID:   assetId,
+				Timestamp: measurement.Time,
+				CreatedAt: time.Now(),
+			})
+		}
+	}
+
+	reserved := make(map[string]struct{}, len(ids))
+	if len(ids) == 0 {
+		return reserved, nil
+	}
+
+	// Continue after the duplicates, so all the new IDs are reserved
+	_, err := m.idsCollection.InsertMany(ctx, ids, options.InsertMany().SetOrdered(false))
+
+	failed := map[int]struct{}{}
+	var bulkErr mongo.BulkWriteException
+	switch {
+	case errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil:
+		for _, writeErr := range bulkErr.WriteErrors {
+			if !mongo.IsDuplicateKeyError(writeErr.WriteError) {
+				return nil, err
+			}
+			failed[writeErr.Index] = struct{}{}
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	for i, id := range ids {
+		if _, ok := failed[i]; !ok {
+			reserved[id.ID] = struct{}{}
+		}
+	}
+
+	return reserved, nil
+}
+
+// reserveMeasurementId inserts the measurement ID into the measurement IDs collection.
+func (m *MeasurementsRepository) reserveMeasurementId(ctx context.Context, assetId string, measurement measurements.Measurement) error {
+	_, err := m.idsCollection.InsertOne(ctx, measurementId{
+		ID:        measurement.Id,
+		AssetID:   assetId,
+		Timestamp: measurement.Time,
+		CreatedAt: time.Now(),
+	})
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return measurements.ErrDuplicateMeasurement
+	case err != nil:
+		return err
 	}
 
 	return nil
@@ -70,7 +281,10 @@ func (m *MeasurementsRepository) GetLatestAssetMeasurement(ctx context.Context,
 	opts := options.FindOne()
 	opts.SetSort(bson.M{"timestamp": -1})
 	res := m.collection.FindOne(ctx, bson.M{"assetId": assetID}, opts)
-	if res.Err() != nil {
+	switch {
+	case errors.Is(res.Err(), mongo.ErrNoDocuments):
+		return nil, measurements.ErrMeasurementNotFound
+	case res.Err() != nil:
 		return nil, res.Err()
 	}
 
@@ -90,6 +304,24 @@ func (m *MeasurementsRepository) GetAssetMeasurements(ctx context.Context, asset
 	opts := options.Find()
 	opts.SetSort(bson.M{"timestamp": -1})
 
+	filter := assetTimeRangeFilter(assetID, timeRange)
+
+	cursor, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbMeasurements []Measurement
+	err = cursor.All(ctx, &dbMeasurements)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMeasurements(dbMeasurements), nil
+}
+
+// assetTimeRangeFilter matches the measurements of the asset within the time range.
+func assetTimeRangeFilter(assetID string, timeRange measurements.TimeRange) bson.M {
 	filter := bson.M{"assetId": assetID}
 	timeRangeFilter := bson.M{}
 
@@ -105,77 +337,309 @@ func (m *MeasurementsRepository) GetAssetMeasurements(ctx context.Context, asset
 		filter["timestamp"] = timeRangeFilter
 	}
 
-	cursor, err := m.collection.Find(ctx, filter, opts)
+	return filter
+}
+
+// GetAssetMeasurementCounts counts the measurements of the asset within the time buckets. Buckets without measurements are omitted.
+func (m *MeasurementsRepository) GetAssetMeasurementCounts(ctx context.Context, assetID string, timeRange measurements.TimeRange, groupBy string) ([]measurements.BucketCount, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementCounts", zap.String("assetID", assetID))
+	defer cancel()
+
+	dateTruncParams, err := mongo2.GroupDateInterval(groupBy)
 	if err != nil {
 		return nil, err
 	}
 
-	var dbMeasurements []Measurement
-	err = cursor.All(ctx, &dbMeasurements)
+	pipeline := mongo.Pipeline{
+		{{"$match", assetTimeRangeFilter(assetID, timeRange)}},
+		{{"$group", bson.D{
+			{"_id", bson.D{{"$dateTrunc", dateTruncParams}}},
+			{"count", bson.D{{"$sum", 1}}},
+		}}},
+		{{"$sort", bson.D{{"_id", 1}}}},
+	}
+
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
 
-	return toMeasurements(dbMeasurements), nil
+	var counts []struct {
+		ID    time.Time `bson:"_id"`
+		Count int       `bson:"count"`
+	}
+	err = cursor.All(ctx, &counts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]measurements.BucketCount, len(counts))
+	for i, count := range counts {
+		result[i] = measurements.BucketCount{Time: count.ID, Count: count.Count}
+	}
+
+	return result, nil
+}
+
+// GetAssetMeasurementGaps returns the periods within the time range longer than minGap without measurements of the asset,
+// including the periods between the start of the range and the first measurement and between the last measurement and the end of the range.
+func (m *MeasurementsRepository) GetAssetMeasurementGaps(ctx context.Context, assetID string, timeRange measurements.TimeRange, minGap time.Duration) ([]measurements.Gap, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementGaps", zap.String("assetID", assetID))
+	defer cancel()
+
+	filter := assetTimeRangeFilter(assetID, timeRange)
+
+	// Compare each measurement with the previous one
+	pipeline := mongo.Pipeline{
+		{{"$match", filter}},
+		{{"$setWindowFields", bson.D{
+			{"sortBy", bson.D{{"timestamp", 1}}},
+			{"output", bson.D{
+				{"previous", bson.D{{"$shift", bson.D{{"output", "$timestamp"}, {"by", -1}}}}},
+			}},
+		}}},
+		{{"$match", bson.D{{"previous", bson.D{{"$ne", nil}}}}}},
+		{{"$project", bson.D{
+			{"_id", 0},
+			{"from", "$previous"},
+			{"to", "$timestamp"},
+			{"duration", bson.D{{"$dateDiff", bson.D{
+				{"startDate", "$previous"},
+				{"endDate", "$timestamp"},
+				{"unit", "millisecond"},
+			}}}},
+		}}},
+		{{"$match", bson.D{{"duration", bson.D{{"$gt", minGap.Milliseconds()}}}}}},
+	}
+
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := []measurements.Gap{}
+	var dbGaps []struct {
+		From time.Time `bson:"from"`
+		To   time.Time `bson:"to"`
+	}
+	err = cursor.All(ctx, &dbGaps)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gap := range dbGaps {
+		gaps = append(gaps, measurements.Gap{From: gap.From, To: gap.To})
+	}
+
+	if timeRange.From == nil || timeRange.To == nil {
+		return gaps, nil
+	}
+
+	first, err := m.findEdgeMeasurement(ctx, filter, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// No measurements within the range
+	if first == nil {
+		return measurements.AddEdgeGaps(gaps, timeRange, nil, nil, minGap), nil
+	}
+
+	last, err := m.findEdgeMeasurement(ctx, filter, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastTimestamp *time.Time
+	if last != nil {
+		lastTimestamp = &last.Timestamp
+	}
+
+	return measurements.AddEdgeGaps(gaps, timeRange, &first.Timestamp, lastTimestamp, minGap), nil
+}
+
+// findEdgeMeasurement returns the first (sort 1) or last (sort -1) measurement matching the filter, or nil if there is none.
+func (m *MeasurementsRepository) findEdgeMeasurement(ctx context.Context, filter bson.M, sort int) (*Measurement, error) {
+	var measurement Measurement
+	err := m.collection.FindOne(ctx, filter, options.FindOne().SetSort(bson.M{"timestamp": sort})).Decode(&measurement)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return &measurement, nil
+}
+
+// GetLatestMeasurementTimes returns the time of the latest measurement of each asset with measurements since the given time.
+func (m *MeasurementsRepository) GetLatestMeasurementTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetLatestMeasurementTimes", zap.Time("since", since))
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{{"timestamp", bson.D{{"$gte", since}}}}}},
+		{{"$group", bson.D{
+			{"_id", "$assetId"},
+			{"latest", bson.D{{"$max", "$timestamp"}}},
+		}}},
+	}
+
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest []struct {
+		AssetID string    `bson:"_id"`
+		Latest  time.Time `bson:"latest"`
+	}
+	err = cursor.All(ctx, &latest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]time.Time, len(latest))
+	for _, l := range latest {
+		result[l.AssetID] = l.Latest
+	}
+
+	return result, nil
 }
 
 // Aggregation pipeline result
 type averagedMeasurement struct {
-	ID            time.Time `bson:"_id"`
-	PowerAvg      float64   `bson:"power"`
-	StateOfEnergy float64   `bson:"SoE"`
-	AssetID       string    `bson:"assetId"`
+	ID      time.Time            `bson:"_id"`
+	Metrics measurements.Metrics `bson:"metrics"`
 }
 
+// GetAssetMeasurementsAveraged averages the power, state of energy and any additional metrics of the asset
+// within the time buckets. Power and state of energy are treated as metrics in the pipeline,
+// so each metric is averaged only over the measurements that contain it.
+// If rollups are enabled, the whole buckets already rolled up are read from the coarsest rollup satisfying the
+// requested grouping, while the remaining edges of the time range are averaged from the raw measurements.
 func (m *MeasurementsRepository) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error) {
 	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementsAveraged", zap.String("assetID", assetID), zap.Any("params", params))
 	defer cancel()
 
+	// Determine group by instruction
+	dateTruncParams, err := mongo2.GroupDateInterval(params.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	sortStage, err := averagedSortStage(params.SortBy, params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	window, tier, err := m.rollupWindowFor(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing to read from the rollups
+	if window == nil {
+		filter := bson.D{{"$gte", params.From}, {"$lte", params.To}}
+		dbMeasurements, err := m.averageRaw(ctx, assetID, filter, dateTruncParams, sortStage)
+		if err != nil {
+			return nil, err
+		}
+		return toMeasurementsFromAverage(dbMeasurements), nil
+	}
+
+	head, err := m.averageRaw(ctx, assetID, bson.D{{"$gte", params.From}, {"$lt", window.from}}, dateTruncParams, sortStage)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup, err := m.averageRollup(ctx, *tier, assetID, *window, dateTruncParams, sortStage)
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := m.averageRaw(ctx, assetID, bson.D{{"$gte", window.to}, {"$lte", params.To}}, dateTruncParams, sortStage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each part is sorted on its own
+	result := toMeasurementsFromAverage(append(append(head, rollup...), tail...))
+	measurements.SortMeasurements(result, params.SortBy, params.Sort)
+
+	return result, nil
+}
+
+// rollupWindowFor returns the window of whole buckets that can be read from a rollup, if any.
+func (m *MeasurementsRepository) rollupWindowFor(ctx context.Context, params measurements.AssetMeasurementAveragedParams) (*timeWindow, *rollupTier, error) {
+	if !m.retention.Rollups.Enabled || params.From == nil || params.To == nil {
+		return nil, nil, nil
+	}
+
+	interval, err := measurements.GroupByInterval(params.GroupBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tier, ok := rollupTierFor(interval)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	watermark, err := m.getWatermark(ctx, tier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	window, ok := splitByWatermark(*params.From, *params.To, watermark, interval)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	return &window, &tier, nil
+}
+
+// averageRaw averages the raw measurements of the asset with the timestamp matching the filter.
+func (m *MeasurementsRepository) averageRaw(ctx context.Context, assetID string, timestampFilter, dateTruncParams, sortStage bson.D) ([]averagedMeasurement, error) {
 	matchStage := bson.D{
 		{"$match", bson.D{
 			{"assetId", assetID},
-			{"timestamp", bson.D{
-				{"$gte", params.From},
-				{"$lte", params.To},
-			}},
+			{"timestamp", timestampFilter},
 		}},
 	}
 
-	// Determine group by instruction
-	dateTruncParams, err := mongo2.GroupDateInterval(params.GroupBy)
-	if err != nil {
-		return nil, err
+	// Flatten the power, state of energy and additional metrics into a single array of metrics
+	projectStage := bson.D{
+		{"$project", bson.D{
+			{"bucket", bson.D{{"$dateTrunc", dateTruncParams}}},
+			{"metrics", flattenedMetrics()},
+		}},
 	}
 
-	groupStage := bson.D{
+	unwindStage := bson.D{{"$unwind", "$metrics"}}
+
+	// Average each metric within the bucket, the values are stored in the base units of the metrics, so they share a unit
+	groupMetricStage := bson.D{
 		{"$group", bson.D{
 			{"_id", bson.D{
-				{"$dateTrunc", dateTruncParams},
+				{"bucket", "$bucket"},
+				{"name", "$metrics.k"},
 			}},
-			{"power", bson.D{{"$avg", "$power.value"}}},
-			{"SoE", bson.D{{"$avg", "$stateOfEnergy"}}},
-			{"assetId", bson.D{{"$first", "$assetId"}}},
+			{"value", bson.D{{"$avg", "$metrics.v.value"}}},
+			{"unit", bson.D{{"$first", "$metrics.v.unit"}}},
 		}},
 	}
 
-	val, err := mongo2.SortBy(params.Sort)
-	if err != nil {
-		return nil, err
-	}
+	pipeline := mongo.Pipeline{matchStage, projectStage, unwindStage, groupMetricStage}
+	pipeline = append(pipeline, collectMetricsStages()...)
+	pipeline = append(pipeline, sortStage)
 
-	sortStage := bson.D{
-		{"$sort",
-			bson.D{
-				{
-					Key: "powerAvg", Value: val,
-				},
-			},
-		},
-	}
+	return m.aggregateAveraged(ctx, m.collection, pipeline)
+}
 
-	pipeline := mongo.Pipeline{matchStage, groupStage, sortStage}
+func (m *MeasurementsRepository) aggregateAveraged(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline) ([]averagedMeasurement, error) {
 	opts := options.Aggregate()
-	cursor, err := m.collection.Aggregate(ctx, pipeline, opts)
+	cursor, err := collection.Aggregate(ctx, pipeline, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -186,25 +650,86 @@ func (m *MeasurementsRepository) GetAssetMeasurementsAveraged(ctx context.Contex
 		return nil, err
 	}
 
-	return toMeasurementsFromAverage(dbMeasurements), nil
+	return dbMeasurements, nil
+}
+
+// flattenedMetrics is an expression combining the power, state of energy and additional metrics
+// of a measurement into a single array of {k: name, v: {value, unit}} metrics.
+// The state of energy is left out for the assets which don't store energy.
+func flattenedMetrics() bson.D {
+	stateOfEnergy := bson.D{{"$cond", bson.A{
+		bson.D{{"$eq", bson.A{bson.D{{"$ifNull", bson.A{"$stateOfEnergy", nil}}}, nil}}},
+		bson.A{},
+		bson.A{bson.D{{"k", measurements.MetricStateOfEnergy}, {"v", bson.D{
+			{"value", "$stateOfEnergy"},
+			{"unit", measurements.UnitPercent},
+		}}}},
+	}}}
+
+	return bson.D{{"$concatArrays", bson.A{
+		bson.A{bson.D{{"k", measurements.MetricPower}, {"v", "$power"}}},
+		stateOfEnergy,
+		bson.D{{"$objectToArray", bson.D{{"$ifNull", bson.A{"$metrics", bson.D{}}}}}},
+	}}}
+}
+
+// collectMetricsStages collects the averaged metrics, grouped by bucket and name, into a metrics object per bucket.
+func collectMetricsStages() []bson.D {
+	groupStage := bson.D{
+		{"$group", bson.D{
+			{"_id", "$_id.bucket"},
+			{"metrics", bson.D{{"$push", bson.D{
+				{"k", "$_id.name"},
+				{"v", bson.D{{"value", "$value"}, {"unit", "$unit"}}},
+			}}}},
+		}},
+	}
+
+	metricsStage := bson.D{
+		{"$set", bson.D{
+			{"metrics", bson.D{{"$arrayToObject", "$metrics"}}},
+		}},
+	}
+
+	return []bson.D{groupStage, metricsStage}
+}
+
+// averagedSortStage sorts the averaged buckets by the key, the bucket start by default. Buckets with equal values are sorted by time.
+func averagedSortStage(key measurements.SortKey, sort string) (bson.D, error) {
+	direction, err := mongo2.SortBy(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	sortFields := bson.D{}
+	switch key {
+	case "", measurements.SortByTime:
+	case measurements.SortByPower:
+		sortFields = append(sortFields, bson.E{Key: "metrics." + measurements.MetricPower + ".value", Value: direction})
+	case measurements.SortByStateOfEnergy:
+		sortFields = append(sortFields, bson.E{Key: "metrics." + measurements.MetricStateOfEnergy + ".value", Value: direction})
+	default:
+		return nil, errors.New("invalid sortBy value")
+	}
+	sortFields = append(sortFields, bson.E{Key: "_id", Value: direction})
+
+	return bson.D{{"$sort", sortFields}}, nil
 }
 
 func toMeasurement(measurement *Measurement) *measurements.Measurement {
 	return &measurements.Measurement{
+		Id:            measurement.MeasurementID,
 		Time:          measurement.Timestamp,
 		Power:         measurement.Power,
 		StateOfEnergy: measurement.StateOfEnergy,
+		Metrics:       measurement.Metrics,
 	}
 }
 
 func toMeasurementsFromAverage(m []averagedMeasurement) []measurements.Measurement {
 	result := make([]measurements.Measurement, len(m))
 	for i, measurement := range m {
-		result[i] = measurements.Measurement{
-			Time:          measurement.ID,
-			Power:         measurements.Power{Value: measurement.PowerAvg, Unit: measurements.UnitWatt},
-			StateOfEnergy: measurement.StateOfEnergy,
-		}
+		result[i] = measurements.MeasurementFromMetrics(measurement.ID, measurement.Metrics)
 	}
 	return result
 }
@@ -219,9 +744,11 @@ func toMeasurements(m []Measurement) []measurements.Measurement {
 
 func fromMeasurement(assetId string, measurement *measurements.Measurement) *Measurement {
 	return &Measurement{
+		MeasurementID: measurement.Id,
 		AssetID:       assetId,
 		Timestamp:     measurement.Time,
 		Power:         measurement.Power,
 		StateOfEnergy: measurement.StateOfEnergy,
+		Metrics:       measurement.Metrics,
 	}
 }
@@ -0,0 +1,146 @@
+//go:build integration
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/repositorytest"
+	mongo2 "asset-measurements-assignment/internal/pkg/infrastructure/mongo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+// newIntegrationRepository connects to the MongoDB at MONGO_URI and creates the repository with the retention
+// in a new database, which is dropped after the test.
+func newIntegrationRepository(t *testing.T, retention RetentionConfig) *MeasurementsRepository {
+	t.Helper()
+
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("MONGO_URI is not set")
+	}
+
+	client, err := mongo2.NewClient(uri)
+	require.NoError(t, err)
+
+	database := client.Database(fmt.Sprintf("assets_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		_ = database.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	repository, err := NewMeasurementsRepository(observability.NewNoopObservability(), database, retention)
+	require.NoError(t, err)
+
+	return repository
+}
+
+func TestMeasurementsRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) measurements.Repository {
+		return newIntegrationRepository(t, RetentionConfig{})
+	})
+}
+
+func TestMeasurementsRepository_Rollups(t *testing.T) {
+	ctx := context.Background()
+	repository := newIntegrationRepository(t, RetentionConfig{
+		Rollups: RollupConfig{Enabled: true, Interval: time.Millisecond * 100},
+	})
+
+	// Reads the same measurements without the rollups
+	raw, err := NewMeasurementsRepository(observability.NewNoopObservability(), repository.collection.Database(), RetentionConfig{})
+	require.NoError(t, err)
+
+	// Two hours of measurements every 20 seconds
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var measurementsToAdd []measurements.Measurement
+	for i := 0; i < 360; i++ {
+		stateOfEnergy := float64(i % 100)
+		measurementsToAdd = append(measurementsToAdd, measurements.Measurement{
+			Time:          start.Add(time.Second * 20 * time.Duration(i)),
+			Power:         measurements.Power{Value: float64(i), Unit: measurements.UnitWatt},
+			StateOfEnergy: &stateOfEnergy,
+			Metrics: measurements.Metrics{
+				"voltage": {Value: 230 + float64(i%10), Unit: measurements.UnitVolt},
+			},
+		})
+	}
+	_, err = repository.AddMeasurements(ctx, "asset-1", measurementsToAdd)
+	require.NoError(t, err)
+
+	repository.StartRollups()
+	require.Eventually(t, func() bool {
+		for _, tier := range rollupTiers {
+			watermark, err := repository.getWatermark(ctx, tier)
+			if err != nil || watermark.Before(start.Add(time.Hour*2)) {
+				return false
+			}
+		}
+		return true
+	}, time.Second*10, time.Millisecond*100)
+	require.NoError(t, repository.StopRollups(ctx))
+
+	// The range starts and ends within the buckets, so the edges are averaged from the raw measurements
+	from, to := start.Add(time.Minute*7+time.Second*30), start.Add(time.Hour+time.Minute*52)
+	assertAveragedEqual := func(t *testing.T) {
+		t.Helper()
+
+		for _, groupBy := range []string{"minute", "15min", "hour"} {
+			params := measurements.AssetMeasurementAveragedParams{
+				TimeRange: measurements.TimeRange{From: &from, To: &to},
+				GroupBy:   groupBy,
+				Sort:      measurements.SortAscending,
+			}
+
+			window, _, err := repository.rollupWindowFor(ctx, params)
+			require.NoError(t, err)
+			require.NotNil(t, window, groupBy)
+
+			expected, err := raw.GetAssetMeasurementsAveraged(ctx, "asset-1", params)
+			require.NoError(t, err)
+			require.NotEmpty(t, expected)
+
+			actual, err := repository.GetAssetMeasurementsAveraged(ctx, "asset-1", params)
+			require.NoError(t, err)
+			require.Len(t, actual, len(expected), groupBy)
+
+			for i := range expected {
+				assert.True(t, expected[i].Time.Equal(actual[i].Time), groupBy)
+				assert.InDelta(t, expected[i].Power.Value, actual[i].Power.Value, 1e-9, groupBy)
+				require.NotNil(t, actual[i].StateOfEnergy, groupBy)
+				assert.InDelta(t, *expected[i].StateOfEnergy, *actual[i].StateOfEnergy, 1e-9, groupBy)
+				assert.InDelta(t, expected[i].Metrics["voltage"].Value, actual[i].Metrics["voltage"].Value, 1e-9, groupBy)
+			}
+		}
+	}
+
+	t.Run("Averages from the rollups", assertAveragedEqual)
+
+	t.Run("Refreshed rollups include late measurements", func(t *testing.T) {
+		late := measurements.Measurement{
+			Time:  start.Add(time.Minute*30 + time.Second*5),
+			Power: measurements.Power{Value: 10000, Unit: measurements.UnitWatt},
+		}
+		require.NoError(t, repository.AddMeasurement(ctx, "asset-1", late))
+		require.NoError(t, repository.RefreshRollups(ctx, "asset-1", late.Time, late.Time))
+
+		assertAveragedEqual(t)
+	})
+
+	t.Run("Late live measurements are rolled up again", func(t *testing.T) {
+		late := measurements.Measurement{
+			Time:  start.Add(time.Minute*45 + time.Second*5),
+			Power: measurements.Power{Value: 20000, Unit: measurements.UnitWatt},
+		}
+		require.NoError(t, repository.AddMeasurement(ctx, "asset-1", late))
+
+		assertAveragedEqual(t)
+	})
+}
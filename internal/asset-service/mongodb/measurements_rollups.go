@@ -0,0 +1,467 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	mongo2 "asset-measurements-assignment/internal/pkg/infrastructure/mongo"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	rollupStateCollection = "asset_measurement_rollups"
+
+	// rollupDelay is the time after the end of a bucket before the bucket is rolled up,
+	// so that the measurements still in flight are included in the rollup.
+	rollupDelay = time.Minute
+
+	// rollupBatch limits the time window rolled up in a single run.
+	rollupBatch = time.Hour * 24
+
+	defaultRollupInterval = time.Minute
+)
+
+// RetentionConfig configures the retention of the measurements and the rollups.
+type RetentionConfig struct {
+	// Raw measurements are removed after the retention period. Zero keeps the measurements forever.
+	// Example: 720h
+	Measurements time.Duration `yaml:"measurements" mapstructure:"measurements" json:"measurements"`
+
+	// Rollup settings
+	Rollups RollupConfig `yaml:"rollups" mapstructure:"rollups" json:"rollups"`
+}
+
+// RollupConfig configures the 1-minute, 15-minute and hourly rollups of the measurements.
+type RollupConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled" json:"enabled"`
+
+	// How often the rollup job runs. Defaults to one minute.
+	Interval time.Duration `yaml:"interval" mapstructure:"interval" json:"interval"`
+
+	// Rollups are removed after the retention period. Zero keeps the rollups forever.
+	Retention time.Duration `yaml:"retention" mapstructure:"retention" json:"retention"`
+}
+
+// Validate checks that the raw measurements are kept long enough to be rolled up before they expire.
+func (c RetentionConfig) Validate() error {
+	if c.Measurements < 0 || c.Rollups.Interval < 0 || c.Rollups.Retention < 0 {
+		return fmt.Errorf("retention periods and rollup interval must not be negative")
+	}
+
+	if !c.Rollups.Enabled || c.Measurements == 0 {
+		return nil
+	}
+
+	minRetention := rollupTiers[len(rollupTiers)-1].interval + rollupDelay + c.rollupInterval()
+	if c.Measurements <= minRetention {
+		return fmt.Errorf("measurements retention must be longer than %s when rollups are enabled", minRetention)
+	}
+
+	return nil
+}
+
+func (c RetentionConfig) rollupInterval() time.Duration {
+	if c.Rollups.Interval == 0 {
+		return defaultRollupInterval
+	}
+	return c.Rollups.Interval
+}
+
+// rollupTier is a collection of measurements aggregated into fixed size buckets.
+type rollupTier struct {
+	interval   time.Duration
+	groupBy    string
+	collection string
+}
+
+// rollupTiers ordered from the finest to the coarsest.
+var rollupTiers = []rollupTier{
+	{interval: time.Minute, groupBy: "minute", collection: "asset_measurements_1m"},
+	{interval: time.Minute * 15, groupBy: "15min", collection: "asset_measurements_15m"},
+	{interval: time.Hour, groupBy: "hour", collection: "asset_measurements_1h"},
+}
+
+// rollupTierFor returns the coarsest tier whose buckets evenly divide the requested bucket interval.
+func rollupTierFor(interval time.Duration) (rollupTier, bool) {
+	for i := len(rollupTiers) - 1; i >= 0; i-- {
+		if interval%rollupTiers[i].interval == 0 {
+			return rollupTiers[i], true
+		}
+	}
+	return rollupTier{}, false
+}
+
+// rollupState mongo entity, stores the end of the last rolled up window of the tier.
+type rollupState struct {
+	ID        string    `bson:"_id"`
+	Watermark time.Time `bson:"watermark"`
+}
+
+// rollupJob is the background job materialising the rollups.
+type rollupJob struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	cancel   context.CancelFunc
+}
+
+// timeWindow is a half-open [from, to) interval.
+type timeWindow struct {
+	from time.Time
+	to   time.Time
+}
+
+// splitByWatermark splits the [from, to] query range into the window of whole buckets that can be read from the rollup
+// and the leading and trailing parts that have to be read from the raw measurements.
+// The rollup window is empty if no whole bucket before the watermark lies within the range.
+func splitByWatermark(from, to, watermark time.Time, interval time.Duration) (rollup timeWindow, ok bool) {
+	start := truncateUp(from, interval)
+
+	end := to
+	if watermark.Before(end) {
+		end = watermark
+	}
+	end = end.Truncate(interval)
+
+	if !start.Before(end) {
+		return timeWindow{}, false
+	}
+
+	return timeWindow{from: start, to: end}, true
+}
+
+// truncateUp rounds the time up to a multiple of the interval.
+func truncateUp(t time.Time, interval time.Duration) time.Time {
+	truncated := t.Truncate(interval)
+	if truncated.Before(t) {
+		return truncated.Add(interval)
+	}
+	return truncated
+}
+
+// createRollupCollections creates the indexes required by the rollup collections.
+func createRollupCollections(ctx context.Context, db *mongo.Database, retention time.Duration) error {
+	for _, tier := range rollupTiers {
+		indexes := []mongo.IndexModel{{
+			// $merge requires a unique index on the fields it matches on
+			Keys:    bson.D{{Key: "assetId", Value: 1}, {Key: "timestamp", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}}
+
+		if retention > 0 {
+			indexes = append(indexes, mongo.IndexModel{
+				Keys:    bson.D{{Key: "timestamp", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+			})
+		}
+
+		_, err := db.Collection(tier.collection).Indexes().CreateMany(ctx, indexes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setMeasurementsRetention sets the expiry of the time series collection. The collection is modified, as the
+// retention set on creation is not applied to an existing collection.
+func setMeasurementsRetention(ctx context.Context, db *mongo.Database, retention time.Duration) error {
+	var expireAfterSeconds any = "off"
+	if retention > 0 {
+		expireAfterSeconds = int64(retention.Seconds())
+	}
+
+	return db.RunCommand(ctx, bson.D{
+		{"collMod", collectionName},
+		{"expireAfterSeconds", expireAfterSeconds},
+	}).Err()
+}
+
+// StartRollups periodically materialises the rollups in the background until StopRollups is called.
+// The rollups run independently of the caller's context, so they can be stopped in order on shutdown.
+func (m *MeasurementsRepository) StartRollups() {
+	if !m.retention.Rollups.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.rollups = &rollupJob{
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer close(m.rollups.done)
+		m.runRollups(ctx, m.rollups.stop)
+	}()
+}
+
+// StopRollups stops the rollups and waits until the running rollup completes. If the context is done first,
+// the running rollup is cancelled and rolled up again on the next start, as its watermark was not advanced.
+// The rollups can be stopped more than once.
+func (m *MeasurementsRepository) StopRollups(ctx context.Context) error {
+	if m.rollups == nil {
+		return nil
+	}
+	defer m.rollups.cancel()
+
+	m.rollups.stopOnce.Do(func() {
+		close(m.rollups.stop)
+	})
+
+	select {
+	case <-m.rollups.done:
+		return nil
+	case <-ctx.Done():
+		m.rollups.cancel()
+		<-m.rollups.done
+		return fmt.Errorf("rollups didn't stop in time: %w", ctx.Err())
+	}
+}
+
+// runRollups materialises the rollups every rollup interval until stopped.
+func (m *MeasurementsRepository) runRollups(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.retention.rollupInterval())
+	defer ticker.Stop()
+
+	for {
+		for _, tier := range rollupTiers {
+			err := m.rollupTier(ctx, tier, time.Now())
+			if err != nil {
+				m.obs.Log().Error("Failed to roll up measurements", zap.String("collection", tier.collection), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rollupTier rolls up the closed buckets since the tier's watermark and advances the watermark.
+func (m *MeasurementsRepository) rollupTier(ctx context.Context, tier rollupTier, now time.Time) error {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.rollupTier", zap.String("collection", tier.collection))
+	defer cancel()
+
+	from, err := m.getWatermark(ctx, tier)
+	if err != nil {
+		return err
+	}
+
+	// Nothing was rolled up yet, start from the oldest measurement
+	if from.IsZero() {
+		res := m.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"timestamp": 1}))
+		var oldest Measurement
+		err = res.Decode(&oldest)
+		switch {
+		case errors.Is(err, mongo.ErrNoDocuments):
+			return nil
+		case err != nil:
+			return err
+		}
+		from = oldest.Timestamp.Truncate(tier.interval)
+	}
+
+	to := now.Add(-rollupDelay).Truncate(tier.interval)
+	if to.Sub(from) > rollupBatch {
+		to = from.Add(rollupBatch)
+	}
+
+	if !from.Before(to) {
+		return nil
+	}
+
+	err = m.rollupWindow(ctx, tier, bson.M{"timestamp": bson.M{"$gte": from, "$lt": to}})
+	if err != nil {
+		return err
+	}
+
+	return m.setWatermark(ctx, tier, to)
+}
+
+// rollupWindow aggregates the raw measurements matching the filter into the tier's buckets and
+// merges them into the rollup collection. Existing buckets are replaced, so a window can be rolled up again.
+// Each metric of a bucket stores the sum and count instead of the average, so the rollups can be
+// re-aggregated into larger buckets.
+func (m *MeasurementsRepository) rollupWindow(ctx context.Context, tier rollupTier, filter bson.M) error {
+	dateTruncParams, err := mongo2.GroupDateInterval(tier.groupBy)
+	if err != nil {
+		return err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{"$match", filter}},
+		{{"$project", bson.D{
+			{"assetId", 1},
+			{"bucket", bson.D{{"$dateTrunc", dateTruncParams}}},
+			{"metrics", flattenedMetrics()},
+		}}},
+		{{"$unwind", "$metrics"}},
+		{{"$group", bson.D{
+			{"_id", bson.D{
+				{"assetId", "$assetId"},
+				{"bucket", "$bucket"},
+				{"name", "$metrics.k"},
+			}},
+			{"sum", bson.D{{"$sum", "$metrics.v.value"}}},
+			{"count", bson.D{{"$sum", 1}}},
+			{"min", bson.D{{"$min", "$metrics.v.value"}}},
+			{"max", bson.D{{"$max", "$metrics.v.value"}}},
+			{"unit", bson.D{{"$first", "$metrics.v.unit"}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", bson.D{
+				{"assetId", "$_id.assetId"},
+				{"bucket", "$_id.bucket"},
+			}},
+			{"metrics", bson.D{{"$push", bson.D{
+				{"k", "$_id.name"},
+				{"v", bson.D{{"sum", "$sum"}, {"count", "$count"}, {"min", "$min"}, {"max", "$max"}, {"unit", "$unit"}}},
+			}}}},
+		}}},
+		{{"$project", bson.D{
+			{"_id", 0},
+			{"assetId", "$_id.assetId"},
+			{"timestamp", "$_id.bucket"},
+			{"metrics", bson.D{{"$arrayToObject", "$metrics"}}},
+		}}},
+		{{"$merge", bson.D{
+			{"into", tier.collection},
+			{"on", bson.A{"assetId", "timestamp"}},
+			{"whenMatched", "replace"},
+			{"whenNotMatched", "insert"},
+		}}},
+	}
+
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	return cursor.Close(ctx)
+}
+
+// RefreshRollups rolls up the already rolled up buckets of the asset between from and to again,
+// so the rollups include the measurements added after the buckets were rolled up.
+func (m *MeasurementsRepository) RefreshRollups(ctx context.Context, assetId string, from, to time.Time) error {
+	if !m.retention.Rollups.Enabled {
+		return nil
+	}
+
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.RefreshRollups", zap.String("assetId", assetId))
+	defer cancel()
+
+	for _, tier := range rollupTiers {
+		watermark, err := m.getWatermark(ctx, tier)
+		if err != nil {
+			return err
+		}
+
+		start := from.Truncate(tier.interval)
+		end := to.Truncate(tier.interval).Add(tier.interval)
+		if watermark.Before(end) {
+			end = watermark
+		}
+
+		// Buckets with expired measurements cannot be rolled up again without losing data
+		if m.retention.Measurements > 0 {
+			oldest := truncateUp(time.Now().Add(-m.retention.Measurements), tier.interval)
+			if start.Before(oldest) {
+				start = oldest
+			}
+		}
+
+		if !start.Before(end) {
+			continue
+		}
+
+		err = m.rollupWindow(ctx, tier, bson.M{
+			"assetId":   assetId,
+			"timestamp": bson.M{"$gte": start, "$lt": end},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refreshLateRollups rolls up the buckets of the measurement again if it is older than the rollup delay, as the
+// rollup job may have already rolled up its buckets. The measurement is stored, so a failure is only logged.
+func (m *MeasurementsRepository) refreshLateRollups(ctx context.Context, assetId string, timestamp time.Time) {
+	if !m.retention.Rollups.Enabled || !timestamp.Before(time.Now().Add(-rollupDelay)) {
+		return
+	}
+
+	err := m.RefreshRollups(ctx, assetId, timestamp, timestamp)
+	if err != nil {
+		m.obs.Log().Error("Failed to roll up the late measurement", zap.String("assetId", assetId), zap.Error(err))
+	}
+}
+
+func (m *MeasurementsRepository) getWatermark(ctx context.Context, tier rollupTier) (time.Time, error) {
+	var state rollupState
+	err := m.stateCollection.FindOne(ctx, bson.M{"_id": tier.collection}).Decode(&state)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return time.Time{}, nil
+	case err != nil:
+		return time.Time{}, err
+	}
+
+	return state.Watermark, nil
+}
+
+func (m *MeasurementsRepository) setWatermark(ctx context.Context, tier rollupTier, watermark time.Time) error {
+	_, err := m.stateCollection.UpdateOne(ctx,
+		bson.M{"_id": tier.collection},
+		bson.M{"$set": bson.M{"watermark": watermark}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// averageRollup averages the rollup buckets of the asset within the window into the requested buckets.
+func (m *MeasurementsRepository) averageRollup(ctx context.Context, tier rollupTier, assetID string, window timeWindow, dateTruncParams, sortStage bson.D) ([]averagedMeasurement, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{
+			{"assetId", assetID},
+			{"timestamp", bson.D{
+				{"$gte", window.from},
+				{"$lt", window.to},
+			}},
+		}}},
+		{{"$project", bson.D{
+			{"bucket", bson.D{{"$dateTrunc", dateTruncParams}}},
+			{"metrics", bson.D{{"$objectToArray", "$metrics"}}},
+		}}},
+		{{"$unwind", "$metrics"}},
+		{{"$group", bson.D{
+			{"_id", bson.D{
+				{"bucket", "$bucket"},
+				{"name", "$metrics.k"},
+			}},
+			{"sum", bson.D{{"$sum", "$metrics.v.sum"}}},
+			{"count", bson.D{{"$sum", "$metrics.v.count"}}},
+			{"unit", bson.D{{"$first", "$metrics.v.unit"}}},
+		}}},
+		{{"$set", bson.D{
+			{"value", bson.D{{"$divide", bson.A{"$sum", "$count"}}}},
+		}}},
+	}
+	pipeline = append(pipeline, collectMetricsStages()...)
+	pipeline = append(pipeline, sortStage)
+
+	return m.aggregateAveraged(ctx, m.collection.Database().Collection(tier.collection), pipeline)
+}
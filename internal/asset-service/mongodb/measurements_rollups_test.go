@@ -0,0 +1,228 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupTierFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		collection string
+		found      bool
+	}{
+		{
+			name:       "Minute",
+			interval:   time.Minute,
+			collection: "asset_measurements_1m",
+			found:      true,
+		},
+		{
+			name:       "15 minutes",
+			interval:   time.Minute * 15,
+			collection: "asset_measurements_15m",
+			found:      true,
+		},
+		{
+			name:       "Hour",
+			interval:   time.Hour,
+			collection: "asset_measurements_1h",
+			found:      true,
+		},
+		{
+			name:       "5 minutes",
+			interval:   time.Minute * 5,
+			collection: "asset_measurements_1m",
+			found:      true,
+		},
+		{
+			name:     "Seconds",
+			interval: time.Second * 30,
+			found:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier, found := rollupTierFor(tt.interval)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.collection, tier.collection)
+		})
+	}
+}
+
+func TestSplitByWatermark(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		from      time.Time
+		to        time.Time
+		watermark time.Time
+		interval  time.Duration
+		expected  timeWindow
+		ok        bool
+	}{
+		{
+			name:      "Range within rollup",
+			from:      at(10, 0),
+			to:        at(14, 0),
+			watermark: at(16, 0),
+			interval:  time.Hour,
+			expected:  timeWindow{from: at(10, 0), to: at(14, 0)},
+			ok:        true,
+		},
+		{
+			name:      "Partial buckets at the edges",
+			from:      at(10, 7),
+			to:        at(14, 30),
+			watermark: at(16, 0),
+			interval:  time.Hour,
+			expected:  timeWindow{from: at(11, 0), to: at(14, 0)},
+			ok:        true,
+		},
+		{
+			name:      "Range after watermark",
+			from:      at(10, 0),
+			to:        at(14, 0),
+			watermark: at(12, 0),
+			interval:  time.Hour,
+			expected:  timeWindow{from: at(10, 0), to: at(12, 0)},
+			ok:        true,
+		},
+		{
+			name:      "Watermark not aligned to requested buckets",
+			from:      at(10, 0),
+			to:        at(14, 0),
+			watermark: at(12, 45),
+			interval:  time.Hour,
+			expected:  timeWindow{from: at(10, 0), to: at(12, 0)},
+			ok:        true,
+		},
+		{
+			name:      "Nothing rolled up",
+			from:      at(10, 0),
+			to:        at(14, 0),
+			watermark: time.Time{},
+			interval:  time.Hour,
+			ok:        false,
+		},
+		{
+			name:      "No whole bucket",
+			from:      at(10, 7),
+			to:        at(10, 50),
+			watermark: at(16, 0),
+			interval:  time.Hour,
+			ok:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, ok := splitByWatermark(tt.from, tt.to, tt.watermark, tt.interval)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, window)
+		})
+	}
+}
+
+func TestRetentionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RetentionConfig
+		wantErr bool
+	}{
+		{
+			name:   "Keep forever",
+			config: RetentionConfig{},
+		},
+		{
+			name: "Retention without rollups",
+			config: RetentionConfig{
+				Measurements: time.Minute,
+			},
+		},
+		{
+			name: "Retention with rollups",
+			config: RetentionConfig{
+				Measurements: time.Hour * 24 * 30,
+				Rollups:      RollupConfig{Enabled: true, Retention: time.Hour * 24 * 365},
+			},
+		},
+		{
+			name: "Retention shorter than the hourly rollup",
+			config: RetentionConfig{
+				Measurements: time.Hour,
+				Rollups:      RollupConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Negative retention",
+			config: RetentionConfig{
+				Measurements: -time.Hour,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMeasurementsRepository_StopRollups(t *testing.T) {
+	t.Run("Rollups disabled", func(t *testing.T) {
+		repository := &MeasurementsRepository{}
+		repository.StartRollups()
+		assert.NoError(t, repository.StopRollups(context.Background()))
+	})
+
+	t.Run("Cancels the running rollup when the context is done", func(t *testing.T) {
+		running := make(chan struct{})
+		repository := &MeasurementsRepository{}
+		ctx, cancel := context.WithCancel(context.Background())
+		repository.rollups = &rollupJob{stop: make(chan struct{}), done: make(chan struct{}), cancel: cancel}
+		go func() {
+			defer close(repository.rollups.done)
+			close(running)
+			<-ctx.Done()
+		}()
+		<-running
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer stopCancel()
+
+		err := repository.StopRollups(stopCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("Stops more than once", func(t *testing.T) {
+		repository := &MeasurementsRepository{}
+		_, cancel := context.WithCancel(context.Background())
+		repository.rollups = &rollupJob{stop: make(chan struct{}), done: make(chan struct{}), cancel: cancel}
+		go func() {
+			defer close(repository.rollups.done)
+			<-repository.rollups.stop
+		}()
+
+		assert.NoError(t, repository.StopRollups(context.Background()))
+		assert.NotPanics(t, func() {
+			assert.NoError(t, repository.StopRollups(context.Background()))
+		})
+	})
+}
@@ -0,0 +1,502 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Measurement is a row of the asset_measurements hypertable.
+type Measurement struct {
+	MeasurementID string    `gorm:"index"`
+	AssetID       string    `gorm:"not null;index:idx_asset_measurements_asset_timestamp,priority:1"`
+	Timestamp     time.Time `gorm:"not null;index:idx_asset_measurements_asset_timestamp,priority:2,sort:desc"`
+	PowerValue    float64
+	PowerUnit     string
+	StateOfEnergy *float64
+	Metrics       []byte `gorm:"type:jsonb"`
+}
+
+func (Measurement) TableName() string {
+	return "asset_measurements"
+}
+
+// MeasurementId is used to enforce uniqueness of measurements, as unique indexes of a hypertable must include the time column.
+type MeasurementId struct {
+	ID        string `gorm:"primarykey"`
+	AssetID   string
+	Timestamp time.Time
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (MeasurementId) TableName() string {
+	return "asset_measurement_ids"
+}
+
+// MeasurementsRepository stores the measurements in a TimescaleDB hypertable.
+type MeasurementsRepository struct {
+	obs observability.Observability
+	db  *gorm.DB
+}
+
+// NewMeasurementsRepository creates the measurements hypertable, if it doesn't exist yet, and applies the retention policy.
+// The database must have the timescaledb extension available.
+func NewMeasurementsRepository(obs observability.Observability, db *gorm.DB, retention time.Duration) (*MeasurementsRepository, error) {
+	err := db.Exec("CREATE EXTENSION IF NOT EXISTS timescaledb").Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the timescaledb extension")
+	}
+
+	err = db.AutoMigrate(&Measurement{}, &MeasurementId{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to migrate measurement schemas")
+	}
+
+	err = db.Exec("SELECT create_hypertable('asset_measurements', 'timestamp', if_not_exists => TRUE, migrate_data => TRUE)").Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the measurements hypertable")
+	}
+
+	// Replace the retention policy, in case the retention changed
+	err = db.Exec("SELECT remove_retention_policy('asset_measurements', if_exists => TRUE)").Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove the retention policy")
+	}
+
+	if retention > 0 {
+		err = db.Exec("SELECT add_retention_policy('asset_measurements', ?::interval)", toInterval(retention)).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to add the retention policy")
+		}
+	}
+
+	err = scheduleMeasurementIdsExpiry(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MeasurementsRepository{
+		obs: obs,
+		db:  db,
+	}, nil
+}
+
+// expireMeasurementIdsProcedure removes the measurement IDs older than the retention of the job's config.
+// The job runs with the search path the procedure was created with, so it finds the table in the same schema.
+const expireMeasurementIdsProcedure = `
+CREATE OR REPLACE PROCEDURE expire_asset_measurement_ids(job_id int, config jsonb)
+LANGUAGE SQL
+SET search_path FROM CURRENT
+AS $$
+	DELETE FROM asset_measurement_ids WHERE created_at < now() - (config->>'retention')::interval;
+$$`
+
+// scheduleMeasurementIdsExpiry schedules a TimescaleDB job removing the measurement IDs after the deduplication window.
+// The IDs table can't be a hypertable with a retention policy, as its unique index would have to include the time column.
+func scheduleMeasurementIdsExpiry(db *gorm.DB) error {
+	err := db.Exec(expireMeasurementIdsProcedure).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to create the measurement IDs expiry procedure")
+	}
+
+	// Replace the job, in case the deduplication window changed
+	err = db.Exec("SELECT delete_job(job_id) FROM timescaledb_information.jobs WHERE proc_schema = current_schema() AND proc_name = 'expire_asset_measurement_ids'").Error
+	if err != nil {
+		return errors.Wrap(err, "failed to remove the measurement IDs expiry job")
+	}
+
+	err = db.Exec("SELECT add_job('expire_asset_measurement_ids', '1 hour', config => jsonb_build_object('retention', ?::text))",
+		toInterval(measurements.DeduplicationWindow)).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to add the measurement IDs expiry job")
+	}
+
+	return nil
+}
+
+// AddMeasurement stores the measurement. If the measurement has an ID, the ID is reserved in the same transaction.
+// If the ID was already reserved, the measurement is a duplicate and ErrDuplicateMeasurement is returned.
+func (m *MeasurementsRepository) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurement", zap.Any("measurement", measurement))
+	defer cancel()
+
+	dbMeasurement, err := fromMeasurement(assetId, measurement)
+	if err != nil {
+		return err
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if measurement.Id != "" {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&MeasurementId{
+				ID:        measurement.Id,
+				AssetID:   assetId,
+				Timestamp: measurement.Time,
+			})
+			switch {
+			case result.Error != nil:
+				return result.Error
+			case result.RowsAffected == 0:
+				return measurements.ErrDuplicateMeasurement
+			}
+		}
+
+		return tx.Create(&dbMeasurement).Error
+	})
+}
+
+// insertBatchSize limits the number of measurements inserted by a single statement.
+const insertBatchSize = 1000
+
+// AddMeasurements stores the measurements in one transaction. The IDs of the measurements are reserved by a single
+// statement, which returns the IDs that weren't reserved yet, and only the measurements with these IDs are stored.
+func (m *MeasurementsRepository) AddMeasurements(ctx context.Context, assetId string, measurementsToAdd []measurements.Measurement) (int, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.AddMeasurements", zap.String("assetId", assetId), zap.Int("measurements", len(measurementsToAdd)))
+	defer cancel()
+
+	distinct, duplicates := measurements.DistinctMeasurements(measurementsToAdd)
+
+	type reservation struct {
+		Id        string    `json:"id"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	reservations := []reservation{}
+	for _, measurement := range distinct {
+		if measurement.Id != "" {
+			reservations = append(reservations, reservation{Id: measurement.Id, Timestamp: measurement.Time})
+		}
+	}
+
+	reservationsJson, err := json.Marshal(reservations)
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reservedIds []string
+		if len(reservations) > 0 {
+			query := `
+				INSERT INTO asset_measurement_ids (id, asset_id, timestamp, created_at)
+				SELECT r.id, ?, r.timestamp, now()
+				FROM jsonb_to_recordset(?::jsonb) AS r(id text, timestamp timestamptz)
+				ON CONFLICT DO NOTHING
+				RETURNING id`
+			err := tx.Raw(query, assetId, reservationsJson).Scan(&reservedIds).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		reserved := make(map[string]struct{}, len(reservedIds))
+		for _, id := range reservedIds {
+			reserved[id] = struct{}{}
+		}
+
+		dbMeasurements := make([]Measurement, 0, len(distinct))
+		for _, measurement := range distinct {
+			if _, ok := reserved[measurement.Id]; measurement.Id != "" && !ok {
+				duplicates++
+				continue
+			}
+
+			dbMeasurement, err := fromMeasurement(assetId, measurement)
+			if err != nil {
+				return err
+			}
+			dbMeasurements = append(dbMeasurements, dbMeasurement)
+		}
+
+		if len(dbMeasurements) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(dbMeasurements, insertBatchSize).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return duplicates, nil
+}
+
+func (m *MeasurementsRepository) GetLatestAssetMeasurement(ctx context.Context, assetID string) (*measurements.Measurement, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetLatestAssetMeasurement", zap.String("assetID", assetID))
+	defer cancel()
+
+	var dbMeasurement Measurement
+	result := m.db.WithContext(ctx).Where("asset_id = ?", assetID).Order("timestamp DESC").Limit(1).Find(&dbMeasurement)
+	switch {
+	case result.Error != nil:
+		return nil, result.Error
+	case result.RowsAffected == 0:
+		return nil, measurements.ErrMeasurementNotFound
+	}
+
+	return toMeasurement(dbMeasurement)
+}
+
+// GetAssetMeasurements returns the measurements of the asset within the time range, latest first.
+func (m *MeasurementsRepository) GetAssetMeasurements(ctx context.Context, assetID string, timeRange measurements.TimeRange) ([]measurements.Measurement, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurements", zap.String("assetID", assetID))
+	defer cancel()
+
+	condition, args := assetTimeRangeCondition(assetID, timeRange)
+
+	var dbMeasurements []Measurement
+	result := m.db.WithContext(ctx).Where(condition, args...).Order("timestamp DESC").Find(&dbMeasurements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return toMeasurements(dbMeasurements)
+}
+
+// assetTimeRangeCondition matches the measurements of the asset within the time range.
+func assetTimeRangeCondition(assetID string, timeRange measurements.TimeRange) (string, []any) {
+	conditions := []string{"asset_id = ?"}
+	args := []any{assetID}
+
+	if timeRange.From != nil {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, *timeRange.From)
+	}
+
+	if timeRange.To != nil {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, *timeRange.To)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// toInterval formats the duration as a Postgres interval.
+func toInterval(duration time.Duration) string {
+	return fmt.Sprintf("%d seconds", int64(duration.Seconds()))
+}
+
+// Averaged metric within a bucket
+type averagedMetric struct {
+	Bucket time.Time
+	Name   string
+	Value  float64
+	Unit   string
+}
+
+// GetAssetMeasurementsAveraged averages the power, state of energy and any additional metrics of the asset
+// within the time buckets. Power and state of energy are treated as metrics in the query,
+// so each metric is averaged only over the measurements that contain it.
+func (m *MeasurementsRepository) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementsAveraged", zap.String("assetID", assetID), zap.Any("params", params))
+	defer cancel()
+
+	interval, err := measurements.GroupByInterval(params.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = measurements.ValidateSort(params.SortBy, params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, args := assetTimeRangeCondition(assetID, params.TimeRange)
+
+	// Flatten the power, state of energy and additional metrics into rows of metrics, and average each metric within the bucket.
+	// The values are stored in the base units of the metrics, so all the values of a metric share a unit.
+	query := `
+		SELECT time_bucket(?::interval, m.timestamp) AS bucket, f.name, avg(f.value) AS value, min(f.unit) AS unit
+		FROM asset_measurements m
+		CROSS JOIN LATERAL (
+			SELECT ?::text AS name, m.power_value AS value, m.power_unit AS unit
+			UNION ALL
+			SELECT ?::text, m.state_of_energy, ?::text WHERE m.state_of_energy IS NOT NULL
+			UNION ALL
+			SELECT key, (value->>'value')::double precision, value->>'unit'
+			FROM jsonb_each(coalesce(m.metrics, '{}'::jsonb))
+		) f
+		WHERE ` + condition + `
+		GROUP BY bucket, f.name
+		ORDER BY bucket`
+
+	queryArgs := append([]any{
+		toInterval(interval),
+		measurements.MetricPower,
+		measurements.MetricStateOfEnergy,
+		string(measurements.UnitPercent),
+	}, args...)
+
+	var metrics []averagedMetric
+	result := m.db.WithContext(ctx).Raw(query, queryArgs...).Scan(&metrics)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// Collect the metrics of each bucket, the rows are ordered by bucket
+	averaged := []measurements.Measurement{}
+	bucketMetrics := measurements.Metrics{}
+	for i, metric := range metrics {
+		bucketMetrics[metric.Name] = measurements.Metric{Value: metric.Value, Unit: measurements.Unit(metric.Unit)}
+
+		if i == len(metrics)-1 || !metrics[i+1].Bucket.Equal(metric.Bucket) {
+			averaged = append(averaged, measurements.MeasurementFromMetrics(metric.Bucket, bucketMetrics))
+			bucketMetrics = measurements.Metrics{}
+		}
+	}
+
+	measurements.SortMeasurements(averaged, params.SortBy, params.Sort)
+	return averaged, nil
+}
+
+// GetAssetMeasurementCounts counts the measurements of the asset within the time buckets. Buckets without measurements are omitted.
+func (m *MeasurementsRepository) GetAssetMeasurementCounts(ctx context.Context, assetID string, timeRange measurements.TimeRange, groupBy string) ([]measurements.BucketCount, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementCounts", zap.String("assetID", assetID))
+	defer cancel()
+
+	interval, err := measurements.GroupByInterval(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, args := assetTimeRangeCondition(assetID, timeRange)
+
+	counts := []measurements.BucketCount{}
+	result := m.db.WithContext(ctx).
+		Model(&Measurement{}).
+		Select("time_bucket(?::interval, timestamp) AS time, count(*) AS count", toInterval(interval)).
+		Where(condition, args...).
+		Group("1").
+		Order("1").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return counts, nil
+}
+
+// GetAssetMeasurementGaps returns the periods within the time range longer than minGap without measurements of the asset,
+// including the periods between the start of the range and the first measurement and between the last measurement and the end of the range.
+func (m *MeasurementsRepository) GetAssetMeasurementGaps(ctx context.Context, assetID string, timeRange measurements.TimeRange, minGap time.Duration) ([]measurements.Gap, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetAssetMeasurementGaps", zap.String("assetID", assetID))
+	defer cancel()
+
+	condition, args := assetTimeRangeCondition(assetID, timeRange)
+
+	// Compare each measurement with the previous one
+	query := `
+		SELECT previous AS "from", timestamp AS "to"
+		FROM (
+			SELECT timestamp, lag(timestamp) OVER (ORDER BY timestamp) AS previous
+			FROM asset_measurements
+			WHERE ` + condition + `
+		) m
+		WHERE previous IS NOT NULL AND timestamp - previous > ?::interval
+		ORDER BY timestamp`
+
+	gaps := []measurements.Gap{}
+	result := m.db.WithContext(ctx).Raw(query, append(args, fmt.Sprintf("%d microseconds", minGap.Microseconds()))...).Scan(&gaps)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var edges struct {
+		First *time.Time
+		Last  *time.Time
+	}
+	result = m.db.WithContext(ctx).
+		Model(&Measurement{}).
+		Select("min(timestamp) AS first, max(timestamp) AS last").
+		Where(condition, args...).
+		Scan(&edges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return measurements.AddEdgeGaps(gaps, timeRange, edges.First, edges.Last, minGap), nil
+}
+
+// GetLatestMeasurementTimes returns the time of the latest measurement of each asset with measurements since the given time.
+func (m *MeasurementsRepository) GetLatestMeasurementTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	ctx, cancel := m.obs.Span(ctx, "measurements.repository.GetLatestMeasurementTimes", zap.Time("since", since))
+	defer cancel()
+
+	var latest []struct {
+		AssetID string
+		Latest  time.Time
+	}
+	result := m.db.WithContext(ctx).
+		Model(&Measurement{}).
+		Select("asset_id, max(timestamp) AS latest").
+		Where("timestamp >= ?", since).
+		Group("asset_id").
+		Scan(&latest)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	times := make(map[string]time.Time, len(latest))
+	for _, l := range latest {
+		times[l.AssetID] = l.Latest
+	}
+
+	return times, nil
+}
+
+func toMeasurement(dbMeasurement Measurement) (*measurements.Measurement, error) {
+	measurement := measurements.Measurement{
+		Id:            dbMeasurement.MeasurementID,
+		Time:          dbMeasurement.Timestamp,
+		Power:         measurements.Power{Value: dbMeasurement.PowerValue, Unit: measurements.Unit(dbMeasurement.PowerUnit)},
+		StateOfEnergy: dbMeasurement.StateOfEnergy,
+	}
+
+	if len(dbMeasurement.Metrics) > 0 {
+		err := json.Unmarshal(dbMeasurement.Metrics, &measurement.Metrics)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode metrics")
+		}
+	}
+
+	return &measurement, nil
+}
+
+func toMeasurements(dbMeasurements []Measurement) ([]measurements.Measurement, error) {
+	result := make([]measurements.Measurement, len(dbMeasurements))
+	for i, dbMeasurement := range dbMeasurements {
+		measurement, err := toMeasurement(dbMeasurement)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *measurement
+	}
+	return result, nil
+}
+
+func fromMeasurement(assetId string, measurement measurements.Measurement) (Measurement, error) {
+	dbMeasurement := Measurement{
+		MeasurementID: measurement.Id,
+		AssetID:       assetId,
+		Timestamp:     measurement.Time,
+		PowerValue:    measurement.Power.Value,
+		PowerUnit:     string(measurement.Power.Unit),
+		StateOfEnergy: measurement.StateOfEnergy,
+	}
+
+	if len(measurement.Metrics) > 0 {
+		metrics, err := json.Marshal(measurement.Metrics)
+		if err != nil {
+			return dbMeasurement, err
+		}
+		dbMeasurement.Metrics = metrics
+	}
+
+	return dbMeasurement, nil
+}
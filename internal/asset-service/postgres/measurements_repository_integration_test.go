@@ -0,0 +1,85 @@
+//go:build integration
+
+package postgres
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/repositorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newIntegrationRepository connects to the TimescaleDB at TIMESCALE_URI and creates the repository in a new schema,
+// which is dropped after the test.
+func newIntegrationRepository(t *testing.T) *MeasurementsRepository {
+	t.Helper()
+
+	uri := os.Getenv("TIMESCALE_URI")
+	if uri == "" {
+		t.Skip("TIMESCALE_URI is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(uri), &gorm.Config{Logger: gormlogger.Discard})
+	require.NoError(t, err)
+
+	schema := fmt.Sprintf("measurements_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE SCHEMA "+schema).Error)
+	t.Cleanup(func() {
+		_ = db.Exec("SELECT delete_job(job_id) FROM timescaledb_information.jobs WHERE proc_schema = ?", schema).Error
+		_ = db.Exec("DROP SCHEMA " + schema + " CASCADE").Error
+		if sqlDb, err := db.DB(); err == nil {
+			_ = sqlDb.Close()
+		}
+	})
+
+	// Every connection of the pool must use the test schema
+	sqlDb, err := db.DB()
+	require.NoError(t, err)
+	sqlDb.SetMaxOpenConns(1)
+	require.NoError(t, db.Exec("SET search_path TO "+schema+", public").Error)
+
+	repository, err := NewMeasurementsRepository(observability.NewNoopObservability(), db, 0)
+	require.NoError(t, err)
+
+	return repository
+}
+
+func TestMeasurementsRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) measurements.Repository {
+		return newIntegrationRepository(t)
+	})
+}
+
+func TestMeasurementsRepository_ExpireMeasurementIds(t *testing.T) {
+	repository := newIntegrationRepository(t)
+	db := repository.db
+
+	now := time.Now()
+	require.NoError(t, db.Create(&[]MeasurementId{
+		{ID: "expired", AssetID: "asset-1", Timestamp: now, CreatedAt: now.Add(-measurements.DeduplicationWindow - time.Hour)},
+		{ID: "kept", AssetID: "asset-1", Timestamp: now, CreatedAt: now},
+	}).Error)
+
+	// The job is replaced when the repository is created again
+	_, err := NewMeasurementsRepository(observability.NewNoopObservability(), db, 0)
+	require.NoError(t, err)
+
+	var jobs []int
+	require.NoError(t, db.Raw("SELECT job_id FROM timescaledb_information.jobs WHERE proc_schema = current_schema() AND proc_name = 'expire_asset_measurement_ids'").Scan(&jobs).Error)
+	require.Len(t, jobs, 1)
+
+	require.NoError(t, db.Exec("CALL run_job(?)", jobs[0]).Error)
+
+	var ids []string
+	require.NoError(t, db.Model(&MeasurementId{}).Pluck("id", &ids).Error)
+	assert.Equal(t, []string{"kept"}, ids)
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SimulatorConfiguration is the read model of the simulator configurations, which are owned by the simulator.
+// Only the columns the asset service needs are read.
+type SimulatorConfiguration struct {
+	ID                  string
+	Version             int
+	AssetId             string
+	Type                string
+	MeasurementInterval time.Duration
+	DeletedAt           gorm.DeletedAt
+}
+
+// TableName of the simulator configurations, as the read model doesn't share the name of the simulator's entity.
+func (SimulatorConfiguration) TableName() string {
+	return "simulator_configurations"
+}
+
+// SimulatorConfigurationRepository reads the simulator configurations from the Postgres database shared with the simulator.
+type SimulatorConfigurationRepository struct {
+	obs observability.Observability
+	db  *gorm.DB
+}
+
+func NewSimulatorConfigurationRepository(obs observability.Observability, db *gorm.DB) *SimulatorConfigurationRepository {
+	return &SimulatorConfigurationRepository{
+		obs: obs,
+		db:  db,
+	}
+}
+
+// GetAssetConfiguration returns the latest configuration for the asset with the given ID.
+func (s *SimulatorConfigurationRepository) GetAssetConfiguration(ctx context.Context, assetId string) (*simulator.Configuration, error) {
+	ctx, cancel := s.obs.Span(ctx, "configuration.repository.GetAssetConfiguration", zap.String("assetId", assetId))
+	defer cancel()
+
+	var dbConfigs []SimulatorConfiguration
+	result := s.db.WithContext(ctx).Where("asset_id = ?", assetId).Order("version desc").Limit(1).Find(&dbConfigs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(dbConfigs) == 0 {
+		return nil, simulator.ErrConfigNotFound
+	}
+
+	cfg := toConfiguration(dbConfigs[0])
+	return &cfg, nil
+}
+
+// GetConfigurations returns configurations for all assets.
+func (s *SimulatorConfigurationRepository) GetConfigurations(ctx context.Context) ([]simulator.Configuration, error) {
+	ctx, cancel := s.obs.Span(ctx, "configuration.repository.GetConfigurations")
+	defer cancel()
+
+	var dbConfigs []SimulatorConfiguration
+	result := s.db.WithContext(ctx).Find(&dbConfigs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var configs []simulator.Configuration
+	for _, dbConfig := range dbConfigs {
+		configs = append(configs, toConfiguration(dbConfig))
+	}
+
+	return configs, nil
+}
+
+func toConfiguration(dbConfig SimulatorConfiguration) simulator.Configuration {
+	return simulator.Configuration{
+		Id:                  dbConfig.ID,
+		Version:             strconv.Itoa(dbConfig.Version),
+		AssetId:             dbConfig.AssetId,
+		Type:                domain.AssetType(dbConfig.Type),
+		MeasurementInterval: dbConfig.MeasurementInterval,
+	}
+}
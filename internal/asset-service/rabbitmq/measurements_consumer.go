@@ -2,12 +2,14 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
-	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/assets"
 	"asset-measurements-assignment/internal/domain/measurements/service"
 	rmq "asset-measurements-assignment/internal/pkg/infrastructure/rabbitmq"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"asset-measurements-assignment/internal/pkg/metrics"
+	"github.com/pkg/errors"
 	"github.com/wagslane/go-rabbitmq"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.opentelemetry.io/otel/trace"
@@ -20,16 +22,19 @@ const measurementExchange = "measurement"
 type Handler struct {
 	obs      observability.Observability
 	consumer *rabbitmq.Consumer
+	state    *rmq.State
 	service  service.ConsumerService
+	metrics  *metrics.ConsumerMetrics
 }
 
-func NewHandler(obs observability.Observability, conn *rabbitmq.Conn, service service.ConsumerService) (*Handler, error) {
+// NewHandler creates a consumer on the shared connection. The state of its channel is tracked in state.
+func NewHandler(obs observability.Observability, conn *rabbitmq.Conn, state *rmq.State, service service.ConsumerService, metrics *metrics.ConsumerMetrics) (*Handler, error) {
 	// Create a new measurements consumer
 	consumer, err := rabbitmq.NewConsumer(
 		conn,
 		"",
 		// Enable consumer logging
-		rabbitmq.WithConsumerOptionsLogger(rmq.NewLogger(obs)),
+		rabbitmq.WithConsumerOptionsLogger(state.Logger(rmq.NewLogger(obs))),
 		rabbitmq.WithConsumerOptionsRoutingKey(measurementRoutingKey),
 		rabbitmq.WithConsumerOptionsExchangeName(measurementExchange),
 		rabbitmq.WithConsumerOptionsQueueDurable,
@@ -42,15 +47,21 @@ func NewHandler(obs observability.Observability, conn *rabbitmq.Conn, service se
 		service:  service,
 		obs:      obs.WithSpanKind(trace.SpanKindConsumer),
 		consumer: consumer,
+		state:    state,
+		metrics:  metrics,
 	}, nil
 }
 
 func (h *Handler) Start(ctx context.Context) error {
+	// In-flight measurements are drained on shutdown, so they must not be cancelled with the service context
+	ctx = context.WithoutCancel(ctx)
+
 	// Start consuming messages in a separate goroutine
 	go func() {
 		err := h.consumer.Run(h.handleMeasurement(ctx))
 		if err != nil {
 			h.obs.Log().Error("Consumer failed", zap.Error(err))
+			h.state.Fail(errors.Wrap(err, "consumer failed"))
 		}
 	}()
 
@@ -58,7 +69,10 @@ func (h *Handler) Start(ctx context.Context) error {
 }
 
 // handleMeasurement handles the incoming measurement messages.
-// It unmarshal the message, gets the assetId from the header and attempts to store the measurement.
+// It decodes the message based on its content type and attempts to store the measurement.
+// Legacy messages carry only the measurement in the body, so the assetId is taken from the header.
+// Duplicate measurements are recognized by the measurement (message) ID and acknowledged without being stored again.
+// Measurements of disabled assets are acknowledged and skipped.
 func (h *Handler) handleMeasurement(ctx context.Context) func(d rabbitmq.Delivery) (action rabbitmq.Action) {
 	return func(delivery rabbitmq.Delivery) (action rabbitmq.Action) {
 		consumeCtx, cancel, logger := h.obs.LogSpanWithTimeout(ctx, "measurement.consumer.Handle",
@@ -66,39 +80,56 @@ func (h *Handler) handleMeasurement(ctx context.Context) func(d rabbitmq.Deliver
 		)
 		defer cancel()
 		logger.Info("Consuming measurement")
+		h.metrics.Consumed()
 
-		// Check if the content type is JSON
-		if delivery.ContentType != "application/json" {
-			return rabbitmq.NackDiscard
-		}
-
-		// Unmarshal the message
-		var measurement measurements.Measurement
-		err := json.Unmarshal(delivery.Body, &measurement)
+		assetID, measurement, err := messages.DecodeMessage(messages.Message{
+			ContentType: delivery.ContentType,
+			MessageId:   delivery.MessageId,
+			Headers:     delivery.Headers,
+			Body:        delivery.Body,
+		})
 		if err != nil {
-			return rabbitmq.NackDiscard
-		}
-
-		// Get assetId from header
-		assetID, ok := delivery.Headers["assetId"].(string)
-		if !ok || assetID == "" {
+			logger.With(zap.Error(err)).Warn("Unable to decode measurement", zap.String("contentType", delivery.ContentType))
+			h.metrics.Discarded(metrics.ReasonDecodingFailed)
 			return rabbitmq.NackDiscard
 		}
 
 		// Store the measurement
-		err = h.service.AddMeasurement(consumeCtx, assetID, measurement)
+		outcome, err := h.service.AddMeasurement(consumeCtx, assetID, *measurement)
 		if err != nil {
 			logger.With(zap.Error(err)).Error("Failed to store measurement")
+			h.metrics.Discarded(discardReason(err))
 			// Requeue?
 			return rabbitmq.NackDiscard
 		}
 
+		switch outcome {
+		case service.OutcomeDuplicate:
+			h.metrics.Skipped(metrics.ReasonDuplicate)
+		case service.OutcomeAssetDisabled:
+			h.metrics.Skipped(metrics.ReasonAssetDisabled)
+		default:
+			h.metrics.Stored()
+		}
+
 		return rabbitmq.Ack
 	}
 }
 
-// Close closes the consumer.
-func (h *Handler) Close() error {
-	h.consumer.Close()
-	return nil
+func discardReason(err error) string {
+	switch {
+	case errors.Is(err, service.ErrInvalidMeasurement):
+		return metrics.ReasonInvalidMeasurement
+	case errors.Is(err, assets.ErrAssetNotFound):
+		return metrics.ReasonAssetNotFound
+	default:
+		return metrics.ReasonStoringFailed
+	}
+}
+
+// Close stops consuming and waits for the in-flight measurements to be handled, until the context is done.
+// Unacknowledged deliveries are requeued by RabbitMQ.
+func (h *Handler) Close(ctx context.Context) error {
+	h.consumer.CloseWithContext(ctx)
+	return ctx.Err()
 }
@@ -2,12 +2,19 @@ package rabbitmq
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"asset-measurements-assignment/internal/domain/assets"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/service"
 	serviceMock "asset-measurements-assignment/internal/domain/measurements/service/mocks"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"asset-measurements-assignment/internal/pkg/metrics"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +25,17 @@ import (
 func TestHandler_handleMeasurement(t *testing.T) {
 	mockObs := observability.NewNoopObservability()
 
+	protobufEnvelope, err := messages.Encode(messages.EncodingProtobuf, messages.NewMeasurementEnvelope(
+		"5",
+		messages.Producer{Name: "simulator", Version: "0.0.1"},
+		measurements.Measurement{
+			Id:    "message-5",
+			Power: measurements.Power{Value: 1000, Unit: measurements.UnitWatt},
+			Time:  time.Now(),
+		},
+	))
+	assert.NoError(t, err)
+
 	tests := []struct {
 		name   string
 		args   rabbitmq.Delivery
@@ -40,6 +58,85 @@ func TestHandler_handleMeasurement(t *testing.T) {
 			},
 			result: rabbitmq.Ack,
 		},
+		{
+			name: "Message ID is used as measurement ID",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers: amqp091.Table{
+						"assetId": "2",
+					},
+					ContentType: "application/json",
+					MessageId:   "message-1",
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`),
+				},
+			},
+			result: rabbitmq.Ack,
+		},
+		{
+			name: "JSON envelope",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers:     amqp091.Table{},
+					ContentType: messages.ContentTypeJSON,
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"schemaVersion": 1, "messageId": "message-4", "assetId": "4", "producer": {"name": "simulator", "version": "0.0.1"}, "measurement": {"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}}`),
+				},
+			},
+			result: rabbitmq.Ack,
+		},
+		{
+			name: "Protobuf envelope",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers:     amqp091.Table{},
+					ContentType: messages.ContentTypeProtobuf,
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        protobufEnvelope,
+				},
+			},
+			result: rabbitmq.Ack,
+		},
+		{
+			name: "Unsupported schema version",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers:     amqp091.Table{},
+					ContentType: messages.ContentTypeJSON,
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"schemaVersion": 99, "assetId": "4", "measurement": {"power": {"value": 1000, "unit": "W"}}}`),
+				},
+			},
+			result: rabbitmq.NackDiscard,
+		},
+		{
+			name: "Unsupported content type",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers: amqp091.Table{
+						"assetId": "1",
+					},
+					ContentType: "text/plain",
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`),
+				},
+			},
+			result: rabbitmq.NackDiscard,
+		},
 		{
 			name: "AssetId is missing",
 			args: rabbitmq.Delivery{
@@ -89,24 +186,109 @@ func TestHandler_handleMeasurement(t *testing.T) {
 			},
 			result: rabbitmq.NackDiscard,
 		},
+		{
+			name: "Asset disabled",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers: amqp091.Table{
+						"assetId": "6",
+					},
+					ContentType: "application/json",
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`),
+				},
+			},
+			result: rabbitmq.Ack,
+		},
+		{
+			name: "Duplicate measurement",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers: amqp091.Table{
+						"assetId": "8",
+					},
+					ContentType: "application/json",
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`),
+				},
+			},
+			result: rabbitmq.Ack,
+		},
+		{
+			name: "Asset not found",
+			args: rabbitmq.Delivery{
+				Delivery: amqp091.Delivery{
+					Headers: amqp091.Table{
+						"assetId": "7",
+					},
+					ContentType: "application/json",
+					MessageId:   uuid.New().String(),
+					Timestamp:   time.Now(),
+					Exchange:    measurementExchange,
+					RoutingKey:  measurementRoutingKey,
+					Body:        []byte(`{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`),
+				},
+			},
+			result: rabbitmq.NackDiscard,
+		},
 	}
 
+	registry := metrics.NewRegistry(observability.MetricsConfig{Enabled: true})
+	consumerMetrics := metrics.NewConsumerMetrics(registry)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			consumerServiceMock := serviceMock.NewMockConsumerService(t)
 
 			switch tt.name {
 			case "Valid measurement":
-				consumerServiceMock.EXPECT().AddMeasurement(mock.Anything, "1", mock.Anything).Return(nil).Once()
+				consumerServiceMock.EXPECT().AddMeasurement(mock.Anything, "1", mock.Anything).Return(service.OutcomeStored, nil).Once()
+			case "Message ID is used as measurement ID":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "2", mock.MatchedBy(func(m measurements.Measurement) bool {
+						return m.Id == "message-1"
+					})).
+					Return(service.OutcomeStored, nil).Once()
+			case "JSON envelope":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "4", mock.MatchedBy(func(m measurements.Measurement) bool {
+						return m.Id == "message-4" && m.Power.Value == 1000
+					})).
+					Return(service.OutcomeStored, nil).Once()
+			case "Protobuf envelope":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "5", mock.MatchedBy(func(m measurements.Measurement) bool {
+						return m.Id == "message-5" && m.Power.Value == 1000
+					})).
+					Return(service.OutcomeStored, nil).Once()
 			case "Unable to store measurement":
 				consumerServiceMock.EXPECT().
 					AddMeasurement(mock.Anything, "3", mock.Anything).
-					Return(errors.New("failed to store measurement")).Once()
+					Return(service.AddOutcome(""), errors.New("failed to store measurement")).Once()
+			case "Asset disabled":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "6", mock.Anything).
+					Return(service.OutcomeAssetDisabled, nil).Once()
+			case "Duplicate measurement":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "8", mock.Anything).
+					Return(service.OutcomeDuplicate, nil).Once()
+			case "Asset not found":
+				consumerServiceMock.EXPECT().
+					AddMeasurement(mock.Anything, "7", mock.Anything).
+					Return(service.AddOutcome(""), assets.ErrAssetNotFound).Once()
 			}
 
 			h := &Handler{
 				obs:     mockObs,
 				service: consumerServiceMock,
+				metrics: consumerMetrics,
 			}
 
 			handleFn := h.handleMeasurement(context.Background())
@@ -115,4 +297,28 @@ func TestHandler_handleMeasurement(t *testing.T) {
 			assert.Equal(t, tt.result, result)
 		})
 	}
+
+	expected := `
+# HELP asset_service_measurements_consumed_total Number of consumed measurement messages.
+# TYPE asset_service_measurements_consumed_total counter
+asset_service_measurements_consumed_total 12
+# HELP asset_service_measurements_discarded_total Number of rejected measurement messages, by reason.
+# TYPE asset_service_measurements_discarded_total counter
+asset_service_measurements_discarded_total{reason="asset_not_found"} 1
+asset_service_measurements_discarded_total{reason="decoding_failed"} 4
+asset_service_measurements_discarded_total{reason="storing_failed"} 1
+# HELP asset_service_measurements_skipped_total Number of acknowledged measurements which were not stored, by reason.
+# TYPE asset_service_measurements_skipped_total counter
+asset_service_measurements_skipped_total{reason="asset_disabled"} 1
+asset_service_measurements_skipped_total{reason="duplicate"} 1
+# HELP asset_service_measurements_stored_total Number of stored measurements.
+# TYPE asset_service_measurements_stored_total counter
+asset_service_measurements_stored_total 4
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"asset_service_measurements_consumed_total",
+		"asset_service_measurements_discarded_total",
+		"asset_service_measurements_skipped_total",
+		"asset_service_measurements_stored_total",
+	))
 }
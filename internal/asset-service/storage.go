@@ -0,0 +1,66 @@
+package asset_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"asset-measurements-assignment/internal/asset-service/memory"
+	"asset-measurements-assignment/internal/asset-service/mongodb"
+	postgres2 "asset-measurements-assignment/internal/asset-service/postgres"
+	domainMeasurements "asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/pkg/health"
+	"asset-measurements-assignment/internal/pkg/infrastructure/mongo"
+	"github.com/xBlaz3kx/DevX/observability"
+	"gorm.io/gorm"
+)
+
+// Measurement storage backends
+const (
+	StorageMongo     = "mongo"
+	StorageTimescale = "timescale"
+	StorageMemory    = "memory"
+)
+
+// newMeasurementsRepository creates the measurements repository of the configured storage backend, with the health checks
+// of the backend. The returned function releases the resources of the backend.
+func newMeasurementsRepository(ctx context.Context, obs observability.Observability, cfg Config, postgresDb *gorm.DB) (domainMeasurements.Repository, []health.Check, func(ctx context.Context) error, error) {
+	switch cfg.Storage {
+	case "", StorageMongo:
+		mongoClient, err := mongo.NewClient(cfg.Mongo)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		repository, err := mongodb.NewMeasurementsRepository(obs, mongoClient.Database(mongo.Database), cfg.Retention)
+		if err != nil {
+			_ = mongoClient.Disconnect(ctx)
+			return nil, nil, nil, err
+		}
+
+		// Materialise the rollups in the background, until the storage is closed
+		repository.StartRollups()
+		closeStorage := func(ctx context.Context) error {
+			return errors.Join(repository.StopRollups(ctx), mongoClient.Disconnect(ctx))
+		}
+
+		return repository, []health.Check{health.MongoCheck(mongoClient)}, closeStorage, nil
+	case StorageTimescale:
+		// Measurements are stored in the Postgres database, which must have the TimescaleDB extension
+		repository, err := postgres2.NewMeasurementsRepository(obs, postgresDb, cfg.Retention.Measurements)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// The Postgres database is checked by the asset service
+		return repository, nil, closeNothing, nil
+	case StorageMemory:
+		return memory.NewMeasurementsRepository(obs), nil, closeNothing, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown measurement storage %q", cfg.Storage)
+	}
+}
+
+func closeNothing(context.Context) error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package measurements
+
+import "time"
+
+const (
+	// DefaultGapIntervals is the number of missed measurement intervals after which a gap is reported.
+	DefaultGapIntervals = 3
+)
+
+type CompletenessParams struct {
+	TimeRange
+
+	// Size of the buckets (minute, 15min or hour)
+	GroupBy string `form:"groupBy"`
+
+	// Only gaps longer than the number of measurement intervals are reported, defaults to DefaultGapIntervals
+	GapIntervals int `form:"gapIntervals"`
+}
+
+// BucketCount is the number of measurements stored within the bucket starting at Time.
+type BucketCount struct {
+	Time  time.Time
+	Count int
+}
+
+// Gap is a period without measurements.
+type Gap struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Number of measurements expected, but not received, within the gap
+	MissingSamples int `json:"missingSamples"`
+}
+
+type CompletenessBucket struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Expected int       `json:"expected"`
+	Received int       `json:"received"`
+}
+
+// CompletenessReport compares the measurements received for the asset to the number expected
+// based on the measurement interval of the asset's simulator configuration.
+// The measurement interval is reported in seconds.
+type CompletenessReport struct {
+	AssetId         string               `json:"assetId"`
+	IntervalSeconds float64              `json:"measurementIntervalSeconds"`
+	Expected        int                  `json:"expected"`
+	Received        int                  `json:"received"`
+	Buckets         []CompletenessBucket `json:"buckets"`
+	Gaps            []Gap                `json:"gaps"`
+}
+
+// AssetActivity reports when the last measurement of the asset was received, and whether the asset went silent,
+// i.e. no measurement was received for the number of measurement intervals. The measurement interval is reported
+// in seconds.
+type AssetActivity struct {
+	AssetId         string     `json:"assetId"`
+	IntervalSeconds float64    `json:"measurementIntervalSeconds"`
+	LastMeasurement *time.Time `json:"lastMeasurement"`
+	Silent          bool       `json:"silent"`
+}
+
+func (p CompletenessParams) Validate() error {
+	if p.From == nil || p.To == nil {
+		return ErrInvalidTimeRange
+	}
+
+	return p.TimeRange.Validate()
+}
+
+// ExpectedSamples returns the number of measurements expected within the period.
+func ExpectedSamples(from, to time.Time, interval time.Duration) int {
+	if interval <= 0 || !from.Before(to) {
+		return 0
+	}
+	return int(to.Sub(from) / interval)
+}
+
+// AddEdgeGaps adds the gaps between the start of the time range and the first measurement, and between the last
+// measurement and the end of the time range, to the gaps between the measurements. If first is nil, there are no
+// measurements within the time range, and the whole time range is a gap. The time range must be bounded on both ends.
+func AddEdgeGaps(gaps []Gap, timeRange TimeRange, first, last *time.Time, minGap time.Duration) []Gap {
+	if timeRange.From == nil || timeRange.To == nil {
+		return gaps
+	}
+
+	if first == nil {
+		if timeRange.To.Sub(*timeRange.From) > minGap {
+			gaps = append(gaps, Gap{From: *timeRange.From, To: *timeRange.To})
+		}
+		return gaps
+	}
+
+	if first.Sub(*timeRange.From) > minGap {
+		gaps = append([]Gap{{From: *timeRange.From, To: *first}}, gaps...)
+	}
+
+	if last != nil && timeRange.To.Sub(*last) > minGap {
+		gaps = append(gaps, Gap{From: *last, To: *timeRange.To})
+	}
+
+	return gaps
+}
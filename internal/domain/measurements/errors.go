@@ -0,0 +1,17 @@
+package measurements
+
+import (
+	"net/http"
+
+	"github.com/xBlaz3kx/DevX/errors"
+)
+
+var (
+	ErrInvalidUnit       = errors.New(3001, http.StatusBadRequest, "Invalid unit")
+	ErrIncompatibleUnits = errors.New(3002, http.StatusBadRequest, "Incompatible units")
+
+	ErrUnsupportedImportFormat = errors.New(3003, http.StatusUnsupportedMediaType, "Unsupported import format, expected application/x-ndjson or text/csv")
+	ErrImportTooLarge          = errors.New(3004, http.StatusRequestEntityTooLarge, "Import is too large")
+
+	ErrTooManyBuckets = errors.New(3005, http.StatusBadRequest, "Time range contains too many buckets")
+)
@@ -0,0 +1,275 @@
+package measurements
+
+import (
+	"slices"
+	"time"
+)
+
+// FillMode determines how the buckets without measurements are filled in averaged queries.
+type FillMode string
+
+const (
+	// FillNone returns only the buckets with measurements.
+	FillNone FillMode = "none"
+	// FillNull returns every bucket, with null values in the buckets without measurements.
+	FillNull FillMode = "null"
+	// FillPrevious carries the values of the previous bucket forward.
+	FillPrevious FillMode = "previous"
+	// FillLinear interpolates the values between the surrounding buckets.
+	FillLinear FillMode = "linear"
+	// FillZero fills the buckets without measurements with zeros.
+	FillZero FillMode = "zero"
+)
+
+// AveragedMeasurement is the average of the measurements within a bucket.
+// Values are nil if the bucket had no measurements and could not be filled.
+type AveragedMeasurement struct {
+	// Power
+	Power *Power `json:"power"`
+
+	// In percent
+	StateOfEnergy *float64 `json:"stateOfEnergy"`
+
+	// Additional metrics, keyed by the metric name
+	Metrics Metrics `json:"metrics,omitempty"`
+
+	// Start of the bucket
+	Start time.Time `json:"start"`
+
+	// End of the bucket, exclusive
+	End time.Time `json:"end"`
+}
+
+// NewAveragedMeasurement creates an averaged measurement from the measurement averaged by the repository,
+// where the time of the measurement is the start of the bucket.
+func NewAveragedMeasurement(measurement Measurement, interval time.Duration) AveragedMeasurement {
+	power := measurement.Power
+
+	return AveragedMeasurement{
+		Power:         &power,
+		StateOfEnergy: measurement.StateOfEnergy,
+		Metrics:       measurement.Metrics,
+		Start:         measurement.Time,
+		End:           measurement.Time.Add(interval),
+	}
+}
+
+// ConvertTo converts the power and all the metrics compatible with the unit to the unit.
+func (a AveragedMeasurement) ConvertTo(unit Unit) (AveragedMeasurement, error) {
+	measurement := Measurement{Metrics: a.Metrics}
+	if a.Power != nil {
+		measurement.Power = *a.Power
+	}
+
+	converted, err := measurement.ConvertTo(unit)
+	if err != nil {
+		return a, err
+	}
+
+	if a.Power != nil {
+		a.Power = &converted.Power
+	}
+	a.Metrics = converted.Metrics
+
+	return a, nil
+}
+
+// ConvertAveragedMeasurements converts all averaged measurements to the unit. If the unit is empty, measurements are returned as they are.
+func ConvertAveragedMeasurements(measurements []AveragedMeasurement, unit Unit) ([]AveragedMeasurement, error) {
+	if unit == "" {
+		return measurements, nil
+	}
+
+	result := make([]AveragedMeasurement, len(measurements))
+	for i, measurement := range measurements {
+		converted, err := measurement.ConvertTo(unit)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = converted
+	}
+
+	return result, nil
+}
+
+func IsValidFillMode(mode FillMode) bool {
+	switch mode {
+	case "", FillNone, FillNull, FillPrevious, FillLinear, FillZero:
+		return true
+	default:
+		return false
+	}
+}
+
+// FillBuckets returns an averaged measurement for every bucket between from and to, ordered by time, filling the buckets
+// without measurements according to the fill mode. Each metric is filled separately, so a metric missing in a bucket
+// with other metrics is filled as well. With FillNone, only the buckets with measurements are returned, in the original order.
+func FillBuckets(averaged []Measurement, from, to time.Time, interval time.Duration, mode FillMode) ([]AveragedMeasurement, error) {
+	if mode == "" || mode == FillNone {
+		result := make([]AveragedMeasurement, len(averaged))
+		for i, measurement := range averaged {
+			result[i] = NewAveragedMeasurement(measurement, interval)
+		}
+		return result, nil
+	}
+
+	start := from.UTC().Truncate(interval)
+	if to.Sub(start)/interval >= MaxBuckets {
+		return nil, ErrTooManyBuckets
+	}
+
+	// Index the averaged metrics by bucket
+	buckets := []time.Time{}
+	for bucket := start; !bucket.After(to); bucket = bucket.Add(interval) {
+		buckets = append(buckets, bucket)
+	}
+
+	series := map[string][]*Metric{}
+	for _, measurement := range averaged {
+		i := int(measurement.Time.Sub(start) / interval)
+		if i < 0 || i >= len(buckets) {
+			continue
+		}
+
+		for name, metric := range FlattenMetrics(measurement) {
+			if series[name] == nil {
+				series[name] = make([]*Metric, len(buckets))
+			}
+			series[name][i] = &metric
+		}
+	}
+
+	// Power is always reported, the state of energy only for the assets storing energy
+	if series[MetricPower] == nil {
+		series[MetricPower] = make([]*Metric, len(buckets))
+	}
+
+	for name, values := range series {
+		fillSeries(values, mode, defaultMetricUnit(name, values))
+	}
+
+	result := make([]AveragedMeasurement, len(buckets))
+	for i, bucket := range buckets {
+		result[i] = AveragedMeasurement{Start: bucket, End: bucket.Add(interval)}
+
+		for name, values := range series {
+			value := values[i]
+			if value == nil {
+				continue
+			}
+
+			switch name {
+			case MetricPower:
+				result[i].Power = &Power{Value: value.Value, Unit: value.Unit}
+			case MetricStateOfEnergy:
+				stateOfEnergy := value.Value
+				result[i].StateOfEnergy = &stateOfEnergy
+			default:
+				if result[i].Metrics == nil {
+					result[i].Metrics = Metrics{}
+				}
+				result[i].Metrics[name] = *value
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// FlattenMetrics combines the power, state of energy, if the asset stores energy, and the additional metrics into a single map.
+func FlattenMetrics(measurement Measurement) Metrics {
+	metrics := Metrics{
+		MetricPower: {Value: measurement.Power.Value, Unit: measurement.Power.Unit},
+	}
+
+	if measurement.StateOfEnergy != nil {
+		metrics[MetricStateOfEnergy] = Metric{Value: *measurement.StateOfEnergy, Unit: UnitPercent}
+	}
+
+	for name, metric := range measurement.Metrics {
+		metrics[name] = metric
+	}
+
+	return metrics
+}
+
+// MeasurementFromMetrics is the inverse of FlattenMetrics, used by the repositories averaging the power and state of energy
+// as metrics. The power defaults to watts.
+func MeasurementFromMetrics(t time.Time, metrics Metrics) Measurement {
+	power := metrics[MetricPower]
+	if power.Unit == "" {
+		power.Unit = UnitWatt
+	}
+
+	measurement := Measurement{
+		Time:  t,
+		Power: Power{Value: power.Value, Unit: power.Unit},
+	}
+
+	if stateOfEnergy, ok := metrics[MetricStateOfEnergy]; ok {
+		measurement.StateOfEnergy = &stateOfEnergy.Value
+	}
+
+	for name, metric := range metrics {
+		if name == MetricPower || name == MetricStateOfEnergy {
+			continue
+		}
+
+		if measurement.Metrics == nil {
+			measurement.Metrics = Metrics{}
+		}
+		measurement.Metrics[name] = metric
+	}
+
+	return measurement
+}
+
+// defaultMetricUnit returns the unit of the first value of the series, used for the zero-filled values.
+func defaultMetricUnit(name string, values []*Metric) Unit {
+	i := slices.IndexFunc(values, func(value *Metric) bool { return value != nil })
+	if i >= 0 {
+		return values[i].Unit
+	}
+
+	switch name {
+	case MetricPower:
+		return UnitWatt
+	case MetricStateOfEnergy:
+		return UnitPercent
+	default:
+		return ""
+	}
+}
+
+// fillSeries fills the missing (nil) values of the series in place.
+func fillSeries(values []*Metric, mode FillMode, unit Unit) {
+	previous := -1
+	for i := range values {
+		if values[i] != nil {
+			previous = i
+			continue
+		}
+
+		switch mode {
+		case FillZero:
+			values[i] = &Metric{Value: 0, Unit: unit}
+		case FillPrevious:
+			if previous >= 0 {
+				values[i] = values[previous]
+			}
+		case FillLinear:
+			next := slices.IndexFunc(values[i:], func(value *Metric) bool { return value != nil })
+			if previous < 0 || next < 0 {
+				continue
+			}
+			next += i
+
+			ratio := float64(i-previous) / float64(next-previous)
+			values[i] = &Metric{
+				Value: values[previous].Value + (values[next].Value-values[previous].Value)*ratio,
+				Unit:  values[previous].Unit,
+			}
+		}
+	}
+}
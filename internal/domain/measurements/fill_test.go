@@ -0,0 +1,109 @@
+package measurements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillBuckets(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2024, 10, 1, 12, minute, 0, 0, time.UTC)
+	}
+	power := func(value float64) *Power {
+		return &Power{Value: value, Unit: UnitWatt}
+	}
+
+	averaged := []Measurement{
+		{Time: at(1), Power: Power{Value: 100, Unit: UnitWatt}, StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+		{Time: at(4), Power: Power{Value: 400, Unit: UnitWatt}, StateOfEnergy: percent(40)},
+	}
+
+	tests := []struct {
+		name     string
+		mode     FillMode
+		expected []AveragedMeasurement
+	}{
+		{
+			name: "None",
+			mode: FillNone,
+			expected: []AveragedMeasurement{
+				{Start: at(1), End: at(2), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(4), End: at(5), Power: power(400), StateOfEnergy: percent(40)},
+			},
+		},
+		{
+			name: "Null",
+			mode: FillNull,
+			expected: []AveragedMeasurement{
+				{Start: at(0), End: at(1)},
+				{Start: at(1), End: at(2), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(2), End: at(3)},
+				{Start: at(3), End: at(4)},
+				{Start: at(4), End: at(5), Power: power(400), StateOfEnergy: percent(40)},
+				{Start: at(5), End: at(6)},
+			},
+		},
+		{
+			name: "Zero",
+			mode: FillZero,
+			expected: []AveragedMeasurement{
+				{Start: at(0), End: at(1), Power: power(0), StateOfEnergy: percent(0), Metrics: Metrics{MetricVoltage: {Value: 0, Unit: UnitVolt}}},
+				{Start: at(1), End: at(2), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(2), End: at(3), Power: power(0), StateOfEnergy: percent(0), Metrics: Metrics{MetricVoltage: {Value: 0, Unit: UnitVolt}}},
+				{Start: at(3), End: at(4), Power: power(0), StateOfEnergy: percent(0), Metrics: Metrics{MetricVoltage: {Value: 0, Unit: UnitVolt}}},
+				{Start: at(4), End: at(5), Power: power(400), StateOfEnergy: percent(40), Metrics: Metrics{MetricVoltage: {Value: 0, Unit: UnitVolt}}},
+				{Start: at(5), End: at(6), Power: power(0), StateOfEnergy: percent(0), Metrics: Metrics{MetricVoltage: {Value: 0, Unit: UnitVolt}}},
+			},
+		},
+		{
+			name: "Previous",
+			mode: FillPrevious,
+			expected: []AveragedMeasurement{
+				{Start: at(0), End: at(1)},
+				{Start: at(1), End: at(2), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(2), End: at(3), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(3), End: at(4), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(4), End: at(5), Power: power(400), StateOfEnergy: percent(40), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(5), End: at(6), Power: power(400), StateOfEnergy: percent(40), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+			},
+		},
+		{
+			name: "Linear",
+			mode: FillLinear,
+			expected: []AveragedMeasurement{
+				{Start: at(0), End: at(1)},
+				{Start: at(1), End: at(2), Power: power(100), StateOfEnergy: percent(10), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Start: at(2), End: at(3), Power: power(200), StateOfEnergy: percent(20)},
+				{Start: at(3), End: at(4), Power: power(300), StateOfEnergy: percent(30)},
+				{Start: at(4), End: at(5), Power: power(400), StateOfEnergy: percent(40)},
+				{Start: at(5), End: at(6)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FillBuckets(averaged, at(0), at(5), time.Minute, tt.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFillBuckets_TooManyBuckets(t *testing.T) {
+	to := time.Now()
+	_, err := FillBuckets(nil, to.Add(-time.Hour*24*30), to, time.Minute, FillNull)
+	assert.ErrorIs(t, err, ErrTooManyBuckets)
+}
+
+func TestAveragedMeasurement_ConvertTo(t *testing.T) {
+	converted, err := ConvertAveragedMeasurements([]AveragedMeasurement{
+		{Power: &Power{Value: 1500, Unit: UnitWatt}},
+		{},
+	}, UnitKilowatt)
+	assert.NoError(t, err)
+	assert.Equal(t, &Power{Value: 1.5, Unit: UnitKilowatt}, converted[0].Power)
+	assert.Nil(t, converted[1].Power)
+}
@@ -0,0 +1,254 @@
+package measurements
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ContentTypeNDJSON = "application/x-ndjson"
+	ContentTypeCSV    = "text/csv"
+
+	// MaxImportRows limits the number of rows in a single import batch.
+	MaxImportRows = 100000
+)
+
+// Known CSV columns. Any other column is a metric, with the unit in brackets, e.g. "voltage[V]".
+const (
+	csvColumnId            = "id"
+	csvColumnTime          = "time"
+	csvColumnPower         = "power"
+	csvColumnPowerUnit     = "powerUnit"
+	csvColumnStateOfEnergy = "stateOfEnergy"
+)
+
+// ImportRow is a single parsed row of an import batch.
+type ImportRow struct {
+	// Line number of the row in the imported file, starting with 1
+	Line        int
+	Measurement Measurement
+	// Err is set if the row could not be parsed
+	Err error
+}
+
+// ImportResult reports the outcome of an import.
+type ImportResult struct {
+	// Number of measurements stored
+	Accepted int `json:"accepted"`
+
+	// Number of measurements skipped, because they were already stored
+	Duplicates int `json:"duplicates"`
+
+	// Rows that could not be imported
+	Rejected []RejectedRow `json:"rejected"`
+}
+
+// RejectedRow is a row of an import that could not be imported.
+type RejectedRow struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// Reject adds a rejected row to the result.
+func (r *ImportResult) Reject(line int, err error) {
+	r.Rejected = append(r.Rejected, RejectedRow{Line: line, Reason: err.Error()})
+}
+
+// ParseImport parses the import batch according to the content type. Rows that cannot be parsed are
+// returned with an error, so they can be reported as rejected. An error is returned only when the batch as a whole
+// cannot be read.
+func ParseImport(contentType string, body io.Reader) ([]ImportRow, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedImportFormat
+	}
+
+	switch mediaType {
+	case ContentTypeNDJSON:
+		return parseNDJSON(body)
+	case ContentTypeCSV:
+		return parseCSV(body)
+	default:
+		return nil, ErrUnsupportedImportFormat
+	}
+}
+
+// parseNDJSON parses one JSON measurement per line. Empty lines are skipped.
+func parseNDJSON(body io.Reader) ([]ImportRow, error) {
+	rows := []ImportRow{}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		if len(rows) == MaxImportRows {
+			return nil, ErrImportTooLarge
+		}
+
+		row := ImportRow{Line: line}
+		row.Err = json.Unmarshal(data, &row.Measurement)
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read import")
+	}
+
+	return rows, nil
+}
+
+// parseCSV parses a CSV file with a header row. The time column is required and must be in RFC 3339 format.
+func parseCSV(body io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CSV header")
+	}
+
+	columns, err := parseCSVHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []ImportRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			rows = append(rows, ImportRow{Line: parseErr.Line, Err: parseErr.Err})
+			continue
+		case err != nil:
+			return nil, errors.Wrap(err, "failed to read import")
+		}
+
+		if len(rows) == MaxImportRows {
+			return nil, ErrImportTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Line: line}
+		row.Measurement, row.Err = columns.parse(record)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+type csvMetricColumn struct {
+	name string
+	unit Unit
+}
+
+type csvColumns struct {
+	index   map[string]int
+	metrics map[int]csvMetricColumn
+}
+
+func parseCSVHeader(header []string) (*csvColumns, error) {
+	columns := &csvColumns{
+		index:   map[string]int{},
+		metrics: map[int]csvMetricColumn{},
+	}
+
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+
+		switch column {
+		case csvColumnId, csvColumnTime, csvColumnPower, csvColumnPowerUnit, csvColumnStateOfEnergy:
+			columns.index[column] = i
+			continue
+		}
+
+		name, unit, ok := strings.Cut(column, "[")
+		if !ok || !strings.HasSuffix(unit, "]") || name == "" {
+			return nil, errors.Errorf("invalid CSV column %q, expected metric[unit]", column)
+		}
+		columns.metrics[i] = csvMetricColumn{name: name, unit: Unit(strings.TrimSuffix(unit, "]"))}
+	}
+
+	if _, ok := columns.index[csvColumnTime]; !ok {
+		return nil, errors.New("CSV header must contain the time column")
+	}
+
+	return columns, nil
+}
+
+func (c *csvColumns) value(record []string, column string) string {
+	i, ok := c.index[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func (c *csvColumns) parse(record []string) (Measurement, error) {
+	var (
+		measurement Measurement
+		err         error
+	)
+
+	measurement.Id = c.value(record, csvColumnId)
+	measurement.Time, err = time.Parse(time.RFC3339Nano, c.value(record, csvColumnTime))
+	if err != nil {
+		return measurement, errors.Wrap(err, "invalid time")
+	}
+
+	if power := c.value(record, csvColumnPower); power != "" {
+		measurement.Power.Value, err = strconv.ParseFloat(power, 64)
+		if err != nil {
+			return measurement, errors.Wrap(err, "invalid power")
+		}
+	}
+	measurement.Power.Unit = Unit(c.value(record, csvColumnPowerUnit))
+
+	if value := c.value(record, csvColumnStateOfEnergy); value != "" {
+		stateOfEnergy, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return measurement, errors.Wrap(err, "invalid state of energy")
+		}
+		measurement.StateOfEnergy = &stateOfEnergy
+	}
+
+	for i, column := range c.metrics {
+		if i >= len(record) || strings.TrimSpace(record[i]) == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+		if err != nil {
+			return measurement, errors.Wrapf(err, "invalid %s", column.name)
+		}
+
+		if measurement.Metrics == nil {
+			measurement.Metrics = Metrics{}
+		}
+		measurement.Metrics[column.name] = Metric{Value: value, Unit: column.unit}
+	}
+
+	return measurement, nil
+}
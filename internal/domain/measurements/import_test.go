@@ -0,0 +1,115 @@
+package measurements
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImport(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    []Measurement
+		rowErrors   []bool
+		err         bool
+	}{
+		{
+			name:        "NDJSON",
+			contentType: "application/x-ndjson",
+			body: `{"id":"a","time":"2024-10-01T12:00:00Z","power":{"value":1500,"unit":"W"},"stateOfEnergy":50}
+
+{"time":"2024-10-01T12:00:00Z","power":{"value":1,"unit":"kW"},"metrics":{"voltage":{"value":230,"unit":"V"}}}
+`,
+			expected: []Measurement{
+				{Id: "a", Time: timestamp, Power: Power{Value: 1500, Unit: UnitWatt}, StateOfEnergy: percent(50)},
+				{Time: timestamp, Power: Power{Value: 1, Unit: UnitKilowatt}, Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+			},
+			rowErrors: []bool{false, false},
+		},
+		{
+			name:        "NDJSON with invalid row",
+			contentType: "application/x-ndjson; charset=utf-8",
+			body:        "{\"time\":\"2024-10-01T12:00:00Z\"}\n{invalid\n",
+			expected:    []Measurement{{Time: timestamp}, {}},
+			rowErrors:   []bool{false, true},
+		},
+		{
+			name:        "CSV",
+			contentType: "text/csv",
+			body: `time,power,powerUnit,stateOfEnergy,voltage[V]
+2024-10-01T12:00:00Z,1500,W,50,230
+2024-10-01T12:00:00Z,1500,W,50,
+`,
+			expected: []Measurement{
+				{Time: timestamp, Power: Power{Value: 1500, Unit: UnitWatt}, StateOfEnergy: percent(50), Metrics: Metrics{MetricVoltage: {Value: 230, Unit: UnitVolt}}},
+				{Time: timestamp, Power: Power{Value: 1500, Unit: UnitWatt}, StateOfEnergy: percent(50)},
+			},
+			rowErrors: []bool{false, false},
+		},
+		{
+			name:        "CSV with invalid rows",
+			contentType: "text/csv",
+			body: `time,power
+yesterday,1500
+2024-10-01T12:00:00Z,much
+`,
+			expected:  []Measurement{{}, {Time: timestamp}},
+			rowErrors: []bool{true, true},
+		},
+		{
+			name:        "CSV without time column",
+			contentType: "text/csv",
+			body:        "power\n1500\n",
+			err:         true,
+		},
+		{
+			name:        "CSV with invalid metric column",
+			contentType: "text/csv",
+			body:        "time,voltage\n2024-10-01T12:00:00Z,230\n",
+			err:         true,
+		},
+		{
+			name:        "Unsupported content type",
+			contentType: "application/json",
+			body:        "[]",
+			err:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseImport(tt.contentType, strings.NewReader(tt.body))
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, rows, len(tt.expected))
+			for i, row := range rows {
+				assert.Equal(t, tt.rowErrors[i], row.Err != nil, "row %d", i)
+				if row.Err == nil {
+					assert.Equal(t, tt.expected[i], row.Measurement)
+				}
+			}
+		})
+	}
+}
+
+func TestParseImport_LineNumbers(t *testing.T) {
+	rows, err := ParseImport(ContentTypeNDJSON, strings.NewReader("\n{\"time\":\"2024-10-01T12:00:00Z\"}\n\n{}\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, 4, rows[1].Line)
+
+	rows, err = ParseImport(ContentTypeCSV, strings.NewReader("time\n2024-10-01T12:00:00Z\n2024-10-01T12:00:01Z\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, 3, rows[1].Line)
+}
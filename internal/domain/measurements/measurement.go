@@ -1,25 +1,151 @@
 package measurements
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
-type Unit string
+// Well-known metric names
+const (
+	MetricPower         = "power"
+	MetricStateOfEnergy = "stateOfEnergy"
+	MetricVoltage       = "voltage"
+	MetricCurrent       = "current"
+	MetricFrequency     = "frequency"
+	MetricTemperature   = "temperature"
+	MetricReactivePower = "reactivePower"
+)
 
-const UnitWatt Unit = "W"
+// metricQuantities are the quantities of the well-known metrics. Other metrics can be in any supported unit.
+var metricQuantities = map[string]Quantity{
+	MetricPower:         QuantityPower,
+	MetricStateOfEnergy: QuantityRatio,
+	MetricVoltage:       QuantityVoltage,
+	MetricCurrent:       QuantityCurrent,
+	MetricFrequency:     QuantityFrequency,
+	MetricTemperature:   QuantityTemperature,
+	MetricReactivePower: QuantityReactivePower,
+}
 
 type Power struct {
 	Value float64 `json:"value"`
 	Unit  Unit    `json:"unit"`
 }
 
+// Metric is a single measured value with its unit.
+type Metric struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// Metrics maps metric names to the measured values.
+type Metrics map[string]Metric
+
 type Measurement struct {
+	// Unique identifier of the measurement, used for deduplication
+	Id string `json:"id,omitempty"`
+
 	// Power
 	Power Power `json:"power"`
 
-	// In percent
-	StateOfEnergy float64 `json:"stateOfEnergy"`
+	// In percent, nil for assets which don't store energy, e.g. consumers
+	StateOfEnergy *float64 `json:"stateOfEnergy"`
+
+	// Additional metrics (voltage, current, frequency, ...), keyed by the metric name
+	Metrics Metrics `json:"metrics,omitempty"`
 
 	// Event timestamp
 	Time time.Time `json:"time"`
 }
+
+// Validate checks if the units of the measurement are supported and measure the quantities of the power and
+// of the well-known metrics.
+func (m *Measurement) Validate() error {
+	if m.Power.Unit != "" {
+		if !IsValidUnit(m.Power.Unit) {
+			return errors.Errorf("invalid power unit: %s", m.Power.Unit)
+		}
+
+		if m.Power.Unit.Quantity() != QuantityPower {
+			return errors.Errorf("power unit %s is not a unit of power", m.Power.Unit)
+		}
+	}
+
+	for name, metric := range m.Metrics {
+		if name == "" {
+			return errors.New("metric name is required")
+		}
+
+		if !IsValidUnit(metric.Unit) {
+			return errors.Errorf("invalid unit of metric %s: %s", name, metric.Unit)
+		}
+
+		if quantity, ok := metricQuantities[name]; ok && metric.Unit.Quantity() != quantity {
+			return errors.Errorf("unit %s of metric %s is not a unit of %s", metric.Unit, name, quantity)
+		}
+	}
+
+	return nil
+}
+
+// Normalize converts the power and the metrics to the base units of their quantities, e.g. kW to W, so the stored
+// measurements of an asset can be aggregated regardless of the units they were reported in.
+// Power without a unit is assumed to be in watts. The measurement must be valid.
+func (m Measurement) Normalize() (Measurement, error) {
+	powerUnit := m.Power.Unit
+	if powerUnit == "" {
+		powerUnit = UnitWatt
+	}
+
+	value, err := Convert(m.Power.Value, powerUnit, UnitWatt)
+	if err != nil {
+		return m, err
+	}
+	m.Power = Power{Value: value, Unit: UnitWatt}
+
+	if len(m.Metrics) > 0 {
+		metrics := make(Metrics, len(m.Metrics))
+		for name, metric := range m.Metrics {
+			base := metric.Unit.Quantity().BaseUnit()
+			value, err := Convert(metric.Value, metric.Unit, base)
+			if err != nil {
+				return m, err
+			}
+
+			metrics[name] = Metric{Value: value, Unit: base}
+		}
+		m.Metrics = metrics
+	}
+
+	return m, nil
+}
+
+// DistinctMeasurements returns the measurements without the ones whose ID appears earlier in the slice, and the number
+// of the removed measurements. Measurements without an ID are kept.
+func DistinctMeasurements(measurements []Measurement) ([]Measurement, int) {
+	distinct := make([]Measurement, 0, len(measurements))
+	ids := make(map[string]struct{}, len(measurements))
+	for _, measurement := range measurements {
+		if measurement.Id != "" {
+			if _, ok := ids[measurement.Id]; ok {
+				continue
+			}
+			ids[measurement.Id] = struct{}{}
+		}
+
+		distinct = append(distinct, measurement)
+	}
+
+	return distinct, len(measurements) - len(distinct)
+}
+
+// NewMeasurementId derives a deterministic measurement ID from the asset ID, the measurement timestamp and
+// the sequence number of the measurement. The same inputs always produce the same ID, so a redelivered
+// or replayed measurement can be recognized as a duplicate.
+func NewMeasurementId(assetId string, timestamp time.Time, sequence uint64) string {
+	name := fmt.Sprintf("%s/%d/%d", assetId, timestamp.UnixNano(), sequence)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
@@ -0,0 +1,155 @@
+package measurements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func percent(value float64) *float64 {
+	return &value
+}
+
+func TestNewMeasurementId(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	id := NewMeasurementId("asset", timestamp, 1)
+	assert.NotEmpty(t, id)
+
+	// Same inputs should produce the same ID
+	assert.Equal(t, id, NewMeasurementId("asset", timestamp, 1))
+
+	// Different inputs should produce different IDs
+	assert.NotEqual(t, id, NewMeasurementId("asset", timestamp, 2))
+	assert.NotEqual(t, id, NewMeasurementId("asset2", timestamp, 1))
+	assert.NotEqual(t, id, NewMeasurementId("asset", timestamp.Add(time.Second), 1))
+}
+
+func TestMeasurement_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		measurement Measurement
+		err         bool
+	}{
+		{
+			name: "Power and state of energy only",
+			measurement: Measurement{
+				Power:         Power{Value: 100, Unit: UnitWatt},
+				StateOfEnergy: percent(50),
+			},
+		},
+		{
+			name: "Valid metrics",
+			measurement: Measurement{
+				Power: Power{Value: 100, Unit: UnitWatt},
+				Metrics: Metrics{
+					MetricVoltage:       {Value: 230, Unit: UnitVolt},
+					MetricCurrent:       {Value: 10, Unit: UnitAmpere},
+					MetricFrequency:     {Value: 50, Unit: UnitHertz},
+					MetricTemperature:   {Value: 25, Unit: UnitCelsius},
+					MetricReactivePower: {Value: 10, Unit: UnitVoltAmpereReactive},
+				},
+			},
+		},
+		{
+			name: "Invalid power unit",
+			measurement: Measurement{
+				Power: Power{Value: 100, Unit: "horsepower"},
+			},
+			err: true,
+		},
+		{
+			name: "Power in a unit of another quantity",
+			measurement: Measurement{
+				Power: Power{Value: 230, Unit: UnitVolt},
+			},
+			err: true,
+		},
+		{
+			name: "Well-known metric in a unit of another quantity",
+			measurement: Measurement{
+				Metrics: Metrics{
+					MetricVoltage: {Value: 10, Unit: UnitAmpere},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "Custom metric",
+			measurement: Measurement{
+				Metrics: Metrics{
+					"energy": {Value: 10, Unit: UnitKilowattHour},
+				},
+			},
+		},
+		{
+			name: "Invalid metric unit",
+			measurement: Measurement{
+				Metrics: Metrics{
+					MetricVoltage: {Value: 230, Unit: "kV"},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "Empty metric name",
+			measurement: Measurement{
+				Metrics: Metrics{
+					"": {Value: 230, Unit: UnitVolt},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.measurement.Validate()
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMeasurement_Normalize(t *testing.T) {
+	measurement := Measurement{
+		Power: Power{Value: 1.5, Unit: UnitKilowatt},
+		Metrics: Metrics{
+			MetricVoltage: {Value: 230, Unit: UnitVolt},
+			"energy":      {Value: 2, Unit: UnitMegawattHour},
+		},
+	}
+
+	normalized, err := measurement.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, Measurement{
+		Power: Power{Value: 1500, Unit: UnitWatt},
+		Metrics: Metrics{
+			MetricVoltage: {Value: 230, Unit: UnitVolt},
+			"energy":      {Value: 2e6, Unit: UnitWattHour},
+		},
+	}, normalized)
+
+	// Power without a unit is in watts
+	normalized, err = Measurement{Power: Power{Value: 100}}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, Power{Value: 100, Unit: UnitWatt}, normalized.Power)
+}
+
+func TestDistinctMeasurements(t *testing.T) {
+	timestamp := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	distinct, duplicates := DistinctMeasurements([]Measurement{
+		{Id: "a", Time: timestamp},
+		{Time: timestamp},
+		{Id: "a", Time: timestamp.Add(time.Second)},
+		{Time: timestamp},
+		{Id: "b", Time: timestamp},
+	})
+
+	assert.Equal(t, 1, duplicates)
+	assert.Equal(t, []Measurement{{Id: "a", Time: timestamp}, {Time: timestamp}, {Time: timestamp}, {Id: "b", Time: timestamp}}, distinct)
+}
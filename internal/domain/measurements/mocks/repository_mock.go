@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -70,6 +72,186 @@ func (_c *MockRepository_AddMeasurement_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// AddMeasurements provides a mock function with given fields: ctx, assetId, _a2
+func (_m *MockRepository) AddMeasurements(ctx context.Context, assetId string, _a2 []measurements.Measurement) (int, error) {
+	ret := _m.Called(ctx, assetId, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMeasurements")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []measurements.Measurement) (int, error)); ok {
+		return rf(ctx, assetId, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []measurements.Measurement) int); ok {
+		r0 = rf(ctx, assetId, _a2)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []measurements.Measurement) error); ok {
+		r1 = rf(ctx, assetId, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AddMeasurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMeasurements'
+type MockRepository_AddMeasurements_Call struct {
+	*mock.Call
+}
+
+// AddMeasurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetId string
+//   - _a2 []measurements.Measurement
+func (_e *MockRepository_Expecter) AddMeasurements(ctx interface{}, assetId interface{}, _a2 interface{}) *MockRepository_AddMeasurements_Call {
+	return &MockRepository_AddMeasurements_Call{Call: _e.mock.On("AddMeasurements", ctx, assetId, _a2)}
+}
+
+func (_c *MockRepository_AddMeasurements_Call) Run(run func(ctx context.Context, assetId string, _a2 []measurements.Measurement)) *MockRepository_AddMeasurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]measurements.Measurement))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AddMeasurements_Call) Return(_a0 int, _a1 error) *MockRepository_AddMeasurements_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AddMeasurements_Call) RunAndReturn(run func(context.Context, string, []measurements.Measurement) (int, error)) *MockRepository_AddMeasurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssetMeasurementCounts provides a mock function with given fields: ctx, assetID, timeRange, groupBy
+func (_m *MockRepository) GetAssetMeasurementCounts(ctx context.Context, assetID string, timeRange measurements.TimeRange, groupBy string) ([]measurements.BucketCount, error) {
+	ret := _m.Called(ctx, assetID, timeRange, groupBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetMeasurementCounts")
+	}
+
+	var r0 []measurements.BucketCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.TimeRange, string) ([]measurements.BucketCount, error)); ok {
+		return rf(ctx, assetID, timeRange, groupBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.TimeRange, string) []measurements.BucketCount); ok {
+		r0 = rf(ctx, assetID, timeRange, groupBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]measurements.BucketCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, measurements.TimeRange, string) error); ok {
+		r1 = rf(ctx, assetID, timeRange, groupBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAssetMeasurementCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetMeasurementCounts'
+type MockRepository_GetAssetMeasurementCounts_Call struct {
+	*mock.Call
+}
+
+// GetAssetMeasurementCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - timeRange measurements.TimeRange
+//   - groupBy string
+func (_e *MockRepository_Expecter) GetAssetMeasurementCounts(ctx interface{}, assetID interface{}, timeRange interface{}, groupBy interface{}) *MockRepository_GetAssetMeasurementCounts_Call {
+	return &MockRepository_GetAssetMeasurementCounts_Call{Call: _e.mock.On("GetAssetMeasurementCounts", ctx, assetID, timeRange, groupBy)}
+}
+
+func (_c *MockRepository_GetAssetMeasurementCounts_Call) Run(run func(ctx context.Context, assetID string, timeRange measurements.TimeRange, groupBy string)) *MockRepository_GetAssetMeasurementCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(measurements.TimeRange), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAssetMeasurementCounts_Call) Return(_a0 []measurements.BucketCount, _a1 error) *MockRepository_GetAssetMeasurementCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAssetMeasurementCounts_Call) RunAndReturn(run func(context.Context, string, measurements.TimeRange, string) ([]measurements.BucketCount, error)) *MockRepository_GetAssetMeasurementCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssetMeasurementGaps provides a mock function with given fields: ctx, assetID, timeRange, minGap
+func (_m *MockRepository) GetAssetMeasurementGaps(ctx context.Context, assetID string, timeRange measurements.TimeRange, minGap time.Duration) ([]measurements.Gap, error) {
+	ret := _m.Called(ctx, assetID, timeRange, minGap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetMeasurementGaps")
+	}
+
+	var r0 []measurements.Gap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.TimeRange, time.Duration) ([]measurements.Gap, error)); ok {
+		return rf(ctx, assetID, timeRange, minGap)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.TimeRange, time.Duration) []measurements.Gap); ok {
+		r0 = rf(ctx, assetID, timeRange, minGap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]measurements.Gap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, measurements.TimeRange, time.Duration) error); ok {
+		r1 = rf(ctx, assetID, timeRange, minGap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAssetMeasurementGaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetMeasurementGaps'
+type MockRepository_GetAssetMeasurementGaps_Call struct {
+	*mock.Call
+}
+
+// GetAssetMeasurementGaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - timeRange measurements.TimeRange
+//   - minGap time.Duration
+func (_e *MockRepository_Expecter) GetAssetMeasurementGaps(ctx interface{}, assetID interface{}, timeRange interface{}, minGap interface{}) *MockRepository_GetAssetMeasurementGaps_Call {
+	return &MockRepository_GetAssetMeasurementGaps_Call{Call: _e.mock.On("GetAssetMeasurementGaps", ctx, assetID, timeRange, minGap)}
+}
+
+func (_c *MockRepository_GetAssetMeasurementGaps_Call) Run(run func(ctx context.Context, assetID string, timeRange measurements.TimeRange, minGap time.Duration)) *MockRepository_GetAssetMeasurementGaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(measurements.TimeRange), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAssetMeasurementGaps_Call) Return(_a0 []measurements.Gap, _a1 error) *MockRepository_GetAssetMeasurementGaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAssetMeasurementGaps_Call) RunAndReturn(run func(context.Context, string, measurements.TimeRange, time.Duration) ([]measurements.Gap, error)) *MockRepository_GetAssetMeasurementGaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAssetMeasurements provides a mock function with given fields: ctx, assetID, timeRange
 func (_m *MockRepository) GetAssetMeasurements(ctx context.Context, assetID string, timeRange measurements.TimeRange) ([]measurements.Measurement, error) {
 	ret := _m.Called(ctx, assetID, timeRange)
@@ -249,6 +431,65 @@ func (_c *MockRepository_GetLatestAssetMeasurement_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// GetLatestMeasurementTimes provides a mock function with given fields: ctx, since
+func (_m *MockRepository) GetLatestMeasurementTimes(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestMeasurementTimes")
+	}
+
+	var r0 map[string]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (map[string]time.Time, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) map[string]time.Time); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetLatestMeasurementTimes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestMeasurementTimes'
+type MockRepository_GetLatestMeasurementTimes_Call struct {
+	*mock.Call
+}
+
+// GetLatestMeasurementTimes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+func (_e *MockRepository_Expecter) GetLatestMeasurementTimes(ctx interface{}, since interface{}) *MockRepository_GetLatestMeasurementTimes_Call {
+	return &MockRepository_GetLatestMeasurementTimes_Call{Call: _e.mock.On("GetLatestMeasurementTimes", ctx, since)}
+}
+
+func (_c *MockRepository_GetLatestMeasurementTimes_Call) Run(run func(ctx context.Context, since time.Time)) *MockRepository_GetLatestMeasurementTimes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetLatestMeasurementTimes_Call) Return(_a0 map[string]time.Time, _a1 error) *MockRepository_GetLatestMeasurementTimes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetLatestMeasurementTimes_Call) RunAndReturn(run func(context.Context, time.Time) (map[string]time.Time, error)) *MockRepository_GetLatestMeasurementTimes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
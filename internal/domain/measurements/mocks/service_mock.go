@@ -22,6 +22,125 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// GetAssetActivity provides a mock function with given fields: ctx, gapIntervals
+func (_m *MockService) GetAssetActivity(ctx context.Context, gapIntervals int) ([]measurements.AssetActivity, error) {
+	ret := _m.Called(ctx, gapIntervals)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetActivity")
+	}
+
+	var r0 []measurements.AssetActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]measurements.AssetActivity, error)); ok {
+		return rf(ctx, gapIntervals)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []measurements.AssetActivity); ok {
+		r0 = rf(ctx, gapIntervals)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]measurements.AssetActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, gapIntervals)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetAssetActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetActivity'
+type MockService_GetAssetActivity_Call struct {
+	*mock.Call
+}
+
+// GetAssetActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gapIntervals int
+func (_e *MockService_Expecter) GetAssetActivity(ctx interface{}, gapIntervals interface{}) *MockService_GetAssetActivity_Call {
+	return &MockService_GetAssetActivity_Call{Call: _e.mock.On("GetAssetActivity", ctx, gapIntervals)}
+}
+
+func (_c *MockService_GetAssetActivity_Call) Run(run func(ctx context.Context, gapIntervals int)) *MockService_GetAssetActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_GetAssetActivity_Call) Return(_a0 []measurements.AssetActivity, _a1 error) *MockService_GetAssetActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetAssetActivity_Call) RunAndReturn(run func(context.Context, int) ([]measurements.AssetActivity, error)) *MockService_GetAssetActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssetCompleteness provides a mock function with given fields: ctx, assetID, params
+func (_m *MockService) GetAssetCompleteness(ctx context.Context, assetID string, params measurements.CompletenessParams) (*measurements.CompletenessReport, error) {
+	ret := _m.Called(ctx, assetID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetCompleteness")
+	}
+
+	var r0 *measurements.CompletenessReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.CompletenessParams) (*measurements.CompletenessReport, error)); ok {
+		return rf(ctx, assetID, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.CompletenessParams) *measurements.CompletenessReport); ok {
+		r0 = rf(ctx, assetID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurements.CompletenessReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, measurements.CompletenessParams) error); ok {
+		r1 = rf(ctx, assetID, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetAssetCompleteness_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetCompleteness'
+type MockService_GetAssetCompleteness_Call struct {
+	*mock.Call
+}
+
+// GetAssetCompleteness is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - params measurements.CompletenessParams
+func (_e *MockService_Expecter) GetAssetCompleteness(ctx interface{}, assetID interface{}, params interface{}) *MockService_GetAssetCompleteness_Call {
+	return &MockService_GetAssetCompleteness_Call{Call: _e.mock.On("GetAssetCompleteness", ctx, assetID, params)}
+}
+
+func (_c *MockService_GetAssetCompleteness_Call) Run(run func(ctx context.Context, assetID string, params measurements.CompletenessParams)) *MockService_GetAssetCompleteness_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(measurements.CompletenessParams))
+	})
+	return _c
+}
+
+func (_c *MockService_GetAssetCompleteness_Call) Return(_a0 *measurements.CompletenessReport, _a1 error) *MockService_GetAssetCompleteness_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetAssetCompleteness_Call) RunAndReturn(run func(context.Context, string, measurements.CompletenessParams) (*measurements.CompletenessReport, error)) *MockService_GetAssetCompleteness_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAssetMeasurements provides a mock function with given fields: ctx, assetID, timeRange
 func (_m *MockService) GetAssetMeasurements(ctx context.Context, assetID string, timeRange measurements.TimeRange) ([]measurements.Measurement, error) {
 	ret := _m.Called(ctx, assetID, timeRange)
@@ -83,23 +202,23 @@ func (_c *MockService_GetAssetMeasurements_Call) RunAndReturn(run func(context.C
 }
 
 // GetAssetMeasurementsAveraged provides a mock function with given fields: ctx, assetID, params
-func (_m *MockService) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error) {
+func (_m *MockService) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.AveragedMeasurement, error) {
 	ret := _m.Called(ctx, assetID, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAssetMeasurementsAveraged")
 	}
 
-	var r0 []measurements.Measurement
+	var r0 []measurements.AveragedMeasurement
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.AssetMeasurementAveragedParams) ([]measurements.AveragedMeasurement, error)); ok {
 		return rf(ctx, assetID, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.AssetMeasurementAveragedParams) []measurements.Measurement); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.AssetMeasurementAveragedParams) []measurements.AveragedMeasurement); ok {
 		r0 = rf(ctx, assetID, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]measurements.Measurement)
+			r0 = ret.Get(0).([]measurements.AveragedMeasurement)
 		}
 	}
 
@@ -132,12 +251,12 @@ func (_c *MockService_GetAssetMeasurementsAveraged_Call) Run(run func(ctx contex
 	return _c
 }
 
-func (_c *MockService_GetAssetMeasurementsAveraged_Call) Return(_a0 []measurements.Measurement, _a1 error) *MockService_GetAssetMeasurementsAveraged_Call {
+func (_c *MockService_GetAssetMeasurementsAveraged_Call) Return(_a0 []measurements.AveragedMeasurement, _a1 error) *MockService_GetAssetMeasurementsAveraged_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_GetAssetMeasurementsAveraged_Call) RunAndReturn(run func(context.Context, string, measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error)) *MockService_GetAssetMeasurementsAveraged_Call {
+func (_c *MockService_GetAssetMeasurementsAveraged_Call) RunAndReturn(run func(context.Context, string, measurements.AssetMeasurementAveragedParams) ([]measurements.AveragedMeasurement, error)) *MockService_GetAssetMeasurementsAveraged_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -201,6 +320,66 @@ func (_c *MockService_GetLatestAssetMeasurement_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// ImportAssetMeasurements provides a mock function with given fields: ctx, assetID, rows
+func (_m *MockService) ImportAssetMeasurements(ctx context.Context, assetID string, rows []measurements.ImportRow) (*measurements.ImportResult, error) {
+	ret := _m.Called(ctx, assetID, rows)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportAssetMeasurements")
+	}
+
+	var r0 *measurements.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []measurements.ImportRow) (*measurements.ImportResult, error)); ok {
+		return rf(ctx, assetID, rows)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []measurements.ImportRow) *measurements.ImportResult); ok {
+		r0 = rf(ctx, assetID, rows)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurements.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []measurements.ImportRow) error); ok {
+		r1 = rf(ctx, assetID, rows)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ImportAssetMeasurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportAssetMeasurements'
+type MockService_ImportAssetMeasurements_Call struct {
+	*mock.Call
+}
+
+// ImportAssetMeasurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - rows []measurements.ImportRow
+func (_e *MockService_Expecter) ImportAssetMeasurements(ctx interface{}, assetID interface{}, rows interface{}) *MockService_ImportAssetMeasurements_Call {
+	return &MockService_ImportAssetMeasurements_Call{Call: _e.mock.On("ImportAssetMeasurements", ctx, assetID, rows)}
+}
+
+func (_c *MockService_ImportAssetMeasurements_Call) Run(run func(ctx context.Context, assetID string, rows []measurements.ImportRow)) *MockService_ImportAssetMeasurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]measurements.ImportRow))
+	})
+	return _c
+}
+
+func (_c *MockService_ImportAssetMeasurements_Call) Return(_a0 *measurements.ImportResult, _a1 error) *MockService_ImportAssetMeasurements_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ImportAssetMeasurements_Call) RunAndReturn(run func(context.Context, string, []measurements.ImportRow) (*measurements.ImportResult, error)) *MockService_ImportAssetMeasurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
@@ -7,11 +7,25 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Repository stores the measurements of the assets. Every implementation must pass the conformance suite
+// in the repositorytest package.
 type Repository interface {
 	AddMeasurement(ctx context.Context, assetId string, measurement Measurement) error
+	// AddMeasurements stores the measurements in a batch, skipping the measurements which were already stored or appear
+	// earlier in the batch. It returns the number of skipped duplicates.
+	AddMeasurements(ctx context.Context, assetId string, measurements []Measurement) (int, error)
 	GetLatestAssetMeasurement(ctx context.Context, assetID string) (*Measurement, error)
 	GetAssetMeasurements(ctx context.Context, assetID string, timeRange TimeRange) ([]Measurement, error)
 	GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params AssetMeasurementAveragedParams) ([]Measurement, error)
+	GetAssetMeasurementCounts(ctx context.Context, assetID string, timeRange TimeRange, groupBy string) ([]BucketCount, error)
+	GetAssetMeasurementGaps(ctx context.Context, assetID string, timeRange TimeRange, minGap time.Duration) ([]Gap, error)
+	GetLatestMeasurementTimes(ctx context.Context, since time.Time) (map[string]time.Time, error)
+}
+
+// RollupRefresher is implemented by repositories that keep aggregates of the measurements,
+// which have to be refreshed when historical measurements are added.
+type RollupRefresher interface {
+	RefreshRollups(ctx context.Context, assetId string, from, to time.Time) error
 }
 
 type TimeRange struct {
@@ -19,7 +33,14 @@ type TimeRange struct {
 	To   *time.Time `form:"to" binding:"required"`
 }
 
-var ErrInvalidTimeRange = errors.New("invalid time range")
+// DeduplicationWindow is how long the IDs of the stored measurements are kept to detect duplicates.
+const DeduplicationWindow = time.Hour * 24 * 30
+
+var (
+	ErrInvalidTimeRange     = errors.New("invalid time range")
+	ErrDuplicateMeasurement = errors.New("duplicate measurement")
+	ErrMeasurementNotFound  = errors.New("measurement not found")
+)
 
 func (t TimeRange) Validate() error {
 	if t.From != nil {
@@ -46,6 +67,25 @@ func (t TimeRange) Validate() error {
 
 type AssetMeasurementAveragedParams struct {
 	TimeRange
-	GroupBy string `form:"groupBy" binding:"required"`
-	Sort    string `form:"sort" binding:"required"`
+	GroupBy string   `form:"groupBy" binding:"required"`
+	SortBy  SortKey  `form:"sortBy"`
+	Sort    string   `form:"sort" binding:"required"`
+	Fill    FillMode `form:"fill"`
+}
+
+// MaxBuckets limits the number of buckets produced by a query.
+const MaxBuckets = 10000
+
+// GroupByInterval returns the size of the buckets for the groupBy value.
+func GroupByInterval(groupBy string) (time.Duration, error) {
+	switch groupBy {
+	case "minute":
+		return time.Minute, nil
+	case "15min":
+		return time.Minute * 15, nil
+	case "hour":
+		return time.Hour, nil
+	default:
+		return 0, errors.New("invalid groupBy value")
+	}
 }
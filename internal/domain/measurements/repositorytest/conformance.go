@@ -0,0 +1,464 @@
+// Package repositorytest contains the conformance suite every measurements.Repository implementation must pass.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewRepository creates an empty repository. Each test gets its own repository.
+type NewRepository func(t *testing.T) measurements.Repository
+
+var start = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// at returns the time the minutes and seconds after the start of the test data.
+func at(minute, second int) time.Time {
+	return start.Add(time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+}
+
+func watts(value float64) measurements.Power {
+	return measurements.Power{Value: value, Unit: measurements.UnitWatt}
+}
+
+func percent(value float64) *float64 {
+	return &value
+}
+
+// Run runs the conformance suite against the repository.
+func Run(t *testing.T, newRepository NewRepository) {
+	tests := map[string]func(t *testing.T, repository measurements.Repository){
+		"AddMeasurement":               testAddMeasurement,
+		"DuplicateMeasurement":         testDuplicateMeasurement,
+		"AddMeasurements":              testAddMeasurements,
+		"LatestMeasurementNotFound":    testLatestMeasurementNotFound,
+		"GetAssetMeasurements":         testGetAssetMeasurements,
+		"GetAssetMeasurementsAveraged": testGetAssetMeasurementsAveraged,
+		"AveragedMetrics":              testAveragedMetrics,
+		"AveragedInvalidParams":        testAveragedInvalidParams,
+		"GetAssetMeasurementCounts":    testGetAssetMeasurementCounts,
+		"GetAssetMeasurementGaps":      testGetAssetMeasurementGaps,
+		"GetLatestMeasurementTimes":    testGetLatestMeasurementTimes,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+// addMeasurements stores the measurements for the asset, with IDs unique across the assets.
+func addMeasurements(t *testing.T, repository measurements.Repository, assetId string, measurementsToAdd ...measurements.Measurement) {
+	t.Helper()
+
+	for i, measurement := range measurementsToAdd {
+		if measurement.Id == "" {
+			measurement.Id = fmt.Sprintf("%s-%d", assetId, i)
+		}
+		require.NoError(t, repository.AddMeasurement(context.Background(), assetId, measurement))
+	}
+}
+
+// timesOf returns the times of the measurements in UTC, so they can be compared regardless of the backend's location.
+func timesOf(result []measurements.Measurement) []time.Time {
+	times := []time.Time{}
+	for _, measurement := range result {
+		times = append(times, measurement.Time.UTC())
+	}
+	return times
+}
+
+func testAddMeasurement(t *testing.T, repository measurements.Repository) {
+	ctx := context.Background()
+
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0), Power: watts(100), StateOfEnergy: percent(10)},
+		measurements.Measurement{
+			Id:            "latest",
+			Time:          at(1, 0),
+			Power:         measurements.Power{Value: 2, Unit: measurements.UnitKilowatt},
+			StateOfEnergy: percent(20),
+			Metrics:       measurements.Metrics{measurements.MetricVoltage: {Value: 230, Unit: measurements.UnitVolt}},
+		},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(2, 0), Power: watts(300)})
+
+	latest, err := repository.GetLatestAssetMeasurement(ctx, "asset-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "latest", latest.Id)
+	assert.Equal(t, at(1, 0), latest.Time.UTC())
+	assert.Equal(t, measurements.Power{Value: 2, Unit: measurements.UnitKilowatt}, latest.Power)
+	assert.Equal(t, percent(20), latest.StateOfEnergy)
+	assert.Equal(t, measurements.Metrics{measurements.MetricVoltage: {Value: 230, Unit: measurements.UnitVolt}}, latest.Metrics)
+
+	// Assets which don't store energy have no state of energy
+	latest, err = repository.GetLatestAssetMeasurement(ctx, "asset-2")
+	require.NoError(t, err)
+	assert.Nil(t, latest.StateOfEnergy)
+}
+
+func testDuplicateMeasurement(t *testing.T, repository measurements.Repository) {
+	ctx := context.Background()
+	measurement := measurements.Measurement{Id: "measurement", Time: at(0, 0), Power: watts(100)}
+
+	require.NoError(t, repository.AddMeasurement(ctx, "asset-1", measurement))
+	assert.ErrorIs(t, repository.AddMeasurement(ctx, "asset-1", measurement), measurements.ErrDuplicateMeasurement)
+
+	// The duplicate is not stored
+	from, to := at(0, 0), at(1, 0)
+	result, err := repository.GetAssetMeasurements(ctx, "asset-1", measurements.TimeRange{From: &from, To: &to})
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
+func testAddMeasurements(t *testing.T, repository measurements.Repository) {
+	ctx := context.Background()
+	require.NoError(t, repository.AddMeasurement(ctx, "asset-1", measurements.Measurement{Id: "stored", Time: at(0, 0), Power: watts(100)}))
+
+	// The stored measurement and the repeated measurement of the batch are skipped
+	duplicates, err := repository.AddMeasurements(ctx, "asset-1", []measurements.Measurement{
+		{Id: "stored", Time: at(0, 0), Power: watts(100)},
+		{Id: "new", Time: at(1, 0), Power: watts(200)},
+		{Id: "new", Time: at(1, 0), Power: watts(200)},
+		{Time: at(2, 0), Power: watts(300)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, duplicates)
+
+	from, to := at(0, 0), at(3, 0)
+	result, err := repository.GetAssetMeasurements(ctx, "asset-1", measurements.TimeRange{From: &from, To: &to})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{at(2, 0), at(1, 0), at(0, 0)}, timesOf(result))
+
+	// Adding the batch again stores nothing
+	duplicates, err = repository.AddMeasurements(ctx, "asset-1", []measurements.Measurement{
+		{Id: "stored", Time: at(0, 0), Power: watts(100)},
+		{Id: "new", Time: at(1, 0), Power: watts(200)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, duplicates)
+
+	// An empty batch is valid
+	duplicates, err = repository.AddMeasurements(ctx, "asset-1", nil)
+	require.NoError(t, err)
+	assert.Zero(t, duplicates)
+}
+
+func testLatestMeasurementNotFound(t *testing.T, repository measurements.Repository) {
+	_, err := repository.GetLatestAssetMeasurement(context.Background(), "asset-1")
+	assert.ErrorIs(t, err, measurements.ErrMeasurementNotFound)
+}
+
+func testGetAssetMeasurements(t *testing.T, repository measurements.Repository) {
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0), Power: watts(100)},
+		measurements.Measurement{Time: at(1, 0), Power: watts(200)},
+		measurements.Measurement{Time: at(2, 0), Power: watts(300)},
+		measurements.Measurement{Time: at(3, 0), Power: watts(400)},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(1, 0), Power: watts(1000)})
+
+	from, to := at(1, 0), at(3, 0)
+
+	tests := []struct {
+		name      string
+		timeRange measurements.TimeRange
+		expected  []time.Time
+	}{
+		{
+			name:      "Bounds are inclusive, latest first",
+			timeRange: measurements.TimeRange{From: &from, To: &to},
+			expected:  []time.Time{at(3, 0), at(2, 0), at(1, 0)},
+		},
+		{
+			name:      "Only from",
+			timeRange: measurements.TimeRange{From: &to},
+			expected:  []time.Time{at(3, 0)},
+		},
+		{
+			name:      "Only to",
+			timeRange: measurements.TimeRange{To: &from},
+			expected:  []time.Time{at(1, 0), at(0, 0)},
+		},
+		{
+			name:     "Unbounded",
+			expected: []time.Time{at(3, 0), at(2, 0), at(1, 0), at(0, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repository.GetAssetMeasurements(context.Background(), "asset-1", tt.timeRange)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timesOf(result))
+		})
+	}
+}
+
+func testGetAssetMeasurementsAveraged(t *testing.T, repository measurements.Repository) {
+	// Buckets average to: 12:00 -> 150 W, 60 %; 12:01 -> 400 W, 20 %; 12:02 -> 100 W, 90 %
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0), Power: watts(100), StateOfEnergy: percent(50)},
+		measurements.Measurement{Time: at(0, 30), Power: watts(200), StateOfEnergy: percent(70)},
+		measurements.Measurement{Time: at(1, 0), Power: watts(400), StateOfEnergy: percent(20)},
+		measurements.Measurement{Time: at(2, 15), Power: watts(100), StateOfEnergy: percent(90)},
+		// Outside of the time range
+		measurements.Measurement{Time: at(5, 0), Power: watts(700), StateOfEnergy: percent(10)},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(1, 0), Power: watts(1000)})
+
+	from, to := at(0, 0), at(3, 0)
+
+	tests := []struct {
+		name     string
+		groupBy  string
+		sortBy   measurements.SortKey
+		sort     string
+		expected []time.Time
+		power    []float64
+	}{
+		{
+			name:     "Time ascending by default",
+			groupBy:  "minute",
+			sort:     measurements.SortAscending,
+			expected: []time.Time{at(0, 0), at(1, 0), at(2, 0)},
+			power:    []float64{150, 400, 100},
+		},
+		{
+			name:     "Time descending",
+			groupBy:  "minute",
+			sortBy:   measurements.SortByTime,
+			sort:     measurements.SortDescending,
+			expected: []time.Time{at(2, 0), at(1, 0), at(0, 0)},
+			power:    []float64{100, 400, 150},
+		},
+		{
+			name:     "Power ascending",
+			groupBy:  "minute",
+			sortBy:   measurements.SortByPower,
+			sort:     measurements.SortAscending,
+			expected: []time.Time{at(2, 0), at(0, 0), at(1, 0)},
+			power:    []float64{100, 150, 400},
+		},
+		{
+			name:     "Power descending",
+			groupBy:  "minute",
+			sortBy:   measurements.SortByPower,
+			sort:     measurements.SortDescending,
+			expected: []time.Time{at(1, 0), at(0, 0), at(2, 0)},
+			power:    []float64{400, 150, 100},
+		},
+		{
+			name:     "State of energy ascending",
+			groupBy:  "minute",
+			sortBy:   measurements.SortByStateOfEnergy,
+			sort:     measurements.SortAscending,
+			expected: []time.Time{at(1, 0), at(0, 0), at(2, 0)},
+			power:    []float64{400, 150, 100},
+		},
+		{
+			name:     "State of energy descending",
+			groupBy:  "minute",
+			sortBy:   measurements.SortByStateOfEnergy,
+			sort:     measurements.SortDescending,
+			expected: []time.Time{at(2, 0), at(0, 0), at(1, 0)},
+			power:    []float64{100, 150, 400},
+		},
+		{
+			name:     "15 minutes",
+			groupBy:  "15min",
+			sort:     measurements.SortAscending,
+			expected: []time.Time{at(0, 0)},
+			power:    []float64{200},
+		},
+		{
+			name:     "Hour",
+			groupBy:  "hour",
+			sort:     measurements.SortAscending,
+			expected: []time.Time{at(0, 0)},
+			power:    []float64{200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repository.GetAssetMeasurementsAveraged(context.Background(), "asset-1", measurements.AssetMeasurementAveragedParams{
+				TimeRange: measurements.TimeRange{From: &from, To: &to},
+				GroupBy:   tt.groupBy,
+				SortBy:    tt.sortBy,
+				Sort:      tt.sort,
+			})
+			require.NoError(t, err)
+
+			power := []float64{}
+			for _, measurement := range result {
+				power = append(power, measurement.Power.Value)
+				assert.Equal(t, measurements.UnitWatt, measurement.Power.Unit)
+			}
+
+			assert.Equal(t, tt.expected, timesOf(result))
+			assert.Equal(t, tt.power, power)
+		})
+	}
+}
+
+func testAveragedMetrics(t *testing.T, repository measurements.Repository) {
+	// The voltage and the state of energy are averaged only over the measurements reporting them
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0), Power: watts(100), StateOfEnergy: percent(50), Metrics: measurements.Metrics{
+			measurements.MetricVoltage: {Value: 220, Unit: measurements.UnitVolt},
+		}},
+		measurements.Measurement{Time: at(0, 20), Power: watts(200)},
+		measurements.Measurement{Time: at(0, 40), Power: watts(300), StateOfEnergy: percent(70), Metrics: measurements.Metrics{
+			measurements.MetricVoltage: {Value: 240, Unit: measurements.UnitVolt},
+		}},
+	)
+
+	from, to := at(0, 0), at(1, 0)
+	result, err := repository.GetAssetMeasurementsAveraged(context.Background(), "asset-1", measurements.AssetMeasurementAveragedParams{
+		TimeRange: measurements.TimeRange{From: &from, To: &to},
+		GroupBy:   "minute",
+		Sort:      measurements.SortAscending,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	assert.Equal(t, watts(200), result[0].Power)
+	assert.Equal(t, percent(60), result[0].StateOfEnergy)
+	assert.Equal(t, measurements.Metrics{measurements.MetricVoltage: {Value: 230, Unit: measurements.UnitVolt}}, result[0].Metrics)
+
+	// Assets which don't store energy have no averaged state of energy
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(0, 0), Power: watts(100)})
+	result, err = repository.GetAssetMeasurementsAveraged(context.Background(), "asset-2", measurements.AssetMeasurementAveragedParams{
+		TimeRange: measurements.TimeRange{From: &from, To: &to},
+		GroupBy:   "minute",
+		Sort:      measurements.SortAscending,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Nil(t, result[0].StateOfEnergy)
+}
+
+func testAveragedInvalidParams(t *testing.T, repository measurements.Repository) {
+	from, to := at(0, 0), at(1, 0)
+
+	tests := []struct {
+		name   string
+		params measurements.AssetMeasurementAveragedParams
+	}{
+		{
+			name:   "Invalid groupBy",
+			params: measurements.AssetMeasurementAveragedParams{GroupBy: "day", Sort: measurements.SortAscending},
+		},
+		{
+			name:   "Invalid sortBy",
+			params: measurements.AssetMeasurementAveragedParams{GroupBy: "minute", SortBy: "voltage", Sort: measurements.SortAscending},
+		},
+		{
+			name:   "Invalid sort",
+			params: measurements.AssetMeasurementAveragedParams{GroupBy: "minute", Sort: "up"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.TimeRange = measurements.TimeRange{From: &from, To: &to}
+			_, err := repository.GetAssetMeasurementsAveraged(context.Background(), "asset-1", tt.params)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testGetAssetMeasurementCounts(t *testing.T, repository measurements.Repository) {
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0)},
+		measurements.Measurement{Time: at(0, 30)},
+		measurements.Measurement{Time: at(2, 0)},
+		measurements.Measurement{Time: at(16, 0)},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(0, 0)})
+
+	from, to := at(0, 0), at(30, 0)
+	timeRange := measurements.TimeRange{From: &from, To: &to}
+
+	counts, err := repository.GetAssetMeasurementCounts(context.Background(), "asset-1", timeRange, "minute")
+	require.NoError(t, err)
+	assert.Equal(t, []measurements.BucketCount{
+		{Time: at(0, 0), Count: 2},
+		{Time: at(2, 0), Count: 1},
+		{Time: at(16, 0), Count: 1},
+	}, utcCounts(counts))
+
+	counts, err = repository.GetAssetMeasurementCounts(context.Background(), "asset-1", timeRange, "15min")
+	require.NoError(t, err)
+	assert.Equal(t, []measurements.BucketCount{
+		{Time: at(0, 0), Count: 3},
+		{Time: at(15, 0), Count: 1},
+	}, utcCounts(counts))
+
+	_, err = repository.GetAssetMeasurementCounts(context.Background(), "asset-1", timeRange, "day")
+	assert.Error(t, err)
+}
+
+func utcCounts(counts []measurements.BucketCount) []measurements.BucketCount {
+	for i := range counts {
+		counts[i].Time = counts[i].Time.UTC()
+	}
+	return counts
+}
+
+func testGetAssetMeasurementGaps(t *testing.T, repository measurements.Repository) {
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(2, 0)},
+		measurements.Measurement{Time: at(3, 0)},
+		measurements.Measurement{Time: at(8, 0)},
+		measurements.Measurement{Time: at(9, 0)},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(5, 0)})
+
+	utcGaps := func(gaps []measurements.Gap) []measurements.Gap {
+		for i := range gaps {
+			gaps[i].From = gaps[i].From.UTC()
+			gaps[i].To = gaps[i].To.UTC()
+		}
+		return gaps
+	}
+
+	from, to := at(0, 0), at(15, 0)
+	gaps, err := repository.GetAssetMeasurementGaps(context.Background(), "asset-1", measurements.TimeRange{From: &from, To: &to}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []measurements.Gap{
+		{From: at(0, 0), To: at(2, 0)},
+		{From: at(3, 0), To: at(8, 0)},
+		{From: at(9, 0), To: at(15, 0)},
+	}, utcGaps(gaps))
+
+	// No measurements within the time range
+	from, to = at(20, 0), at(30, 0)
+	gaps, err = repository.GetAssetMeasurementGaps(context.Background(), "asset-1", measurements.TimeRange{From: &from, To: &to}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []measurements.Gap{{From: at(20, 0), To: at(30, 0)}}, utcGaps(gaps))
+}
+
+func testGetLatestMeasurementTimes(t *testing.T, repository measurements.Repository) {
+	addMeasurements(t, repository, "asset-1",
+		measurements.Measurement{Time: at(0, 0)},
+		measurements.Measurement{Time: at(5, 0)},
+	)
+	addMeasurements(t, repository, "asset-2", measurements.Measurement{Time: at(3, 0)})
+	addMeasurements(t, repository, "asset-3", measurements.Measurement{Time: at(1, 0)})
+
+	latest, err := repository.GetLatestMeasurementTimes(context.Background(), at(2, 0))
+	require.NoError(t, err)
+
+	assert.Len(t, latest, 2)
+	assert.Equal(t, at(5, 0), latest["asset-1"].UTC())
+	assert.Equal(t, at(3, 0), latest["asset-2"].UTC())
+}
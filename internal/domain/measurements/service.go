@@ -7,5 +7,8 @@ import (
 type Service interface {
 	GetLatestAssetMeasurement(ctx context.Context, assetID string) (*Measurement, error)
 	GetAssetMeasurements(ctx context.Context, assetID string, timeRange TimeRange) ([]Measurement, error)
-	GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params AssetMeasurementAveragedParams) ([]Measurement, error)
+	GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params AssetMeasurementAveragedParams) ([]AveragedMeasurement, error)
+	ImportAssetMeasurements(ctx context.Context, assetID string, rows []ImportRow) (*ImportResult, error)
+	GetAssetCompleteness(ctx context.Context, assetID string, params CompletenessParams) (*CompletenessReport, error)
+	GetAssetActivity(ctx context.Context, gapIntervals int) ([]AssetActivity, error)
 }
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/assets"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCompletenessGroupBy = "hour"
+
+	// activityLookback is how far back the latest measurements are looked up when checking if assets went silent.
+	activityLookback = time.Hour * 24
+)
+
+// GetAssetCompleteness compares the number of measurements received for the asset within the time range to the number
+// expected based on the measurement interval of the asset's simulator configuration. Gaps longer than the
+// configured number of intervals are listed in the report.
+func (m *measurementsService) GetAssetCompleteness(ctx context.Context, assetID string, params measurements.CompletenessParams) (*measurements.CompletenessReport, error) {
+	ctx, cancel, logger := m.obs.LogSpan(ctx,
+		"measurements.service.GetAssetCompleteness",
+		zap.String("assetId", assetID),
+		zap.Any("query", params),
+	)
+	defer cancel()
+	logger.Info("Getting asset measurement completeness")
+
+	err := params.Validate()
+	if err != nil {
+		return nil, assets.ErrTimeRangeViolation
+	}
+
+	if params.GroupBy == "" {
+		params.GroupBy = defaultCompletenessGroupBy
+	}
+
+	if params.GapIntervals <= 0 {
+		params.GapIntervals = measurements.DefaultGapIntervals
+	}
+
+	bucketSize, err := measurements.GroupByInterval(params.GroupBy)
+	if err != nil {
+		return nil, assets.ErrValidation
+	}
+
+	from, to := *params.From, *params.To
+	if to.Sub(from.Truncate(bucketSize))/bucketSize >= measurements.MaxBuckets {
+		return nil, measurements.ErrTooManyBuckets
+	}
+
+	// Verify if asset exists
+	_, err = m.assetRepository.GetAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration, err := m.configurationRepository.GetAssetConfiguration(ctx, assetID)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to get simulator configuration")
+		return nil, err
+	}
+	interval := configuration.MeasurementInterval
+
+	counts, err := m.repository.GetAssetMeasurementCounts(ctx, assetID, params.TimeRange, params.GroupBy)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to count asset measurements")
+		return nil, err
+	}
+
+	gaps, err := m.repository.GetAssetMeasurementGaps(ctx, assetID, params.TimeRange, interval*time.Duration(params.GapIntervals))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to get asset measurement gaps")
+		return nil, err
+	}
+
+	for i, gap := range gaps {
+		gaps[i].MissingSamples = max(measurements.ExpectedSamples(gap.From, gap.To, interval)-1, 0)
+	}
+
+	report := &measurements.CompletenessReport{
+		AssetId:         assetID,
+		IntervalSeconds: interval.Seconds(),
+		Buckets:         completenessBuckets(from, to, bucketSize, interval, counts),
+		Gaps:            gaps,
+	}
+
+	for _, bucket := range report.Buckets {
+		report.Expected += bucket.Expected
+		report.Received += bucket.Received
+	}
+
+	return report, nil
+}
+
+// completenessBuckets creates a bucket for each bucket interval between from and to, including the buckets without measurements.
+func completenessBuckets(from, to time.Time, bucketSize, interval time.Duration, counts []measurements.BucketCount) []measurements.CompletenessBucket {
+	received := make(map[int64]int, len(counts))
+	for _, count := range counts {
+		received[count.Time.Unix()] = count.Count
+	}
+
+	buckets := []measurements.CompletenessBucket{}
+	for start := from.Truncate(bucketSize); start.Before(to); start = start.Add(bucketSize) {
+		end := start.Add(bucketSize)
+
+		// The first and the last bucket may be only partially within the time range
+		expectedFrom, expectedTo := start, end
+		if expectedFrom.Before(from) {
+			expectedFrom = from
+		}
+		if expectedTo.After(to) {
+			expectedTo = to
+		}
+
+		buckets = append(buckets, measurements.CompletenessBucket{
+			Start:    start,
+			End:      end,
+			Expected: measurements.ExpectedSamples(expectedFrom, expectedTo, interval),
+			Received: received[start.Unix()],
+		})
+	}
+
+	return buckets
+}
+
+// GetAssetActivity returns the latest measurement time of the enabled assets with a simulator configuration,
+// flagging the assets that haven't sent a measurement for the number of measurement intervals.
+func (m *measurementsService) GetAssetActivity(ctx context.Context, gapIntervals int) ([]measurements.AssetActivity, error) {
+	ctx, cancel, logger := m.obs.LogSpan(ctx, "measurements.service.GetAssetActivity", zap.Int("gapIntervals", gapIntervals))
+	defer cancel()
+	logger.Info("Getting asset activity")
+
+	if gapIntervals <= 0 {
+		gapIntervals = measurements.DefaultGapIntervals
+	}
+
+	enabled := true
+	enabledAssets, err := m.assetRepository.GetAssets(ctx, assets.AssetQuery{Enabled: &enabled})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to get assets")
+		return nil, err
+	}
+
+	configurations, err := m.configurationRepository.GetConfigurations(ctx)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to get simulator configurations")
+		return nil, err
+	}
+	latestConfigurations := simulator.LatestConfigurationPerAsset(configurations)
+
+	now := time.Now()
+	lookback := activityLookback
+	for _, configuration := range latestConfigurations {
+		lookback = max(lookback, configuration.MeasurementInterval*time.Duration(gapIntervals))
+	}
+
+	latestMeasurements, err := m.repository.GetLatestMeasurementTimes(ctx, now.Add(-lookback))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to get latest measurements")
+		return nil, err
+	}
+
+	activity := []measurements.AssetActivity{}
+	for _, asset := range enabledAssets {
+		// Without a configuration, no measurements are expected
+		configuration, ok := latestConfigurations[asset.ID]
+		if !ok {
+			continue
+		}
+
+		assetActivity := measurements.AssetActivity{
+			AssetId:         asset.ID,
+			IntervalSeconds: configuration.MeasurementInterval.Seconds(),
+			Silent:          true,
+		}
+
+		if latest, ok := latestMeasurements[asset.ID]; ok {
+			assetActivity.LastMeasurement = &latest
+			assetActivity.Silent = now.Sub(latest) > configuration.MeasurementInterval*time.Duration(gapIntervals)
+		}
+
+		activity = append(activity, assetActivity)
+	}
+
+	return activity, nil
+}
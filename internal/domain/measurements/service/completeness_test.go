@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/assets"
+	"asset-measurements-assignment/internal/domain/measurements"
+	measurementMocks "asset-measurements-assignment/internal/domain/measurements/mocks"
+	"asset-measurements-assignment/internal/domain/simulator"
+	simulatorMocks "asset-measurements-assignment/internal/domain/simulator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestMeasurementsService_GetAssetCompleteness(t *testing.T) {
+	from := time.Date(2024, 10, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	monthAgo := to.Add(-time.Hour * 24 * 30)
+	hour := func(h int) time.Time {
+		return time.Date(2024, 10, 1, h, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		params   measurements.CompletenessParams
+		expected *measurements.CompletenessReport
+		err      error
+	}{
+		{
+			name:   "Completeness report",
+			params: measurements.CompletenessParams{TimeRange: measurements.TimeRange{From: &from, To: &to}},
+			expected: &measurements.CompletenessReport{
+				AssetId:         "1",
+				IntervalSeconds: 60,
+				Expected:        90,
+				Received:        50,
+				Buckets: []measurements.CompletenessBucket{
+					{Start: hour(10), End: hour(11), Expected: 30, Received: 30},
+					{Start: hour(11), End: hour(12), Expected: 60, Received: 20},
+				},
+				Gaps: []measurements.Gap{
+					{From: hour(11).Add(20 * time.Minute), To: hour(12), MissingSamples: 39},
+				},
+			},
+		},
+		{
+			name:   "No simulator configuration",
+			params: measurements.CompletenessParams{TimeRange: measurements.TimeRange{From: &from, To: &to}},
+			err:    simulator.ErrConfigNotFound,
+		},
+		{
+			name:   "Invalid time range",
+			params: measurements.CompletenessParams{TimeRange: measurements.TimeRange{From: &to, To: &from}},
+			err:    assets.ErrTimeRangeViolation,
+		},
+		{
+			name: "Too many buckets",
+			params: measurements.CompletenessParams{
+				TimeRange: measurements.TimeRange{From: &monthAgo, To: &to},
+				GroupBy:   "minute",
+			},
+			err: measurements.ErrTooManyBuckets,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetRepository := assets.NewMockRepository(t)
+			repository := measurementMocks.NewMockRepository(t)
+			configurationRepository := simulatorMocks.NewMockConfigurationReader(t)
+			service := &measurementsService{
+				obs:                     observability.NewNoopObservability(),
+				repository:              repository,
+				assetRepository:         assetRepository,
+				configurationRepository: configurationRepository,
+			}
+
+			switch tt.name {
+			case "Completeness report":
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(&assets.Asset{ID: "1"}, nil).Once()
+				configurationRepository.EXPECT().GetAssetConfiguration(mock.Anything, "1").
+					Return(&simulator.Configuration{AssetId: "1", MeasurementInterval: time.Minute}, nil).Once()
+				repository.EXPECT().GetAssetMeasurementCounts(mock.Anything, "1", tt.params.TimeRange, "hour").
+					Return([]measurements.BucketCount{{Time: hour(10), Count: 30}, {Time: hour(11), Count: 20}}, nil).Once()
+				repository.EXPECT().GetAssetMeasurementGaps(mock.Anything, "1", tt.params.TimeRange, 3*time.Minute).
+					Return([]measurements.Gap{{From: hour(11).Add(20 * time.Minute), To: hour(12)}}, nil).Once()
+			case "No simulator configuration":
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(&assets.Asset{ID: "1"}, nil).Once()
+				configurationRepository.EXPECT().GetAssetConfiguration(mock.Anything, "1").Return(nil, simulator.ErrConfigNotFound).Once()
+			}
+
+			report, err := service.GetAssetCompleteness(context.TODO(), "1", tt.params)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, report)
+			}
+		})
+	}
+}
+
+func TestMeasurementsService_GetAssetActivity(t *testing.T) {
+	assetRepository := assets.NewMockRepository(t)
+	repository := measurementMocks.NewMockRepository(t)
+	configurationRepository := simulatorMocks.NewMockConfigurationReader(t)
+	service := &measurementsService{
+		obs:                     observability.NewNoopObservability(),
+		repository:              repository,
+		assetRepository:         assetRepository,
+		configurationRepository: configurationRepository,
+	}
+
+	recent := time.Now().Add(-time.Second * 5)
+	stale := time.Now().Add(-time.Minute)
+
+	assetRepository.EXPECT().GetAssets(mock.Anything, mock.MatchedBy(func(query assets.AssetQuery) bool {
+		return query.Enabled != nil && *query.Enabled
+	})).Return([]assets.Asset{{ID: "active"}, {ID: "stale"}, {ID: "silent"}, {ID: "unconfigured"}}, nil).Once()
+	configurationRepository.EXPECT().GetConfigurations(mock.Anything).Return([]simulator.Configuration{
+		{AssetId: "active", Version: "1", MeasurementInterval: time.Minute},
+		{AssetId: "active", Version: "2", MeasurementInterval: time.Second * 2},
+		{AssetId: "stale", Version: "1", MeasurementInterval: time.Second * 10},
+		{AssetId: "silent", Version: "1", MeasurementInterval: time.Second},
+	}, nil).Once()
+	repository.EXPECT().GetLatestMeasurementTimes(mock.Anything, mock.Anything).Return(map[string]time.Time{
+		"active": recent,
+		"stale":  stale,
+	}, nil).Once()
+
+	activity, err := service.GetAssetActivity(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []measurements.AssetActivity{
+		{AssetId: "active", IntervalSeconds: 2, LastMeasurement: &recent, Silent: false},
+		{AssetId: "stale", IntervalSeconds: 10, LastMeasurement: &stale, Silent: true},
+		{AssetId: "silent", IntervalSeconds: 1, Silent: true},
+	}, activity)
+}
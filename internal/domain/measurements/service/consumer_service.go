@@ -2,21 +2,39 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"asset-measurements-assignment/internal/domain/assets"
 	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.uber.org/zap"
 )
 
+// recentMeasurementsWindowSize is the number of recently stored measurement IDs kept in memory for deduplication.
+const recentMeasurementsWindowSize = 10000
+
+// ErrInvalidMeasurement is returned when the measurement fails the validation.
+var ErrInvalidMeasurement = errors.New("invalid measurement")
+
+// AddOutcome tells whether a measurement added without an error was stored or skipped.
+type AddOutcome string
+
+const (
+	OutcomeStored        AddOutcome = "stored"
+	OutcomeDuplicate     AddOutcome = "duplicate"
+	OutcomeAssetDisabled AddOutcome = "assetDisabled"
+)
+
 type ConsumerService interface {
-	AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error
+	AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) (AddOutcome, error)
 }
 
 type consumerService struct {
 	obs             observability.Observability
 	repository      measurements.Repository
 	assetRepository assets.Repository
+	recentIds       *recentIdWindow
 }
 
 func NewConsumerService(obs observability.Observability, repository measurements.Repository, assetRepository assets.Repository) ConsumerService {
@@ -24,31 +42,63 @@ func NewConsumerService(obs observability.Observability, repository measurements
 		obs:             obs,
 		repository:      repository,
 		assetRepository: assetRepository,
+		recentIds:       newRecentIdWindow(recentMeasurementsWindowSize),
 	}
 }
 
-// AddMeasurement adds a new measurement to the database if the asset is enabled.
-func (c *consumerService) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error {
+// AddMeasurement adds a new measurement to the database if the asset is enabled. Measurements of disabled assets and
+// measurements that were already stored (based on the measurement ID) are skipped without an error, the outcome
+// tells which.
+func (c *consumerService) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) (AddOutcome, error) {
 	ctx, cancel, logger := c.obs.LogSpan(ctx, "consumer.service.AddMeasurement", zap.String("assetId", assetId))
 	defer cancel()
+
+	// Fast path: skip measurements that were recently stored
+	if measurement.Id != "" && c.recentIds.Contains(measurement.Id) {
+		logger.Info("Measurement already stored, skipping", zap.String("measurementId", measurement.Id))
+		return OutcomeDuplicate, nil
+	}
+
+	err := measurement.Validate()
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("Invalid measurement")
+		return "", fmt.Errorf("%w: %w", ErrInvalidMeasurement, err)
+	}
+
+	// Store the values in the base units, so the averages don't mix units
+	measurement, err = measurement.Normalize()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidMeasurement, err)
+	}
+
 	logger.Info("Checking if asset exists")
 
 	// Check if asset exists
 	asset, err := c.assetRepository.GetAsset(ctx, assetId)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Only add measurement if asset is enabled
-	if asset.Enabled {
-		logger.Info("Adding measurement to asset")
-		err = c.repository.AddMeasurement(ctx, assetId, measurement)
-		if err != nil {
-			return err
-		}
-	} else {
+	if !asset.Enabled {
 		logger.Info("Asset is disabled, skipping measurement")
+		return OutcomeAssetDisabled, nil
+	}
+
+	logger.Info("Adding measurement to asset")
+	outcome := OutcomeStored
+	err = c.repository.AddMeasurement(ctx, assetId, measurement)
+	switch {
+	case errors.Is(err, measurements.ErrDuplicateMeasurement):
+		logger.Info("Measurement already stored, skipping", zap.String("measurementId", measurement.Id))
+		outcome = OutcomeDuplicate
+	case err != nil:
+		return "", err
+	}
+
+	if measurement.Id != "" {
+		c.recentIds.Add(measurement.Id)
 	}
 
-	return nil
+	return outcome, nil
 }
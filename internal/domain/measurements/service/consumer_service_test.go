@@ -36,24 +36,25 @@ func (s *consumerServiceTestSuite) TestConsumerService_AddMeasurement() {
 		assetId     string
 		measurement measurements.Measurement
 		err         bool
+		expectedErr error
+		outcome     AddOutcome
 	}{
 		{
 			name:    "Added measurement",
 			assetId: "1",
 			measurement: measurements.Measurement{
-				Power:         measurements.Power{},
-				StateOfEnergy: 0.0,
-				Time:          currentTime,
+				Power: measurements.Power{},
+				Time:  currentTime,
 			},
-			err: false,
+			err:     false,
+			outcome: OutcomeStored,
 		},
 		{
 			name:    "Asset doesnt exist",
 			assetId: "2",
 			measurement: measurements.Measurement{
-				Power:         measurements.Power{},
-				StateOfEnergy: 0.0,
-				Time:          currentTime,
+				Power: measurements.Power{},
+				Time:  currentTime,
 			},
 			err: true,
 		},
@@ -61,42 +62,124 @@ func (s *consumerServiceTestSuite) TestConsumerService_AddMeasurement() {
 			name:    "Asset disabled",
 			assetId: "3",
 			measurement: measurements.Measurement{
-				Power:         measurements.Power{},
-				StateOfEnergy: 0.0,
-				Time:          currentTime,
+				Power: measurements.Power{},
+				Time:  currentTime,
 			},
-			err: false,
+			err:     false,
+			outcome: OutcomeAssetDisabled,
 		},
 		{
 			name:    "Repository error",
 			assetId: "4",
 			measurement: measurements.Measurement{
-				Power:         measurements.Power{},
-				StateOfEnergy: 0.0,
-				Time:          currentTime,
+				Power: measurements.Power{},
+				Time:  currentTime,
 			},
 			err: true,
 		},
+		{
+			name:    "Invalid measurement",
+			assetId: "7",
+			measurement: measurements.Measurement{
+				Power: measurements.Power{},
+				Metrics: measurements.Metrics{
+					measurements.MetricVoltage: {Value: 230, Unit: "unknown"},
+				},
+				Time: currentTime,
+			},
+			err:         true,
+			expectedErr: ErrInvalidMeasurement,
+		},
+		{
+			name:    "Power in volts",
+			assetId: "7",
+			measurement: measurements.Measurement{
+				Power: measurements.Power{Value: 230, Unit: measurements.UnitVolt},
+				Time:  currentTime,
+			},
+			err:         true,
+			expectedErr: ErrInvalidMeasurement,
+		},
+		{
+			name:    "Power in kilowatts",
+			assetId: "8",
+			measurement: measurements.Measurement{
+				Power: measurements.Power{Value: 1.5, Unit: measurements.UnitKilowatt},
+				Metrics: measurements.Metrics{
+					measurements.MetricReactivePower: {Value: 10, Unit: measurements.UnitVoltAmpereReactive},
+				},
+				Time: currentTime,
+			},
+			err:     false,
+			outcome: OutcomeStored,
+		},
+		{
+			name:    "Duplicate measurement in repository",
+			assetId: "5",
+			measurement: measurements.Measurement{
+				Id:    measurements.NewMeasurementId("5", currentTime, 0),
+				Power: measurements.Power{},
+				Time:  currentTime,
+			},
+			err:     false,
+			outcome: OutcomeDuplicate,
+		},
+		{
+			name:    "Recently stored measurement",
+			assetId: "6",
+			measurement: measurements.Measurement{
+				Id:    measurements.NewMeasurementId("6", currentTime, 0),
+				Power: measurements.Power{},
+				Time:  currentTime,
+			},
+			err:     false,
+			outcome: OutcomeDuplicate,
+		},
 	}
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
+			// Measurements are stored in the base units
+			stored, _ := tt.measurement.Normalize()
 
 			switch tt.name {
 			case "Added measurement":
 				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: true}, nil)
-				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, tt.measurement).Return(nil)
+				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, stored).Return(nil)
+			case "Power in kilowatts":
+				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: true}, nil)
+				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, measurements.Measurement{
+					Power: measurements.Power{Value: 1500, Unit: measurements.UnitWatt},
+					Metrics: measurements.Metrics{
+						measurements.MetricReactivePower: {Value: 10, Unit: measurements.UnitVoltAmpereReactive},
+					},
+					Time: currentTime,
+				}).Return(nil)
 			case "Asset doesnt exist":
 				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(nil, errors.New("asset not found"))
 			case "Asset disabled":
 				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: false}, nil)
 			case "Repository error":
 				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: true}, nil)
-				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, tt.measurement).Return(errors.New("repository error"))
+				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, stored).Return(errors.New("repository error"))
+			case "Duplicate measurement in repository":
+				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: true}, nil)
+				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, stored).Return(measurements.ErrDuplicateMeasurement)
+			case "Recently stored measurement":
+				// Only the first measurement should reach the repository
+				s.assetRepository.EXPECT().GetAsset(mock.Anything, tt.assetId).Return(&assets.Asset{Enabled: true}, nil).Once()
+				s.repository.EXPECT().AddMeasurement(mock.Anything, tt.assetId, stored).Return(nil).Once()
+
+				outcome, err := s.service.AddMeasurement(context.Background(), tt.assetId, tt.measurement)
+				s.Require().NoError(err)
+				s.Require().Equal(OutcomeStored, outcome)
 			}
 
-			err := s.service.AddMeasurement(context.Background(), tt.assetId, tt.measurement)
-			if tt.err {
+			outcome, err := s.service.AddMeasurement(context.Background(), tt.assetId, tt.measurement)
+			s.Assert().Equal(tt.outcome, outcome)
+			if tt.expectedErr != nil {
+				s.Assert().ErrorIs(err, tt.expectedErr)
+			} else if tt.err {
 				s.Assert().Error(err)
 			} else {
 				s.Assert().NoError(err)
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	service "asset-measurements-assignment/internal/domain/measurements/service"
 )
 
 // MockConsumerService is an autogenerated mock type for the ConsumerService type
@@ -23,21 +25,31 @@ func (_m *MockConsumerService) EXPECT() *MockConsumerService_Expecter {
 }
 
 // AddMeasurement provides a mock function with given fields: ctx, assetId, measurement
-func (_m *MockConsumerService) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) error {
+func (_m *MockConsumerService) AddMeasurement(ctx context.Context, assetId string, measurement measurements.Measurement) (service.AddOutcome, error) {
 	ret := _m.Called(ctx, assetId, measurement)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddMeasurement")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.Measurement) error); ok {
+	var r0 service.AddOutcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.Measurement) (service.AddOutcome, error)); ok {
+		return rf(ctx, assetId, measurement)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, measurements.Measurement) service.AddOutcome); ok {
 		r0 = rf(ctx, assetId, measurement)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(service.AddOutcome)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, measurements.Measurement) error); ok {
+		r1 = rf(ctx, assetId, measurement)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // MockConsumerService_AddMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMeasurement'
@@ -60,12 +72,12 @@ func (_c *MockConsumerService_AddMeasurement_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockConsumerService_AddMeasurement_Call) Return(_a0 error) *MockConsumerService_AddMeasurement_Call {
-	_c.Call.Return(_a0)
+func (_c *MockConsumerService_AddMeasurement_Call) Return(_a0 service.AddOutcome, _a1 error) *MockConsumerService_AddMeasurement_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockConsumerService_AddMeasurement_Call) RunAndReturn(run func(context.Context, string, measurements.Measurement) error) *MockConsumerService_AddMeasurement_Call {
+func (_c *MockConsumerService_AddMeasurement_Call) RunAndReturn(run func(context.Context, string, measurements.Measurement) (service.AddOutcome, error)) *MockConsumerService_AddMeasurement_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,51 @@
+package service
+
+import "sync"
+
+// recentIdWindow is a bounded, thread-safe set of recently seen measurement IDs.
+// When the window is full, the oldest ID is evicted to make room for the new one.
+type recentIdWindow struct {
+	mu    sync.Mutex
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+func newRecentIdWindow(size int) *recentIdWindow {
+	return &recentIdWindow{
+		ids:   make(map[string]struct{}, size),
+		order: make([]string, size),
+	}
+}
+
+// Contains checks if the ID was recently seen.
+func (w *recentIdWindow) Contains(id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.ids[id]
+	return ok
+}
+
+// Add adds the ID to the window, evicting the oldest ID if the window is full.
+func (w *recentIdWindow) Add(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.order) == 0 {
+		return
+	}
+
+	if _, ok := w.ids[id]; ok {
+		return
+	}
+
+	// Evict the oldest ID
+	if oldest := w.order[w.next]; oldest != "" {
+		delete(w.ids, oldest)
+	}
+
+	w.order[w.next] = id
+	w.ids[id] = struct{}{}
+	w.next = (w.next + 1) % len(w.order)
+}
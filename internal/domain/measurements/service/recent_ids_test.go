@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentIdWindow(t *testing.T) {
+	window := newRecentIdWindow(2)
+
+	window.Add("1")
+	window.Add("2")
+	assert.True(t, window.Contains("1"))
+	assert.True(t, window.Contains("2"))
+
+	// Adding an existing ID should not evict anything
+	window.Add("2")
+	assert.True(t, window.Contains("1"))
+
+	// The oldest ID should be evicted
+	window.Add("3")
+	assert.False(t, window.Contains("1"))
+	assert.True(t, window.Contains("2"))
+	assert.True(t, window.Contains("3"))
+	assert.False(t, window.Contains("4"))
+}
@@ -2,24 +2,34 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"asset-measurements-assignment/internal/domain/assets"
 	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/pkg/errors"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.uber.org/zap"
 )
 
 type measurementsService struct {
-	obs             observability.Observability
-	repository      measurements.Repository
-	assetRepository assets.Repository
+	obs                     observability.Observability
+	repository              measurements.Repository
+	assetRepository         assets.Repository
+	configurationRepository simulator.ConfigurationReader
 }
 
-func NewMeasurementsService(obs observability.Observability, assetRepository assets.Repository, repository measurements.Repository) measurements.Service {
+func NewMeasurementsService(
+	obs observability.Observability,
+	assetRepository assets.Repository,
+	repository measurements.Repository,
+	configurationRepository simulator.ConfigurationReader,
+) measurements.Service {
 	return &measurementsService{
-		obs:             obs,
-		repository:      repository,
-		assetRepository: assetRepository,
+		obs:                     obs,
+		repository:              repository,
+		assetRepository:         assetRepository,
+		configurationRepository: configurationRepository,
 	}
 }
 
@@ -73,8 +83,9 @@ func (m *measurementsService) GetAssetMeasurements(ctx context.Context, assetID
 	return measurement, nil
 }
 
-// GetAssetMeasurementsAveraged returns the average power from measurements for the given asset.
-func (m *measurementsService) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.Measurement, error) {
+// GetAssetMeasurementsAveraged returns the average power from measurements for the given asset, sorted by the requested key.
+// Unless the fill mode is none, every bucket within the time range is returned.
+func (m *measurementsService) GetAssetMeasurementsAveraged(ctx context.Context, assetID string, params measurements.AssetMeasurementAveragedParams) ([]measurements.AveragedMeasurement, error) {
 	ctx, cancel, logger := m.obs.LogSpan(ctx,
 		"measurements.service.GetAssetMeasurementsAveraged",
 		zap.String("assetId", assetID),
@@ -84,21 +95,146 @@ func (m *measurementsService) GetAssetMeasurementsAveraged(ctx context.Context,
 	logger.Info("Getting asset measurement averages")
 
 	err := params.Validate()
-	if err != nil {
+	if err != nil || params.From == nil || params.To == nil {
 		return nil, assets.ErrTimeRangeViolation
 	}
 
+	if !measurements.IsValidFillMode(params.Fill) || !measurements.IsValidSortKey(params.SortBy) {
+		return nil, assets.ErrValidation
+	}
+
+	interval, err := measurements.GroupByInterval(params.GroupBy)
+	if err != nil {
+		return nil, assets.ErrValidation
+	}
+
 	// Verify if asset exists
 	_, err = m.assetRepository.GetAsset(ctx, assetID)
 	if err != nil {
 		return nil, err
 	}
 
-	measurements, err := m.repository.GetAssetMeasurementsAveraged(ctx, assetID, params)
+	averaged, err := m.repository.GetAssetMeasurementsAveraged(ctx, assetID, params)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("Failed to get asset measurements")
 		return nil, err
 	}
 
-	return measurements, nil
+	result, err := measurements.FillBuckets(averaged, *params.From, *params.To, interval, params.Fill)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements.SortAveragedMeasurements(result, params.SortBy, params.Sort)
+
+	return result, nil
+}
+
+// importBatchSize is the number of imported measurements stored at once.
+const importBatchSize = 1000
+
+// ImportAssetMeasurements stores the historical measurements of the asset and reports the accepted and rejected rows.
+// The asset must exist, but is not required to be enabled, as the measurements were taken in the past.
+// Rows without an ID get the deterministic ID the publisher derives for the first measurement of the asset at the
+// same time, so importing the same rows again, or rows that were also received live, does not duplicate them.
+// If storing a batch fails, the result of the batches stored before is returned with the error.
+func (m *measurementsService) ImportAssetMeasurements(ctx context.Context, assetID string, rows []measurements.ImportRow) (*measurements.ImportResult, error) {
+	ctx, cancel, logger := m.obs.LogSpan(ctx,
+		"measurements.service.ImportAssetMeasurements",
+		zap.String("assetId", assetID),
+		zap.Int("rows", len(rows)),
+	)
+	defer cancel()
+	logger.Info("Importing asset measurements")
+
+	// Verify if asset exists
+	_, err := m.assetRepository.GetAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &measurements.ImportResult{Rejected: []measurements.RejectedRow{}}
+	now := time.Now()
+
+	valid := make([]measurements.Measurement, 0, len(rows))
+	for _, row := range rows {
+		if row.Err != nil {
+			result.Reject(row.Line, row.Err)
+			continue
+		}
+
+		measurement, err := normalizeImportedMeasurement(row.Measurement, now)
+		if err != nil {
+			result.Reject(row.Line, err)
+			continue
+		}
+
+		if measurement.Id == "" {
+			measurement.Id = measurements.NewMeasurementId(assetID, measurement.Time, 0)
+		}
+
+		valid = append(valid, measurement)
+	}
+
+	// Store the measurements in batches, so a large import doesn't take a round trip per row
+	var (
+		from, to time.Time
+		storeErr error
+	)
+	for start := 0; start < len(valid); start += importBatchSize {
+		batch := valid[start:min(start+importBatchSize, len(valid))]
+
+		duplicates, err := m.repository.AddMeasurements(ctx, assetID, batch)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("Failed to store imported measurements", zap.Int("stored", result.Accepted))
+			storeErr = err
+			break
+		}
+
+		result.Accepted += len(batch) - duplicates
+		result.Duplicates += duplicates
+
+		for _, measurement := range batch {
+			if from.IsZero() || measurement.Time.Before(from) {
+				from = measurement.Time
+			}
+			if measurement.Time.After(to) {
+				to = measurement.Time
+			}
+		}
+	}
+
+	// Include the stored measurements in the rollups that were already computed
+	if refresher, ok := m.repository.(measurements.RollupRefresher); ok && result.Accepted > 0 {
+		err = refresher.RefreshRollups(ctx, assetID, from, to)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("Failed to refresh rollups")
+		}
+	}
+
+	logger.Info("Imported asset measurements",
+		zap.Int("accepted", result.Accepted),
+		zap.Int("duplicates", result.Duplicates),
+		zap.Int("rejected", len(result.Rejected)),
+	)
+
+	return result, storeErr
+}
+
+// normalizeImportedMeasurement validates the imported measurement and converts it to the base units.
+func normalizeImportedMeasurement(measurement measurements.Measurement, now time.Time) (measurements.Measurement, error) {
+	if measurement.Time.IsZero() {
+		return measurement, errors.New("measurement time is required")
+	}
+
+	if measurement.Time.After(now) {
+		return measurement, errors.New("measurement time is in the future")
+	}
+
+	err := measurement.Validate()
+	if err != nil {
+		return measurement, err
+	}
+
+	return measurement.Normalize()
 }
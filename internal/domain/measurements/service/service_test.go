@@ -9,6 +9,7 @@ import (
 	"asset-measurements-assignment/internal/domain/measurements"
 	measurementMocks "asset-measurements-assignment/internal/domain/measurements/mocks"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/xBlaz3kx/DevX/observability"
@@ -192,3 +193,182 @@ func TestMeasurementService(t *testing.T) {
 	t.Skip()
 	suite.Run(t, new(measurementServiceTestSuite))
 }
+
+// refreshingRepository is a measurements repository keeping rollups.
+type refreshingRepository struct {
+	*measurementMocks.MockRepository
+	*measurementMocks.MockRollupRefresher
+}
+
+func TestMeasurementsService_ImportAssetMeasurements(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	rows := []measurements.ImportRow{
+		{Line: 1, Measurement: measurements.Measurement{Id: "a", Time: timestamp}},
+		{Line: 2, Measurement: measurements.Measurement{
+			Power: measurements.Power{Value: 2, Unit: measurements.UnitKilowatt},
+			Time:  timestamp.Add(time.Hour),
+		}},
+		{Line: 3, Err: errors.New("invalid json")},
+		{Line: 4, Measurement: measurements.Measurement{Time: time.Now().Add(time.Hour)}},
+		{Line: 5, Measurement: measurements.Measurement{}},
+		{Line: 6, Measurement: measurements.Measurement{Id: "b", Time: timestamp}},
+		{Line: 7, Measurement: measurements.Measurement{
+			Power: measurements.Power{Value: 230, Unit: measurements.UnitVolt},
+			Time:  timestamp,
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		expected *measurements.ImportResult
+		err      bool
+	}{
+		{
+			name: "Import measurements",
+			expected: &measurements.ImportResult{
+				Accepted:   2,
+				Duplicates: 1,
+				Rejected: []measurements.RejectedRow{
+					{Line: 3, Reason: "invalid json"},
+					{Line: 4, Reason: "measurement time is in the future"},
+					{Line: 5, Reason: "measurement time is required"},
+					{Line: 7, Reason: "power unit V is not a unit of power"},
+				},
+			},
+		},
+		{
+			name: "Import measurements into repository with rollups",
+			expected: &measurements.ImportResult{
+				Accepted:   2,
+				Duplicates: 1,
+				Rejected: []measurements.RejectedRow{
+					{Line: 3, Reason: "invalid json"},
+					{Line: 4, Reason: "measurement time is in the future"},
+					{Line: 5, Reason: "measurement time is required"},
+					{Line: 7, Reason: "power unit V is not a unit of power"},
+				},
+			},
+		},
+		{
+			name: "Asset not found",
+			err:  true,
+		},
+		{
+			name: "Failed to store measurement",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetRepository := assets.NewMockRepository(t)
+			repository := measurementMocks.NewMockRepository(t)
+			service := &measurementsService{
+				obs:             observability.NewNoopObservability(),
+				repository:      repository,
+				assetRepository: assetRepository,
+			}
+
+			// Disabled assets accept imports
+			asset := &assets.Asset{ID: "1", Enabled: false}
+
+			switch tt.name {
+			case "Import measurements":
+				// The valid rows are stored in one batch, the row with ID b was already stored
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(asset, nil).Once()
+				repository.EXPECT().AddMeasurements(mock.Anything, "1", []measurements.Measurement{
+					{Id: "a", Power: measurements.Power{Unit: measurements.UnitWatt}, Time: timestamp},
+					{
+						Id:    measurements.NewMeasurementId("1", timestamp.Add(time.Hour), 0),
+						Power: measurements.Power{Value: 2000, Unit: measurements.UnitWatt},
+						Time:  timestamp.Add(time.Hour),
+					},
+					{Id: "b", Power: measurements.Power{Unit: measurements.UnitWatt}, Time: timestamp},
+				}).Return(1, nil).Once()
+			case "Import measurements into repository with rollups":
+				refresher := measurementMocks.NewMockRollupRefresher(t)
+				service.repository = refreshingRepository{MockRepository: repository, MockRollupRefresher: refresher}
+
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(asset, nil).Once()
+				repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.Anything).Return(1, nil).Once()
+				refresher.EXPECT().RefreshRollups(mock.Anything, "1", timestamp, timestamp.Add(time.Hour)).Return(nil).Once()
+			case "Asset not found":
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(nil, assets.ErrAssetNotFound).Once()
+			case "Failed to store measurement":
+				assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(asset, nil).Once()
+				repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.Anything).Return(0, errors.New("db error")).Once()
+			}
+
+			result, err := service.ImportAssetMeasurements(context.TODO(), "1", rows)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMeasurementsService_ImportAssetMeasurements_Batches(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	rows := make([]measurements.ImportRow, importBatchSize+1)
+	for i := range rows {
+		rows[i] = measurements.ImportRow{Line: i + 1, Measurement: measurements.Measurement{Time: timestamp.Add(time.Duration(i) * time.Second)}}
+	}
+
+	assetRepository := assets.NewMockRepository(t)
+	repository := measurementMocks.NewMockRepository(t)
+	service := &measurementsService{
+		obs:             observability.NewNoopObservability(),
+		repository:      repository,
+		assetRepository: assetRepository,
+	}
+
+	assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(&assets.Asset{ID: "1"}, nil).Once()
+	repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.MatchedBy(func(batch []measurements.Measurement) bool {
+		return len(batch) == importBatchSize
+	})).Return(0, nil).Once()
+	repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.MatchedBy(func(batch []measurements.Measurement) bool {
+		return len(batch) == 1 && batch[0].Time.Equal(timestamp.Add(importBatchSize*time.Second))
+	})).Return(1, nil).Once()
+
+	result, err := service.ImportAssetMeasurements(context.TODO(), "1", rows)
+	assert.NoError(t, err)
+	assert.Equal(t, importBatchSize, result.Accepted)
+	assert.Equal(t, 1, result.Duplicates)
+}
+
+func TestMeasurementsService_ImportAssetMeasurements_PartialFailure(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	rows := make([]measurements.ImportRow, importBatchSize+1)
+	for i := range rows {
+		rows[i] = measurements.ImportRow{Line: i + 1, Measurement: measurements.Measurement{Time: timestamp.Add(time.Duration(i) * time.Second)}}
+	}
+
+	assetRepository := assets.NewMockRepository(t)
+	repository := measurementMocks.NewMockRepository(t)
+	refresher := measurementMocks.NewMockRollupRefresher(t)
+	service := &measurementsService{
+		obs:             observability.NewNoopObservability(),
+		repository:      refreshingRepository{MockRepository: repository, MockRollupRefresher: refresher},
+		assetRepository: assetRepository,
+	}
+
+	// The first batch is stored, the second one fails
+	assetRepository.EXPECT().GetAsset(mock.Anything, "1").Return(&assets.Asset{ID: "1"}, nil).Once()
+	repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.MatchedBy(func(batch []measurements.Measurement) bool {
+		return len(batch) == importBatchSize
+	})).Return(0, nil).Once()
+	repository.EXPECT().AddMeasurements(mock.Anything, "1", mock.MatchedBy(func(batch []measurements.Measurement) bool {
+		return len(batch) == 1
+	})).Return(0, errors.New("db error")).Once()
+	refresher.EXPECT().RefreshRollups(mock.Anything, "1", timestamp, timestamp.Add((importBatchSize-1)*time.Second)).Return(nil).Once()
+
+	result, err := service.ImportAssetMeasurements(context.TODO(), "1", rows)
+	assert.Error(t, err)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, importBatchSize, result.Accepted)
+		assert.Equal(t, 0, result.Duplicates)
+	}
+}
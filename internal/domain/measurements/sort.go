@@ -0,0 +1,111 @@
+package measurements
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SortKey is the value averaged measurements are sorted by.
+type SortKey string
+
+const (
+	SortByTime          SortKey = "time"
+	SortByPower         SortKey = "power"
+	SortByStateOfEnergy SortKey = "stateOfEnergy"
+)
+
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+func IsValidSortKey(key SortKey) bool {
+	switch key {
+	case "", SortByTime, SortByPower, SortByStateOfEnergy:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateSort checks the sort key and the sort direction of an averaged query.
+func ValidateSort(key SortKey, direction string) error {
+	if !IsValidSortKey(key) {
+		return errors.New("invalid sortBy value")
+	}
+
+	if direction != SortAscending && direction != SortDescending {
+		return errors.New("invalid sort value")
+	}
+
+	return nil
+}
+
+// SortMeasurements sorts the averaged measurements, returned by the repository, by the key in the direction.
+// Measurements with equal values are ordered by time.
+func SortMeasurements(measurements []Measurement, key SortKey, direction string) {
+	sortBy(measurements, direction, func(m Measurement) (float64, time.Time, bool) {
+		switch key {
+		case SortByPower:
+			return m.Power.Value, m.Time, true
+		case SortByStateOfEnergy:
+			if m.StateOfEnergy == nil {
+				return 0, m.Time, false
+			}
+			return *m.StateOfEnergy, m.Time, true
+		default:
+			return 0, m.Time, true
+		}
+	})
+}
+
+// SortAveragedMeasurements sorts the averaged measurements by the key in the direction.
+// Buckets without a value are placed last and measurements with equal values are ordered by time.
+func SortAveragedMeasurements(measurements []AveragedMeasurement, key SortKey, direction string) {
+	sortBy(measurements, direction, func(m AveragedMeasurement) (float64, time.Time, bool) {
+		switch key {
+		case SortByPower:
+			if m.Power == nil {
+				return 0, m.Start, false
+			}
+			return m.Power.Value, m.Start, true
+		case SortByStateOfEnergy:
+			if m.StateOfEnergy == nil {
+				return 0, m.Start, false
+			}
+			return *m.StateOfEnergy, m.Start, true
+		default:
+			return 0, m.Start, true
+		}
+	})
+}
+
+func sortBy[T any](items []T, direction string, value func(T) (float64, time.Time, bool)) {
+	descending := direction == SortDescending
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		aValue, aTime, aOk := value(a)
+		bValue, bTime, bOk := value(b)
+
+		// Missing values are always last
+		switch {
+		case aOk && !bOk:
+			return -1
+		case !aOk && bOk:
+			return 1
+		}
+
+		result := cmp.Compare(aValue, bValue)
+		if result == 0 {
+			result = aTime.Compare(bTime)
+		}
+
+		if descending {
+			return -result
+		}
+		return result
+	})
+}
@@ -0,0 +1,75 @@
+package measurements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortAveragedMeasurements(t *testing.T) {
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minute int) time.Time {
+		return start.Add(time.Duration(minute) * time.Minute)
+	}
+	power := func(value float64) *Power {
+		return &Power{Value: value, Unit: UnitWatt}
+	}
+
+	averaged := []AveragedMeasurement{
+		{Start: at(0), Power: power(200), StateOfEnergy: percent(10)},
+		{Start: at(1)},
+		{Start: at(2), Power: power(100), StateOfEnergy: percent(30)},
+		{Start: at(3), Power: power(200), StateOfEnergy: percent(20)},
+	}
+
+	tests := []struct {
+		name      string
+		key       SortKey
+		direction string
+		expected  []time.Time
+	}{
+		{
+			name:      "Time ascending by default",
+			direction: SortAscending,
+			expected:  []time.Time{at(0), at(1), at(2), at(3)},
+		},
+		{
+			name:      "Time descending",
+			key:       SortByTime,
+			direction: SortDescending,
+			expected:  []time.Time{at(3), at(2), at(1), at(0)},
+		},
+		{
+			name:      "Power ascending, ties by time, empty buckets last",
+			key:       SortByPower,
+			direction: SortAscending,
+			expected:  []time.Time{at(2), at(0), at(3), at(1)},
+		},
+		{
+			name:      "Power descending, empty buckets last",
+			key:       SortByPower,
+			direction: SortDescending,
+			expected:  []time.Time{at(3), at(0), at(2), at(1)},
+		},
+		{
+			name:      "State of energy descending",
+			key:       SortByStateOfEnergy,
+			direction: SortDescending,
+			expected:  []time.Time{at(2), at(3), at(0), at(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]AveragedMeasurement{}, averaged...)
+			SortAveragedMeasurements(sorted, tt.key, tt.direction)
+
+			result := []time.Time{}
+			for _, measurement := range sorted {
+				result = append(result, measurement.Start)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
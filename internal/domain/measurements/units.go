@@ -0,0 +1,163 @@
+package measurements
+
+type Unit string
+
+const (
+	UnitWatt               Unit = "W"
+	UnitKilowatt           Unit = "kW"
+	UnitMegawatt           Unit = "MW"
+	UnitWattHour           Unit = "Wh"
+	UnitKilowattHour       Unit = "kWh"
+	UnitMegawattHour       Unit = "MWh"
+	UnitVolt               Unit = "V"
+	UnitAmpere             Unit = "A"
+	UnitHertz              Unit = "Hz"
+	UnitCelsius            Unit = "°C"
+	UnitVoltAmpereReactive Unit = "var"
+	UnitPercent            Unit = "%"
+)
+
+// Quantity is the physical quantity measured in a unit. Only units of the same quantity can be converted.
+type Quantity string
+
+const (
+	QuantityPower         Quantity = "power"
+	QuantityEnergy        Quantity = "energy"
+	QuantityVoltage       Quantity = "voltage"
+	QuantityCurrent       Quantity = "current"
+	QuantityFrequency     Quantity = "frequency"
+	QuantityTemperature   Quantity = "temperature"
+	QuantityReactivePower Quantity = "reactivePower"
+	QuantityRatio         Quantity = "ratio"
+)
+
+// baseUnits are the units the values of the quantities are stored in.
+var baseUnits = map[Quantity]Unit{
+	QuantityPower:         UnitWatt,
+	QuantityEnergy:        UnitWattHour,
+	QuantityVoltage:       UnitVolt,
+	QuantityCurrent:       UnitAmpere,
+	QuantityFrequency:     UnitHertz,
+	QuantityTemperature:   UnitCelsius,
+	QuantityReactivePower: UnitVoltAmpereReactive,
+	QuantityRatio:         UnitPercent,
+}
+
+// BaseUnit returns the unit the values of the quantity are stored in, an empty string if the quantity is not supported.
+func (q Quantity) BaseUnit() Unit {
+	return baseUnits[q]
+}
+
+type unitDefinition struct {
+	quantity Quantity
+	// Multiplier to the base unit of the quantity
+	factor float64
+}
+
+var units = map[Unit]unitDefinition{
+	UnitWatt:               {quantity: QuantityPower, factor: 1},
+	UnitKilowatt:           {quantity: QuantityPower, factor: 1e3},
+	UnitMegawatt:           {quantity: QuantityPower, factor: 1e6},
+	UnitWattHour:           {quantity: QuantityEnergy, factor: 1},
+	UnitKilowattHour:       {quantity: QuantityEnergy, factor: 1e3},
+	UnitMegawattHour:       {quantity: QuantityEnergy, factor: 1e6},
+	UnitVolt:               {quantity: QuantityVoltage, factor: 1},
+	UnitAmpere:             {quantity: QuantityCurrent, factor: 1},
+	UnitHertz:              {quantity: QuantityFrequency, factor: 1},
+	UnitCelsius:            {quantity: QuantityTemperature, factor: 1},
+	UnitVoltAmpereReactive: {quantity: QuantityReactivePower, factor: 1},
+	UnitPercent:            {quantity: QuantityRatio, factor: 1},
+}
+
+func IsValidUnit(unit Unit) bool {
+	_, ok := units[unit]
+	return ok
+}
+
+// Quantity returns the quantity of the unit or an empty string if the unit is not supported.
+func (u Unit) Quantity() Quantity {
+	return units[u].quantity
+}
+
+// IsCompatibleWith checks if the values in the unit can be converted to the target unit.
+func (u Unit) IsCompatibleWith(target Unit) bool {
+	return IsValidUnit(u) && IsValidUnit(target) && u.Quantity() == target.Quantity()
+}
+
+// Convert converts the value from one unit to another. The units must be of the same quantity.
+// Conversions are linear, so converting an averaged value gives the same result as averaging converted values.
+func Convert(value float64, from, to Unit) (float64, error) {
+	if !IsValidUnit(from) || !IsValidUnit(to) {
+		return 0, ErrInvalidUnit
+	}
+
+	if !from.IsCompatibleWith(to) {
+		return 0, ErrIncompatibleUnits
+	}
+
+	if from == to {
+		return value, nil
+	}
+
+	return value * units[from].factor / units[to].factor, nil
+}
+
+// ConvertTo converts the power and all the metrics compatible with the unit to the unit.
+// Metrics of other quantities are left unchanged. Power without a unit is assumed to be in watts.
+func (m Measurement) ConvertTo(unit Unit) (Measurement, error) {
+	if !IsValidUnit(unit) {
+		return m, ErrInvalidUnit
+	}
+
+	powerUnit := m.Power.Unit
+	if powerUnit == "" {
+		powerUnit = UnitWatt
+	}
+
+	if powerUnit.IsCompatibleWith(unit) {
+		value, err := Convert(m.Power.Value, powerUnit, unit)
+		if err != nil {
+			return m, err
+		}
+
+		m.Power = Power{Value: value, Unit: unit}
+	}
+
+	if len(m.Metrics) > 0 {
+		metrics := make(Metrics, len(m.Metrics))
+		for name, metric := range m.Metrics {
+			if metric.Unit.IsCompatibleWith(unit) {
+				value, err := Convert(metric.Value, metric.Unit, unit)
+				if err != nil {
+					return m, err
+				}
+
+				metric = Metric{Value: value, Unit: unit}
+			}
+
+			metrics[name] = metric
+		}
+		m.Metrics = metrics
+	}
+
+	return m, nil
+}
+
+// ConvertMeasurements converts all measurements to the unit. If the unit is empty, measurements are returned as they are.
+func ConvertMeasurements(measurements []Measurement, unit Unit) ([]Measurement, error) {
+	if unit == "" {
+		return measurements, nil
+	}
+
+	result := make([]Measurement, len(measurements))
+	for i, measurement := range measurements {
+		converted, err := measurement.ConvertTo(unit)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = converted
+	}
+
+	return result, nil
+}
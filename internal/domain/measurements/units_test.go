@@ -0,0 +1,120 @@
+package measurements
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		from     Unit
+		to       Unit
+		expected float64
+		err      error
+	}{
+		{
+			name:     "Same unit",
+			value:    1500,
+			from:     UnitWatt,
+			to:       UnitWatt,
+			expected: 1500,
+		},
+		{
+			name:     "Watt to kilowatt",
+			value:    1500,
+			from:     UnitWatt,
+			to:       UnitKilowatt,
+			expected: 1.5,
+		},
+		{
+			name:     "Megawatt to watt",
+			value:    -2.5,
+			from:     UnitMegawatt,
+			to:       UnitWatt,
+			expected: -2500000,
+		},
+		{
+			name:     "Kilowatt hour to megawatt hour",
+			value:    2500,
+			from:     UnitKilowattHour,
+			to:       UnitMegawattHour,
+			expected: 2.5,
+		},
+		{
+			name: "Power to energy",
+			from: UnitWatt,
+			to:   UnitWattHour,
+			err:  ErrIncompatibleUnits,
+		},
+		{
+			name: "Unknown unit",
+			from: UnitWatt,
+			to:   Unit("hp"),
+			err:  ErrInvalidUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Convert(tt.value, tt.from, tt.to)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, value, 1e-9)
+			}
+		})
+	}
+}
+
+func TestMeasurement_ConvertTo(t *testing.T) {
+	measurement := Measurement{
+		Power:         Power{Value: 2000, Unit: UnitWatt},
+		StateOfEnergy: percent(50),
+		Metrics: Metrics{
+			MetricVoltage: {Value: 230, Unit: UnitVolt},
+			"solarPower":  {Value: 500, Unit: UnitWatt},
+		},
+	}
+
+	converted, err := measurement.ConvertTo(UnitKilowatt)
+	assert.NoError(t, err)
+	assert.Equal(t, Power{Value: 2, Unit: UnitKilowatt}, converted.Power)
+	assert.Equal(t, percent(50), converted.StateOfEnergy)
+	assert.Equal(t, Metric{Value: 230, Unit: UnitVolt}, converted.Metrics[MetricVoltage])
+	assert.Equal(t, Metric{Value: 0.5, Unit: UnitKilowatt}, converted.Metrics["solarPower"])
+
+	// Original measurement should not be modified
+	assert.Equal(t, Metric{Value: 500, Unit: UnitWatt}, measurement.Metrics["solarPower"])
+
+	// Energy units convert only the energy metrics
+	measurement.Metrics["energy"] = Metric{Value: 1500, Unit: UnitWattHour}
+	converted, err = measurement.ConvertTo(UnitKilowattHour)
+	assert.NoError(t, err)
+	assert.Equal(t, Power{Value: 2000, Unit: UnitWatt}, converted.Power)
+	assert.Equal(t, Metric{Value: 500, Unit: UnitWatt}, converted.Metrics["solarPower"])
+	assert.Equal(t, Metric{Value: 1.5, Unit: UnitKilowattHour}, converted.Metrics["energy"])
+
+	_, err = measurement.ConvertTo("hp")
+	assert.ErrorIs(t, err, ErrInvalidUnit)
+}
+
+func TestConvertMeasurements(t *testing.T) {
+	averaged := []Measurement{
+		{Power: Power{Value: 1000, Unit: UnitWatt}},
+		{Power: Power{Value: 3000}},
+	}
+
+	// Converting the averages gives the same result as averaging the converted values
+	converted, err := ConvertMeasurements(averaged, UnitKilowatt)
+	assert.NoError(t, err)
+	assert.Equal(t, Power{Value: 1, Unit: UnitKilowatt}, converted[0].Power)
+	assert.Equal(t, Power{Value: 3, Unit: UnitKilowatt}, converted[1].Power)
+
+	unchanged, err := ConvertMeasurements(averaged, "")
+	assert.NoError(t, err)
+	assert.Equal(t, averaged, unchanged)
+}
@@ -0,0 +1,70 @@
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BackfillRequest asks to simulate the measurements of the assets in a historical time window, using their current
+// configurations.
+type BackfillRequest struct {
+	AssetIds []string  `json:"assetIds"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+
+	// Speed of the simulated time relative to the real time. Zero simulates the window as fast as possible.
+	Speed float64 `json:"speed,omitempty"`
+}
+
+func (r *BackfillRequest) Validate() error {
+	if len(r.AssetIds) == 0 {
+		return errors.New("at least one assetId is required")
+	}
+
+	for _, assetId := range r.AssetIds {
+		if assetId == "" {
+			return errors.New("assetId is required")
+		}
+	}
+
+	if r.From.IsZero() || r.To.IsZero() || !r.From.Before(r.To) {
+		return errors.New("from must be before to")
+	}
+
+	if r.Speed < 0 {
+		return errors.New("speed must not be negative")
+	}
+
+	return nil
+}
+
+// BackfillState is the progress of a backfill.
+type BackfillState string
+
+const (
+	BackfillRunning   = BackfillState("running")
+	BackfillCompleted = BackfillState("completed")
+	BackfillFailed    = BackfillState("failed")
+)
+
+// Backfill simulates the measurements of the assets in a historical time window. The measurements carry the simulated
+// time and are published like the measurements of the running simulations.
+type Backfill struct {
+	Id string `json:"id"`
+	BackfillRequest
+
+	State      BackfillState `json:"state"`
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	// Error of a failed backfill
+	Error string `json:"error,omitempty"`
+}
+
+type BackfillService interface {
+	// StartBackfill starts simulating the window in the background. It returns ErrNoConfigForAsset if any of the assets
+	// has no configuration.
+	StartBackfill(ctx context.Context, request BackfillRequest) (*Backfill, error)
+	GetBackfill(ctx context.Context, backfillId string) (*Backfill, error)
+}
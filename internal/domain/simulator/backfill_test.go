@@ -0,0 +1,65 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillRequest_Validate(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		request BackfillRequest
+		err     bool
+	}{
+		{
+			name:    "Valid backfill",
+			request: BackfillRequest{AssetIds: []string{uuid.New().String(), uuid.New().String()}, From: from, To: to},
+		},
+		{
+			name:    "Valid accelerated backfill",
+			request: BackfillRequest{AssetIds: []string{uuid.New().String()}, From: from, To: to, Speed: 3600},
+		},
+		{
+			name:    "No assets",
+			request: BackfillRequest{From: from, To: to},
+			err:     true,
+		},
+		{
+			name:    "Empty assetId",
+			request: BackfillRequest{AssetIds: []string{""}, From: from, To: to},
+			err:     true,
+		},
+		{
+			name:    "From after to",
+			request: BackfillRequest{AssetIds: []string{uuid.New().String()}, From: to, To: from},
+			err:     true,
+		},
+		{
+			name:    "Missing from",
+			request: BackfillRequest{AssetIds: []string{uuid.New().String()}, To: to},
+			err:     true,
+		},
+		{
+			name:    "Negative speed",
+			request: BackfillRequest{AssetIds: []string{uuid.New().String()}, From: from, To: to, Speed: -1},
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
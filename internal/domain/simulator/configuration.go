@@ -1,6 +1,7 @@
 package simulator
 
 import (
+	"strconv"
 	"time"
 
 	"asset-measurements-assignment/internal/domain"
@@ -16,6 +17,77 @@ type Configuration struct {
 	MaxPower            float64          `json:"maxPower"`
 	MinPower            float64          `json:"minPower"`
 	MaxPowerStep        float64          `json:"maxPowerStep"`
+
+	// Seed of the random numbers of the generator. Generators with the same configuration and seed generate the same
+	// measurements at the same times. A random seed is assigned when a configuration is created without one.
+	Seed int64 `json:"seed,omitempty"`
+
+	// Mode selects between the model of the asset type and the replay of recorded measurements
+	Mode SimulationMode `json:"mode,omitempty"`
+
+	// Replay parameters, required in the replay mode
+	Replay *ReplayParameters `json:"replay,omitempty"`
+
+	// Solar parameters of the solar generator, optional for solar assets
+	Solar *SolarParameters `json:"solar,omitempty"`
+
+	// Wind parameters of the wind generator, optional for wind assets
+	Wind *WindParameters `json:"wind,omitempty"`
+
+	// Battery parameters of the battery generator, optional for batteries
+	Battery *BatteryParameters `json:"battery,omitempty"`
+
+	// Heater parameters of the thermostat generator, optional for heaters
+	Heater *HeaterParameters `json:"heater,omitempty"`
+
+	// Motor parameters of the start/stop generator, optional for motors
+	Motor *MotorParameters `json:"motor,omitempty"`
+
+	// LoadShape replaces the model of a consumer with a daily and weekly load shape
+	LoadShape *LoadShape `json:"loadShape,omitempty"`
+}
+
+// SolarParameters describe the location and the panels of a solar asset.
+type SolarParameters struct {
+	// Latitude of the panels in degrees, positive to the north
+	Latitude float64 `json:"latitude"`
+
+	// Longitude of the panels in degrees, positive to the east
+	Longitude float64 `json:"longitude"`
+
+	// Capacity is the peak power of the panels in W at the standard irradiance of 1000 W/m2.
+	// Defaults to the absolute value of MaxPower.
+	Capacity float64 `json:"capacity,omitempty"`
+
+	// CloudCover is the average fraction of the sky covered by clouds, between 0 and 1
+	CloudCover float64 `json:"cloudCover,omitempty"`
+
+	// CloudVariability is the standard deviation of the cloud cover around the average, between 0 and 1
+	CloudVariability float64 `json:"cloudVariability,omitempty"`
+}
+
+func (p *SolarParameters) Validate() error {
+	if p.Latitude < -90 || p.Latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+
+	if p.Longitude < -180 || p.Longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+
+	if p.Capacity < 0 {
+		return errors.New("capacity must not be negative")
+	}
+
+	if p.CloudCover < 0 || p.CloudCover > 1 {
+		return errors.New("cloudCover must be between 0 and 1")
+	}
+
+	if p.CloudVariability < 0 || p.CloudVariability > 1 {
+		return errors.New("cloudVariability must be between 0 and 1")
+	}
+
+	return nil
 }
 
 func (c *Configuration) Validate() error {
@@ -39,5 +111,494 @@ func (c *Configuration) Validate() error {
 		return err
 	}
 
+	switch c.Mode {
+	case "", ModeModel:
+		if c.Replay != nil {
+			return errors.New("replay parameters are supported in the replay mode only")
+		}
+	case ModeReplay:
+		if c.Replay == nil {
+			return errors.New("replay parameters are required in the replay mode")
+		}
+
+		err = c.Replay.Validate()
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("invalid simulation mode")
+	}
+
+	if c.Battery != nil {
+		if c.Type != domain.AssetTypeBattery {
+			return errors.New("battery parameters are supported for batteries only")
+		}
+
+		err = c.Battery.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Heater != nil {
+		if c.Type != domain.AssetTypeHeater {
+			return errors.New("heater parameters are supported for heaters only")
+		}
+
+		err = c.Heater.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Motor != nil {
+		if c.Type != domain.AssetTypeMotor {
+			return errors.New("motor parameters are supported for motors only")
+		}
+
+		err = c.Motor.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.LoadShape != nil {
+		if c.Type.GetEnergyType() != domain.EnergyTypeConsumer {
+			return errors.New("load shapes are supported for consumers only")
+		}
+
+		err = c.LoadShape.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Wind != nil {
+		if c.Type != domain.AssetTypeWind {
+			return errors.New("wind parameters are supported for wind assets only")
+		}
+
+		err = c.Wind.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Solar != nil {
+		if c.Type != domain.AssetTypeSolar {
+			return errors.New("solar parameters are supported for solar assets only")
+		}
+
+		err = c.Solar.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// Default wind parameters of a typical onshore turbine.
+const (
+	DefaultMeanWindSpeed       = 7.0
+	DefaultWeibullShape        = 2.0
+	DefaultWindCorrelationTime = 2 * time.Hour
+	DefaultCutInSpeed          = 3.0
+	DefaultRatedSpeed          = 12.0
+	DefaultCutOutSpeed         = 25.0
+)
+
+// WindParameters describe the wind at the location of a wind turbine and the power curve of the turbine. The turbine
+// produces its rated power, the absolute value of MaxPower, between the rated and the cut-out wind speed.
+// Zero values are replaced with the defaults.
+type WindParameters struct {
+	// MeanWindSpeed is the average wind speed in m/s
+	MeanWindSpeed float64 `json:"meanWindSpeed,omitempty"`
+
+	// WeibullShape is the shape of the Weibull distribution of the wind speed, lower values mean more variable wind
+	WeibullShape float64 `json:"weibullShape,omitempty"`
+
+	// CorrelationTime is the time after which the wind speed mostly forgets its previous value
+	CorrelationTime time.Duration `json:"correlationTime,omitempty"`
+
+	// CutInSpeed is the wind speed in m/s at which the turbine starts producing
+	CutInSpeed float64 `json:"cutInSpeed,omitempty"`
+
+	// RatedSpeed is the wind speed in m/s at which the turbine reaches the rated power
+	RatedSpeed float64 `json:"ratedSpeed,omitempty"`
+
+	// CutOutSpeed is the wind speed in m/s at which the turbine shuts down to protect itself
+	CutOutSpeed float64 `json:"cutOutSpeed,omitempty"`
+}
+
+// WithDefaults returns the parameters with the zero values replaced with the defaults.
+func (p WindParameters) WithDefaults() WindParameters {
+	if p.MeanWindSpeed == 0 {
+		p.MeanWindSpeed = DefaultMeanWindSpeed
+	}
+
+	if p.WeibullShape == 0 {
+		p.WeibullShape = DefaultWeibullShape
+	}
+
+	if p.CorrelationTime == 0 {
+		p.CorrelationTime = DefaultWindCorrelationTime
+	}
+
+	if p.CutInSpeed == 0 {
+		p.CutInSpeed = DefaultCutInSpeed
+	}
+
+	if p.RatedSpeed == 0 {
+		p.RatedSpeed = DefaultRatedSpeed
+	}
+
+	if p.CutOutSpeed == 0 {
+		p.CutOutSpeed = DefaultCutOutSpeed
+	}
+
+	return p
+}
+
+func (p *WindParameters) Validate() error {
+	parameters := p.WithDefaults()
+
+	if parameters.MeanWindSpeed < 0 {
+		return errors.New("meanWindSpeed must not be negative")
+	}
+
+	if parameters.WeibullShape < 0 {
+		return errors.New("weibullShape must not be negative")
+	}
+
+	if parameters.CorrelationTime < 0 {
+		return errors.New("correlationTime must not be negative")
+	}
+
+	if parameters.CutInSpeed < 0 {
+		return errors.New("cutInSpeed must not be negative")
+	}
+
+	if parameters.CutInSpeed >= parameters.RatedSpeed {
+		return errors.New("cutInSpeed must be less than ratedSpeed")
+	}
+
+	if parameters.RatedSpeed >= parameters.CutOutSpeed {
+		return errors.New("ratedSpeed must be less than cutOutSpeed")
+	}
+
+	return nil
+}
+
+// Default battery parameters of a typical lithium-ion battery.
+const (
+	DefaultChargeEfficiency    = 0.95
+	DefaultDischargeEfficiency = 0.95
+	DefaultMaxStateOfEnergy    = 100.0
+)
+
+// BatteryParameters describe the storage of a battery. The battery charges with positive and discharges with negative
+// power, limited by MinPower and MaxPower. Zero values are replaced with the defaults.
+type BatteryParameters struct {
+	// Capacity is the energy the battery stores in Wh. Defaults to one hour at the largest absolute power bound.
+	Capacity float64 `json:"capacity,omitempty"`
+
+	// ChargeEfficiency is the fraction of the charging energy which is stored, between 0 and 1
+	ChargeEfficiency float64 `json:"chargeEfficiency,omitempty"`
+
+	// DischargeEfficiency is the fraction of the stored energy which is delivered when discharging, between 0 and 1
+	DischargeEfficiency float64 `json:"dischargeEfficiency,omitempty"`
+
+	// InitialStateOfEnergy is the state of energy in % at the start of the simulation. The battery starts at
+	// MinStateOfEnergy by default.
+	InitialStateOfEnergy float64 `json:"initialStateOfEnergy,omitempty"`
+
+	// MinStateOfEnergy is the state of energy in % below which the battery doesn't discharge
+	MinStateOfEnergy float64 `json:"minStateOfEnergy,omitempty"`
+
+	// MaxStateOfEnergy is the state of energy in % above which the battery doesn't charge
+	MaxStateOfEnergy float64 `json:"maxStateOfEnergy,omitempty"`
+
+	// CRate limits the power to CRate times the capacity, e.g. 0.5 charges the battery in two hours at the earliest.
+	// Without it, the power is limited by MinPower and MaxPower only.
+	CRate float64 `json:"cRate,omitempty"`
+}
+
+// WithDefaults returns the parameters with the zero values replaced with the defaults. The capacity depends on the power
+// bounds of the asset, so it is defaulted by the generator.
+func (p BatteryParameters) WithDefaults() BatteryParameters {
+	if p.ChargeEfficiency == 0 {
+		p.ChargeEfficiency = DefaultChargeEfficiency
+	}
+
+	if p.DischargeEfficiency == 0 {
+		p.DischargeEfficiency = DefaultDischargeEfficiency
+	}
+
+	if p.MaxStateOfEnergy == 0 {
+		p.MaxStateOfEnergy = DefaultMaxStateOfEnergy
+	}
+
+	if p.InitialStateOfEnergy == 0 {
+		p.InitialStateOfEnergy = p.MinStateOfEnergy
+	}
+
+	return p
+}
+
+func (p *BatteryParameters) Validate() error {
+	parameters := p.WithDefaults()
+
+	if parameters.Capacity < 0 {
+		return errors.New("capacity must not be negative")
+	}
+
+	if parameters.ChargeEfficiency < 0 || parameters.ChargeEfficiency > 1 {
+		return errors.New("chargeEfficiency must be between 0 and 1")
+	}
+
+	if parameters.DischargeEfficiency < 0 || parameters.DischargeEfficiency > 1 {
+		return errors.New("dischargeEfficiency must be between 0 and 1")
+	}
+
+	if parameters.MinStateOfEnergy < 0 || parameters.MaxStateOfEnergy > 100 {
+		return errors.New("state of energy limits must be between 0 and 100")
+	}
+
+	if parameters.MinStateOfEnergy >= parameters.MaxStateOfEnergy {
+		return errors.New("minStateOfEnergy must be less than maxStateOfEnergy")
+	}
+
+	if parameters.InitialStateOfEnergy < parameters.MinStateOfEnergy ||
+		parameters.InitialStateOfEnergy > parameters.MaxStateOfEnergy {
+		return errors.New("initialStateOfEnergy must be between minStateOfEnergy and maxStateOfEnergy")
+	}
+
+	if parameters.CRate < 0 {
+		return errors.New("cRate must not be negative")
+	}
+
+	return nil
+}
+
+// Default heater parameters of a room heated by an electric heater.
+const (
+	DefaultSetPoint            = 21.0
+	DefaultHysteresis          = 1.0
+	DefaultOutdoorTemperature  = 5.0
+	DefaultOutdoorAmplitude    = 5.0
+	DefaultThermalTimeConstant = 10 * time.Hour
+)
+
+// HeaterParameters describe a heater switched by a thermostat. The heater runs at MaxPower until the indoor temperature
+// rises above the set point, and at MinPower, the standby power, until it drops below it. The room loses heat to the
+// outdoor temperature, which follows a daily curve with the minimum at 04:00 UTC. Zero values are replaced with the
+// defaults, except for the outdoor temperature and its amplitude.
+type HeaterParameters struct {
+	// SetPoint is the indoor temperature in °C the thermostat keeps
+	SetPoint float64 `json:"setPoint,omitempty"`
+
+	// Hysteresis is the width of the band around the set point in °C, in which the thermostat doesn't switch
+	Hysteresis float64 `json:"hysteresis,omitempty"`
+
+	// OutdoorTemperature is the average outdoor temperature in °C
+	OutdoorTemperature float64 `json:"outdoorTemperature"`
+
+	// OutdoorAmplitude is the difference in °C between the average and the warmest outdoor temperature of the day
+	OutdoorAmplitude float64 `json:"outdoorAmplitude"`
+
+	// HeatLoss is the heat the room loses in W per °C of difference to the outdoor temperature. Defaults to the heat loss
+	// at which the heater runs two thirds of the time at the coldest outdoor temperature.
+	HeatLoss float64 `json:"heatLoss,omitempty"`
+
+	// ThermalTimeConstant is the time after which the room mostly cools down to the outdoor temperature
+	ThermalTimeConstant time.Duration `json:"thermalTimeConstant,omitempty"`
+}
+
+// DefaultHeaterParameters returns the parameters used by heaters without heater parameters.
+func DefaultHeaterParameters() HeaterParameters {
+	return HeaterParameters{
+		OutdoorTemperature: DefaultOutdoorTemperature,
+		OutdoorAmplitude:   DefaultOutdoorAmplitude,
+	}.WithDefaults()
+}
+
+// WithDefaults returns the parameters with the zero values replaced with the defaults. The heat loss depends on the
+// power of the heater, so it is defaulted by the generator.
+func (p HeaterParameters) WithDefaults() HeaterParameters {
+	if p.SetPoint == 0 {
+		p.SetPoint = DefaultSetPoint
+	}
+
+	if p.Hysteresis == 0 {
+		p.Hysteresis = DefaultHysteresis
+	}
+
+	if p.ThermalTimeConstant == 0 {
+		p.ThermalTimeConstant = DefaultThermalTimeConstant
+	}
+
+	return p
+}
+
+func (p *HeaterParameters) Validate() error {
+	parameters := p.WithDefaults()
+
+	if parameters.Hysteresis < 0 {
+		return errors.New("hysteresis must not be negative")
+	}
+
+	if parameters.OutdoorAmplitude < 0 {
+		return errors.New("outdoorAmplitude must not be negative")
+	}
+
+	if parameters.OutdoorTemperature-parameters.OutdoorAmplitude >= parameters.SetPoint {
+		return errors.New("coldest outdoorTemperature must be below the setPoint")
+	}
+
+	if parameters.HeatLoss < 0 {
+		return errors.New("heatLoss must not be negative")
+	}
+
+	if parameters.ThermalTimeConstant < 0 {
+		return errors.New("thermalTimeConstant must not be negative")
+	}
+
+	return nil
+}
+
+// Default motor parameters of a motor running in cycles.
+const (
+	DefaultOnDuration     = 30 * time.Minute
+	DefaultOffDuration    = 30 * time.Minute
+	DefaultInrushFactor   = 5.0
+	DefaultInrushDuration = 2 * time.Second
+)
+
+// MotorParameters describe a motor which starts and stops randomly. When the motor starts, it draws a peak of the
+// inrush factor times its rated power, which decays to the rated power. A stopped motor draws MinPower, the standby
+// power. Zero values are replaced with the defaults.
+type MotorParameters struct {
+	// RatedPower is the power in W of the running motor. Defaults to MaxPower divided by the inrush factor, so the
+	// inrush peak reaches MaxPower.
+	RatedPower float64 `json:"ratedPower,omitempty"`
+
+	// OnDuration is the average time the motor runs
+	OnDuration time.Duration `json:"onDuration,omitempty"`
+
+	// OffDuration is the average time the motor is stopped
+	OffDuration time.Duration `json:"offDuration,omitempty"`
+
+	// InrushFactor is the peak power at the start relative to the rated power
+	InrushFactor float64 `json:"inrushFactor,omitempty"`
+
+	// InrushDuration is the time constant of the decay of the inrush peak
+	InrushDuration time.Duration `json:"inrushDuration,omitempty"`
+}
+
+// WithDefaults returns the parameters with the zero values replaced with the defaults. The rated power depends on the
+// power bounds of the motor, so it is defaulted by the generator.
+func (p MotorParameters) WithDefaults() MotorParameters {
+	if p.OnDuration == 0 {
+		p.OnDuration = DefaultOnDuration
+	}
+
+	if p.OffDuration == 0 {
+		p.OffDuration = DefaultOffDuration
+	}
+
+	if p.InrushFactor == 0 {
+		p.InrushFactor = DefaultInrushFactor
+	}
+
+	if p.InrushDuration == 0 {
+		p.InrushDuration = DefaultInrushDuration
+	}
+
+	return p
+}
+
+func (p *MotorParameters) Validate() error {
+	parameters := p.WithDefaults()
+
+	if parameters.RatedPower < 0 {
+		return errors.New("ratedPower must not be negative")
+	}
+
+	if parameters.OnDuration < 0 || parameters.OffDuration < 0 {
+		return errors.New("onDuration and offDuration must not be negative")
+	}
+
+	if parameters.InrushFactor < 1 {
+		return errors.New("inrushFactor must be at least 1")
+	}
+
+	if parameters.InrushDuration < 0 {
+		return errors.New("inrushDuration must not be negative")
+	}
+
+	return nil
+}
+
+// LoadShape describes the power of a consumer as a fraction between MinPower and MaxPower, which changes during the day
+// and the week. The daily values are spread evenly over the day starting at midnight UTC, e.g. 24 hourly or 96
+// quarter-hourly values, and the power is interpolated between them.
+type LoadShape struct {
+	// Daily fractions of the power range, between 0 and 1
+	Daily []float64 `json:"daily"`
+
+	// Weekly factors multiplying the daily fractions, from Monday to Sunday. Without them, every day is the same.
+	Weekly []float64 `json:"weekly,omitempty"`
+
+	// Noise is the standard deviation of the random deviation from the shape, as a fraction of the power range
+	Noise float64 `json:"noise,omitempty"`
+}
+
+func (s *LoadShape) Validate() error {
+	if len(s.Daily) == 0 {
+		return errors.New("daily load shape is required")
+	}
+
+	for _, value := range s.Daily {
+		if value < 0 || value > 1 {
+			return errors.New("daily load shape values must be between 0 and 1")
+		}
+	}
+
+	if len(s.Weekly) != 0 && len(s.Weekly) != 7 {
+		return errors.New("weekly load shape must have 7 values")
+	}
+
+	for _, value := range s.Weekly {
+		if value < 0 || value > 1 {
+			return errors.New("weekly load shape values must be between 0 and 1")
+		}
+	}
+
+	if s.Noise < 0 || s.Noise > 1 {
+		return errors.New("noise must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// LatestConfigurationPerAsset returns the configuration with the highest version for each asset. The repositories keep
+// every version, while each asset has a single worker running its latest configuration.
+func LatestConfigurationPerAsset(configurations []Configuration) map[string]Configuration {
+	latest := map[string]Configuration{}
+	for _, configuration := range configurations {
+		current, ok := latest[configuration.AssetId]
+		if !ok || configuration.version() > current.version() {
+			latest[configuration.AssetId] = configuration
+		}
+	}
+	return latest
+}
+
+func (c Configuration) version() int {
+	version, err := strconv.Atoi(c.Version)
+	if err != nil {
+		return 0
+	}
+	return version
+}
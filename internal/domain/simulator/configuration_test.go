@@ -104,6 +104,432 @@ func (s *configurationTestSuite) TestConfiguration_Validate() {
 			},
 			err: true,
 		},
+		{
+			name: "Valid solar parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -5000,
+				MinPower:            0,
+				Solar: &SolarParameters{
+					Latitude:         46.05,
+					Longitude:        14.5,
+					Capacity:         5000,
+					CloudCover:       0.3,
+					CloudVariability: 0.1,
+				},
+			},
+			err: false,
+		},
+		{
+			name: "Invalid latitude",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -5000,
+				MinPower:            0,
+				Solar:               &SolarParameters{Latitude: 91},
+			},
+			err: true,
+		},
+		{
+			name: "Invalid cloud cover",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -5000,
+				MinPower:            0,
+				Solar:               &SolarParameters{CloudCover: 1.5},
+			},
+			err: true,
+		},
+		{
+			name: "Valid battery parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{Capacity: 5000, ChargeEfficiency: 0.9, InitialStateOfEnergy: 50, MaxStateOfEnergy: 90, CRate: 0.5},
+			},
+			err: false,
+		},
+		{
+			name: "Default battery parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{},
+			},
+			err: false,
+		},
+		{
+			name: "Battery efficiency above 1",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{DischargeEfficiency: 1.1},
+			},
+			err: true,
+		},
+		{
+			name: "Minimum state of energy above the maximum",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{MinStateOfEnergy: 60, MaxStateOfEnergy: 40},
+			},
+			err: true,
+		},
+		{
+			name: "Initial state of energy outside the limits",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{InitialStateOfEnergy: 95, MaxStateOfEnergy: 90},
+			},
+			err: true,
+		},
+		{
+			name: "Negative C-rate",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{CRate: -1},
+			},
+			err: true,
+		},
+		{
+			name: "Battery parameters of a motor",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeMotor,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            -1000,
+				Battery:             &BatteryParameters{},
+			},
+			err: true,
+		},
+		{
+			name: "Valid heater parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeHeater,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				Heater:              &HeaterParameters{SetPoint: 20, OutdoorTemperature: -2, OutdoorAmplitude: 6},
+			},
+			err: false,
+		},
+		{
+			name: "Outdoor temperature above the set point",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeHeater,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				Heater:              &HeaterParameters{SetPoint: 20, OutdoorTemperature: 25},
+			},
+			err: true,
+		},
+		{
+			name: "Heater parameters of a motor",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeMotor,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				Heater:              &HeaterParameters{},
+			},
+			err: true,
+		},
+		{
+			name: "Valid motor parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeMotor,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				Motor:               &MotorParameters{RatedPower: 200, OnDuration: time.Minute, InrushFactor: 3},
+			},
+			err: false,
+		},
+		{
+			name: "Inrush factor below 1",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeMotor,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				Motor:               &MotorParameters{InrushFactor: 0.5},
+			},
+			err: true,
+		},
+		{
+			name: "Valid load shape",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeMotor,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				LoadShape:           &LoadShape{Daily: []float64{0.2, 0.8}, Weekly: []float64{1, 1, 1, 1, 1, 0.5, 0.5}},
+			},
+			err: false,
+		},
+		{
+			name: "Load shape without daily values",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeHeater,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				LoadShape:           &LoadShape{},
+			},
+			err: true,
+		},
+		{
+			name: "Load shape value above 1",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeHeater,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				LoadShape:           &LoadShape{Daily: []float64{1.5}},
+			},
+			err: true,
+		},
+		{
+			name: "Weekly load shape without 7 values",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeHeater,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				LoadShape:           &LoadShape{Daily: []float64{1}, Weekly: []float64{1, 1}},
+			},
+			err: true,
+		},
+		{
+			name: "Load shape of a battery",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				MaxPower:            1000,
+				MinPower:            0,
+				LoadShape:           &LoadShape{Daily: []float64{1}},
+			},
+			err: true,
+		},
+		{
+			name: "Valid wind parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind: &WindParameters{
+					MeanWindSpeed: 8,
+					CutInSpeed:    3.5,
+					RatedSpeed:    13,
+					CutOutSpeed:   25,
+				},
+			},
+			err: false,
+		},
+		{
+			name: "Default wind parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind:                &WindParameters{},
+			},
+			err: false,
+		},
+		{
+			name: "Cut-in speed above the rated speed",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind:                &WindParameters{CutInSpeed: 14},
+			},
+			err: true,
+		},
+		{
+			name: "Rated speed above the cut-out speed",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind:                &WindParameters{RatedSpeed: 12, CutOutSpeed: 10},
+			},
+			err: true,
+		},
+		{
+			name: "Negative mean wind speed",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind:                &WindParameters{MeanWindSpeed: -1},
+			},
+			err: true,
+		},
+		{
+			name: "Wind parameters of a solar asset",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				MinPower:            0,
+				Wind:                &WindParameters{},
+			},
+			err: true,
+		},
+		{
+			name: "Solar parameters of a wind asset",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeWind,
+				MeasurementInterval: time.Second,
+				MaxPower:            -5000,
+				MinPower:            0,
+				Solar:               &SolarParameters{},
+			},
+			err: true,
+		},
+		{
+			name: "Valid recording replay",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+				Replay:              &ReplayParameters{Source: ReplaySourceRecording, RecordingId: uuid.New().String(), Speed: 60},
+			},
+			err: false,
+		},
+		{
+			name: "Valid history replay",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeBattery,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+				Replay: &ReplayParameters{
+					Source: ReplaySourceHistory,
+					From:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+					To:     time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			err: false,
+		},
+		{
+			name: "Replay without parameters",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+			},
+			err: true,
+		},
+		{
+			name: "Recording replay without recordingId",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+				Replay:              &ReplayParameters{Source: ReplaySourceRecording},
+			},
+			err: true,
+		},
+		{
+			name: "History replay without time range",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+				Replay:              &ReplayParameters{Source: ReplaySourceHistory},
+			},
+			err: true,
+		},
+		{
+			name: "Negative replay speed",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				Mode:                ModeReplay,
+				Replay:              &ReplayParameters{Source: ReplaySourceRecording, RecordingId: uuid.New().String(), Speed: -1},
+			},
+			err: true,
+		},
+		{
+			name: "Replay parameters in the model mode",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				Replay:              &ReplayParameters{Source: ReplaySourceRecording, RecordingId: uuid.New().String()},
+			},
+			err: true,
+		},
+		{
+			name: "Invalid simulation mode",
+			cfg: Configuration{
+				AssetId:             assetId,
+				Type:                domain.AssetTypeSolar,
+				MeasurementInterval: time.Second,
+				MaxPower:            -2000,
+				Mode:                "unknown",
+			},
+			err: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,4 +10,20 @@ var (
 	ErrNoConfigForAsset = errors.New(2001, http.StatusNotFound, "Config for asset not found")
 	ErrConfigNotFound   = errors.New(2002, http.StatusNotFound, "Config not found")
 	ErrConfigValidation = errors.New(2003, http.StatusBadRequest, "Validation error")
+
+	ErrRecordingNotFound   = errors.New(2004, http.StatusNotFound, "Recording not found")
+	ErrInvalidRecording    = errors.New(2005, http.StatusBadRequest, "Invalid recording")
+	ErrEmptyReplay         = errors.New(2006, http.StatusBadRequest, "No measurements to replay")
+	ErrHistoryNotAvailable = errors.New(2007, http.StatusBadRequest, "History of the asset service is not available")
+
+	ErrBackfillNotFound   = errors.New(2008, http.StatusNotFound, "Backfill not found")
+	ErrBackfillValidation = errors.New(2009, http.StatusBadRequest, "Invalid backfill")
+
+	ErrScenarioNotFound   = errors.New(2010, http.StatusNotFound, "Scenario not found")
+	ErrScenarioValidation = errors.New(2011, http.StatusBadRequest, "Invalid scenario")
+
+	ErrWorkerNotFound  = errors.New(2012, http.StatusNotFound, "Worker for asset not found")
+	ErrFaultValidation = errors.New(2013, http.StatusBadRequest, "Invalid fault")
+
+	ErrWorkerNotRunning = errors.New(2014, http.StatusConflict, "Worker is not running")
 )
@@ -0,0 +1,106 @@
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FaultType is the misbehaviour of a faulty asset or its connection.
+type FaultType string
+
+const (
+	// FaultStuck repeats the measurement at the start of the fault
+	FaultStuck = FaultType("stuck")
+
+	// FaultDrift adds an offset to the power, which grows by the magnitude every minute
+	FaultDrift = FaultType("drift")
+
+	// FaultSpikes adds spikes of the magnitude to the power of random samples
+	FaultSpikes = FaultType("spikes")
+
+	// FaultDropped doesn't publish random samples
+	FaultDropped = FaultType("dropped")
+
+	// FaultDuplicated publishes random samples twice, with the same measurement ID
+	FaultDuplicated = FaultType("duplicated")
+
+	// FaultOutOfOrder holds back random samples and publishes them after the next sample
+	FaultOutOfOrder = FaultType("outOfOrder")
+
+	// FaultMalformedJson publishes random samples as truncated JSON
+	FaultMalformedJson = FaultType("malformedJson")
+
+	// FaultWrongContentType publishes random samples with the content type of the other encoding
+	FaultWrongContentType = FaultType("wrongContentType")
+)
+
+// DefaultSpikeProbability is the fraction of the samples with a spike. The other faults affect every sample by default.
+const DefaultSpikeProbability = 0.1
+
+// Fault is injected into the worker of an asset for a duration.
+type Fault struct {
+	Type FaultType `json:"type"`
+
+	// Duration of the fault
+	Duration time.Duration `json:"duration"`
+
+	// Probability is the fraction of the samples affected by the sample faults, between 0 and 1
+	Probability float64 `json:"probability,omitempty"`
+
+	// Magnitude of the drift in W per minute or of the spikes in W
+	Magnitude float64 `json:"magnitude,omitempty"`
+}
+
+// WithDefaults returns the fault with the zero values replaced with the defaults.
+func (f Fault) WithDefaults() Fault {
+	if f.Probability == 0 {
+		f.Probability = 1
+		if f.Type == FaultSpikes {
+			f.Probability = DefaultSpikeProbability
+		}
+	}
+
+	return f
+}
+
+func (f *Fault) Validate() error {
+	switch f.Type {
+	case FaultDrift, FaultSpikes:
+		if f.Magnitude == 0 {
+			return errors.Errorf("magnitude is required by %s", f.Type)
+		}
+	case FaultStuck, FaultDropped, FaultDuplicated, FaultOutOfOrder, FaultMalformedJson, FaultWrongContentType:
+	default:
+		return errors.New("invalid fault type")
+	}
+
+	if f.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+
+	if f.Probability < 0 || f.Probability > 1 {
+		return errors.New("probability must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// ActiveFault is a fault injected into the worker of an asset.
+type ActiveFault struct {
+	AssetId string `json:"assetId"`
+	Fault
+	StartedAt time.Time `json:"startedAt"`
+	Until     time.Time `json:"until"`
+}
+
+type FaultService interface {
+	// InjectFault switches the worker of the asset into the fault for the duration of the fault. A fault of the same
+	// type replaces the previous one.
+	InjectFault(ctx context.Context, assetId string, fault Fault) (*ActiveFault, error)
+	// GetFaults returns the faults of the worker of the asset which didn't end yet
+	GetFaults(ctx context.Context, assetId string) ([]ActiveFault, error)
+	// ClearFaults ends all the faults of the worker of the asset
+	ClearFaults(ctx context.Context, assetId string) error
+}
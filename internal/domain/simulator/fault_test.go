@@ -0,0 +1,66 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFault_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault Fault
+		err   bool
+	}{
+		{
+			name:  "Valid stuck fault",
+			fault: Fault{Type: FaultStuck, Duration: time.Minute},
+		},
+		{
+			name:  "Valid drift",
+			fault: Fault{Type: FaultDrift, Duration: time.Minute, Magnitude: -10},
+		},
+		{
+			name:  "Valid dropped samples",
+			fault: Fault{Type: FaultDropped, Duration: time.Minute, Probability: 0.5},
+		},
+		{
+			name:  "Invalid type",
+			fault: Fault{Type: "broken", Duration: time.Minute},
+			err:   true,
+		},
+		{
+			name:  "Missing duration",
+			fault: Fault{Type: FaultOutOfOrder},
+			err:   true,
+		},
+		{
+			name:  "Spikes without magnitude",
+			fault: Fault{Type: FaultSpikes, Duration: time.Minute},
+			err:   true,
+		},
+		{
+			name:  "Invalid probability",
+			fault: Fault{Type: FaultDuplicated, Duration: time.Minute, Probability: 1.5},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fault.Validate()
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFault_WithDefaults(t *testing.T) {
+	assert.Equal(t, DefaultSpikeProbability, Fault{Type: FaultSpikes}.WithDefaults().Probability)
+	assert.Equal(t, 1.0, Fault{Type: FaultDropped}.WithDefaults().Probability)
+	assert.Equal(t, 0.5, Fault{Type: FaultDropped, Probability: 0.5}.WithDefaults().Probability)
+}
@@ -12,26 +12,28 @@ func clamp(value, min, max float64) float64 {
 	return math.Max(min, math.Min(max, value))
 }
 
-func zeroPowerMeasurement(stateOfEnergy float64) *measurements.Measurement {
+// zeroPowerMeasurement returns the first measurement of an asset. The state of energy is nil for the assets which
+// don't store energy.
+func zeroPowerMeasurement(stateOfEnergy *float64, now time.Time) *measurements.Measurement {
 	return &measurements.Measurement{
 		Power: measurements.Power{
 			Value: 0,
 			Unit:  "W",
 		},
 		StateOfEnergy: stateOfEnergy,
-		Time:          time.Now(),
+		Time:          now,
 	}
 }
 
-func getPowerStep(maxPowerStep float64) float64 {
+func getPowerStep(rng *rand.Rand, maxPowerStep float64) float64 {
 	stepValue := maxPowerStep
 
 	// Generate a random step value
 	if maxPowerStep <= 0 {
-		stepValue = rand.Float64() * maxPowerStep
+		stepValue = rng.Float64() * maxPowerStep
 	}
 
-	sign := rand.IntN(2)
+	sign := rng.IntN(2)
 	if sign == 0 {
 		// Negative step
 		sign = -1
@@ -2,7 +2,9 @@ package generator
 
 import (
 	"math"
+	"math/rand/v2"
 	"testing"
+	"time"
 
 	"asset-measurements-assignment/internal/domain/measurements"
 	"github.com/stretchr/testify/assert"
@@ -62,25 +64,34 @@ func TestClamp(t *testing.T) {
 	}
 }
 
+func percent(value float64) *float64 {
+	return &value
+}
+
 func TestZeroPowerMeasurement(t *testing.T) {
 	tests := []struct {
 		name          string
-		stateOfCharge float64
+		stateOfCharge *float64
 	}{
+		{
+			name: "Asset without storage",
+		},
 		{
 			name:          "State of charge is zero",
-			stateOfCharge: 0,
+			stateOfCharge: new(float64),
 		},
 		{
 			name:          "State of charge is not zero",
-			stateOfCharge: 50,
+			stateOfCharge: percent(50),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			measurement := zeroPowerMeasurement(tt.stateOfCharge)
+			now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+			measurement := zeroPowerMeasurement(tt.stateOfCharge, now)
 			assert.NotNil(t, measurement)
+			assert.Equal(t, now, measurement.Time)
 			assert.Equal(t, 0.0, measurement.Power.Value)
 			assert.Equal(t, measurements.UnitWatt, measurement.Power.Unit)
 			assert.Equal(t, tt.stateOfCharge, measurement.StateOfEnergy)
@@ -109,7 +120,7 @@ func TestGetPowerStep(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			step := getPowerStep(tt.maxPowerStep)
+			step := getPowerStep(rand.New(NewSource(1)), tt.maxPowerStep)
 
 			if tt.maxPowerStep <= 0 {
 				assert.LessOrEqual(t, tt.maxPowerStep, math.Abs(step))
@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"math"
+	"math/rand/v2"
 	"time"
 
 	"asset-measurements-assignment/internal/domain"
@@ -8,16 +10,41 @@ import (
 	"asset-measurements-assignment/internal/domain/simulator"
 )
 
+// taperStateOfEnergy is the distance in % from the state of energy limits at which the battery starts reducing the
+// charging or discharging power, reaching zero at the limit.
+const taperStateOfEnergy = 10.0
+
+// CombinedMeasurementGenerator generates the power of a battery, which charges with positive and discharges with
+// negative power. The state of energy follows the energy stored in the battery, taking the efficiency into account.
 type CombinedMeasurementGenerator struct {
-	cfg simulator.Configuration
+	cfg        simulator.Configuration
+	parameters simulator.BatteryParameters
 
 	// Last generated measurement
 	previousMeasurement *measurements.Measurement
+
+	clock Clock
+	rng   *rand.Rand
 }
 
-func NewCombined(cfg simulator.Configuration) *CombinedMeasurementGenerator {
+// NewCombined creates a battery generator. Without battery parameters, the battery stores one hour at the largest
+// absolute power bound.
+func NewCombined(cfg simulator.Configuration, clock Clock, source rand.Source) *CombinedMeasurementGenerator {
+	var parameters simulator.BatteryParameters
+	if cfg.Battery != nil {
+		parameters = *cfg.Battery
+	}
+	parameters = parameters.WithDefaults()
+
+	if parameters.Capacity == 0 {
+		parameters.Capacity = math.Max(math.Abs(cfg.MinPower), math.Abs(cfg.MaxPower))
+	}
+
 	return &CombinedMeasurementGenerator{
-		cfg: cfg,
+		cfg:        cfg,
+		parameters: parameters,
+		clock:      clock,
+		rng:        rand.New(source),
 	}
 }
 
@@ -26,31 +53,36 @@ func NewCombined(cfg simulator.Configuration) *CombinedMeasurementGenerator {
 func (c *CombinedMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
 	if c.previousMeasurement == nil {
 		// This is the first measurement
-		measurement := zeroPowerMeasurement(0)
+		stateOfEnergy := c.parameters.InitialStateOfEnergy
+		measurement := zeroPowerMeasurement(&stateOfEnergy, c.clock.Now())
 		c.previousMeasurement = measurement
 		return measurement, nil
 	}
 
-	powerStep := getPowerStep(c.cfg.MaxPowerStep)
-	power := c.previousMeasurement.Power.Value + powerStep
-	power = clamp(power, c.cfg.MinPower, c.cfg.MaxPower)
-
 	measurement := &measurements.Measurement{
 		Power: measurements.Power{
-			Value: power,
-			Unit:  measurements.UnitWatt,
+			Unit: measurements.UnitWatt,
 		},
-		Time: time.Now(),
+		Time: c.clock.Now(),
 	}
 
-	// If full, only allow negative power (discharge)
-	// If empty, only allow positive power (charge)
-	if (c.previousMeasurement.StateOfEnergy >= 100 && measurement.Power.Value > 0) ||
-		(c.previousMeasurement.StateOfEnergy <= 0 && measurement.Power.Value < 0) {
-		measurement.Power.Value = 0
+	// The previous power was applied until now
+	stateOfEnergy := c.calculateSoE(measurement.Time)
+	measurement.StateOfEnergy = &stateOfEnergy
+
+	powerStep := getPowerStep(c.rng, c.cfg.MaxPowerStep)
+	power := c.previousMeasurement.Power.Value + powerStep
+	power = clamp(power, c.cfg.MinPower, c.cfg.MaxPower)
+
+	maxCharge, maxDischarge := c.powerLimits(stateOfEnergy)
+
+	// Avoid a negative zero when the battery can't discharge
+	minPower := 0.0
+	if maxDischarge > 0 {
+		minPower = -maxDischarge
 	}
+	measurement.Power.Value = clamp(power, minPower, maxCharge)
 
-	c.calculateSoE(measurement)
 	c.previousMeasurement = measurement
 	return measurement, nil
 }
@@ -59,12 +91,47 @@ func (c *CombinedMeasurementGenerator) GetEnergyType() domain.EnergyType {
 	return domain.EnergyTypeCombined
 }
 
-func (c *CombinedMeasurementGenerator) calculateSoE(measurement *measurements.Measurement) {
-	// Calculate energy between two measurements
-	timeElapsed := measurement.Time.Sub(c.previousMeasurement.Time).Seconds()
-	energyChange := ((measurement.Power.Value + c.previousMeasurement.Power.Value) / 2) * timeElapsed
-	energyPercentageChange := (energyChange / c.cfg.MaxPower) * 100
-	measurement.StateOfEnergy = c.previousMeasurement.StateOfEnergy + energyPercentageChange
+func (c *CombinedMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorBattery
+}
+
+// calculateSoE returns the state of energy after applying the previous power until the given time. Charging stores
+// less energy than drawn, and discharging draws more stored energy than delivered.
+func (c *CombinedMeasurementGenerator) calculateSoE(now time.Time) float64 {
+	// The battery measurements always have a state of energy
+	previous := *c.previousMeasurement.StateOfEnergy
+
+	power := c.previousMeasurement.Power.Value
+	if power == 0 || c.parameters.Capacity == 0 {
+		return previous
+	}
+
+	hoursElapsed := now.Sub(c.previousMeasurement.Time).Hours()
+
+	var energyChange float64
+	if power > 0 {
+		energyChange = power * c.parameters.ChargeEfficiency * hoursElapsed
+	} else {
+		energyChange = power / c.parameters.DischargeEfficiency * hoursElapsed
+	}
+
+	stateOfEnergy := previous + energyChange/c.parameters.Capacity*100
+	return clamp(stateOfEnergy, c.parameters.MinStateOfEnergy, c.parameters.MaxStateOfEnergy)
+}
+
+// powerLimits returns the largest charging and discharging power at the given state of energy. Both are limited by the
+// power bounds and the C-rate, and decrease linearly to zero near the state of energy limits.
+func (c *CombinedMeasurementGenerator) powerLimits(stateOfEnergy float64) (maxCharge, maxDischarge float64) {
+	maxCharge = math.Max(c.cfg.MaxPower, 0)
+	maxDischarge = math.Max(-c.cfg.MinPower, 0)
+
+	if c.parameters.CRate > 0 {
+		rateLimit := c.parameters.CRate * c.parameters.Capacity
+		maxCharge = math.Min(maxCharge, rateLimit)
+		maxDischarge = math.Min(maxDischarge, rateLimit)
+	}
 
-	measurement.StateOfEnergy = clamp(measurement.StateOfEnergy, 0, 100)
+	maxCharge *= clamp((c.parameters.MaxStateOfEnergy-stateOfEnergy)/taperStateOfEnergy, 0, 1)
+	maxDischarge *= clamp((stateOfEnergy-c.parameters.MinStateOfEnergy)/taperStateOfEnergy, 0, 1)
+	return maxCharge, maxDischarge
 }
@@ -2,9 +2,11 @@ package generator
 
 import (
 	"testing"
+	"time"
 
 	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -13,8 +15,11 @@ type combinedGeneratorTestSuite struct {
 	generator *CombinedMeasurementGenerator
 }
 
+var combinedTestTime = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
 func (s *combinedGeneratorTestSuite) SetupSuite() {
-	s.generator = NewCombined(batteryCfg)
+	s.generator = NewCombined(batteryCfg, SystemClock, NewSource(1))
+	s.generator.clock = ClockFunc(func() time.Time { return combinedTestTime })
 }
 
 func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
@@ -34,7 +39,8 @@ func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 0,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 0,
+				StateOfEnergy: percent(0),
+				Time:          combinedTestTime.Add(-time.Second),
 			},
 		},
 		{
@@ -44,7 +50,8 @@ func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 30,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 30,
+				StateOfEnergy: percent(30),
+				Time:          combinedTestTime.Add(-time.Second),
 			},
 		},
 		{
@@ -54,7 +61,8 @@ func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 30,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 100,
+				StateOfEnergy: percent(100),
+				Time:          combinedTestTime.Add(-time.Second),
 			},
 		},
 		{
@@ -64,7 +72,8 @@ func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 0,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 0,
+				StateOfEnergy: percent(0),
+				Time:          combinedTestTime.Add(-time.Second),
 			},
 		},
 	}
@@ -121,6 +130,177 @@ func (s *combinedGeneratorTestSuite) TestGenerateMeasurement() {
 	}
 }
 
+func (s *combinedGeneratorTestSuite) TestStateOfEnergy() {
+	cfg := batteryCfg
+	cfg.MaxPowerStep = 0
+	cfg.MinPower = -1000
+	cfg.MaxPower = 1000
+	cfg.Battery = &simulator.BatteryParameters{
+		Capacity:             2000,
+		ChargeEfficiency:     0.9,
+		DischargeEfficiency:  0.8,
+		InitialStateOfEnergy: 50,
+	}
+
+	tests := []struct {
+		name                  string
+		power                 float64
+		elapsed               time.Duration
+		expectedStateOfEnergy float64
+	}{
+		{
+			name:                  "Charging stores less energy than drawn",
+			power:                 200,
+			elapsed:               time.Hour,
+			expectedStateOfEnergy: 50 + 100*200*0.9/2000,
+		},
+		{
+			name:                  "Discharging draws more energy than delivered",
+			power:                 -200,
+			elapsed:               time.Hour,
+			expectedStateOfEnergy: 50 - 100*200/0.8/2000,
+		},
+		{
+			name:                  "Idle battery keeps its energy",
+			power:                 0,
+			elapsed:               time.Hour,
+			expectedStateOfEnergy: 50,
+		},
+		{
+			name:                  "State of energy is limited",
+			power:                 1000,
+			elapsed:               10 * time.Hour,
+			expectedStateOfEnergy: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			generator := NewCombined(cfg, SystemClock, NewSource(1))
+			generator.clock = ClockFunc(func() time.Time { return combinedTestTime.Add(tt.elapsed) })
+			generator.previousMeasurement = &measurements.Measurement{
+				Power:         measurements.Power{Value: tt.power, Unit: measurements.UnitWatt},
+				StateOfEnergy: percent(50),
+				Time:          combinedTestTime,
+			}
+
+			measurement, err := generator.GenerateMeasurement()
+			s.Require().NoError(err)
+			s.InDelta(tt.expectedStateOfEnergy, *measurement.StateOfEnergy, 0.0001)
+		})
+	}
+}
+
+func (s *combinedGeneratorTestSuite) TestPowerLimits() {
+	cfg := batteryCfg
+	cfg.MinPower = -1000
+	cfg.MaxPower = 1000
+	cfg.Battery = &simulator.BatteryParameters{
+		Capacity:         4000,
+		MinStateOfEnergy: 10,
+		MaxStateOfEnergy: 90,
+	}
+
+	tests := []struct {
+		name                 string
+		cRate                float64
+		stateOfEnergy        float64
+		expectedMaxCharge    float64
+		expectedMaxDischarge float64
+	}{
+		{
+			name:                 "Power bounds",
+			stateOfEnergy:        50,
+			expectedMaxCharge:    1000,
+			expectedMaxDischarge: 1000,
+		},
+		{
+			name:                 "C-rate",
+			cRate:                0.2,
+			stateOfEnergy:        50,
+			expectedMaxCharge:    800,
+			expectedMaxDischarge: 800,
+		},
+		{
+			name:                 "Charging tapers near the maximum",
+			stateOfEnergy:        85,
+			expectedMaxCharge:    500,
+			expectedMaxDischarge: 1000,
+		},
+		{
+			name:                 "Discharging tapers near the minimum",
+			stateOfEnergy:        12,
+			expectedMaxCharge:    1000,
+			expectedMaxDischarge: 200,
+		},
+		{
+			name:                 "Full battery",
+			stateOfEnergy:        90,
+			expectedMaxCharge:    0,
+			expectedMaxDischarge: 1000,
+		},
+		{
+			name:                 "Empty battery",
+			stateOfEnergy:        10,
+			expectedMaxCharge:    1000,
+			expectedMaxDischarge: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			limitedCfg := cfg
+			battery := *cfg.Battery
+			battery.CRate = tt.cRate
+			limitedCfg.Battery = &battery
+
+			maxCharge, maxDischarge := NewCombined(limitedCfg, SystemClock, NewSource(1)).powerLimits(tt.stateOfEnergy)
+			s.InDelta(tt.expectedMaxCharge, maxCharge, 0.0001)
+			s.InDelta(tt.expectedMaxDischarge, maxDischarge, 0.0001)
+		})
+	}
+}
+
+func (s *combinedGeneratorTestSuite) TestSimulation() {
+	cfg := batteryCfg
+	cfg.MinPower = -1000
+	cfg.MaxPower = 1000
+	cfg.MaxPowerStep = 200
+	cfg.Battery = &simulator.BatteryParameters{
+		Capacity:             500,
+		InitialStateOfEnergy: 50,
+		MinStateOfEnergy:     20,
+		MaxStateOfEnergy:     80,
+	}
+
+	generator := NewCombined(cfg, SystemClock, NewSource(1))
+	current := combinedTestTime
+	generator.clock = ClockFunc(func() time.Time { return current })
+
+	for i := 0; i < 2000; i++ {
+		current = current.Add(time.Minute)
+		measurement, err := generator.GenerateMeasurement()
+		s.Require().NoError(err)
+
+		s.GreaterOrEqual(*measurement.StateOfEnergy, 20.0)
+		s.LessOrEqual(*measurement.StateOfEnergy, 80.0)
+		s.GreaterOrEqual(measurement.Power.Value, -1000.0)
+		s.LessOrEqual(measurement.Power.Value, 1000.0)
+		s.Equal(current, measurement.Time)
+	}
+}
+
+func (s *combinedGeneratorTestSuite) TestDefaultParameters() {
+	generator := NewCombined(batteryCfg, SystemClock, NewSource(1))
+	s.Equal(100.0, generator.parameters.Capacity)
+	s.Equal(simulator.DefaultChargeEfficiency, generator.parameters.ChargeEfficiency)
+	s.Equal(simulator.DefaultMaxStateOfEnergy, generator.parameters.MaxStateOfEnergy)
+
+	measurement, err := generator.GenerateMeasurement()
+	s.Require().NoError(err)
+	s.Equal(percent(0), measurement.StateOfEnergy)
+}
+
 func (s *combinedGeneratorTestSuite) TestGetEnergyType() {
 	s.EqualValues(domain.EnergyTypeCombined, s.generator.GetEnergyType())
 }
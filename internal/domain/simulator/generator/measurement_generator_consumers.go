@@ -1,22 +1,29 @@
 package generator
 
 import (
-	"time"
+	"math/rand/v2"
 
 	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
 	"asset-measurements-assignment/internal/domain/simulator"
 )
 
+// ConsumerMeasurementGenerator generates a random walk of the power of a consumer. Consumers don't store energy, so the
+// state of energy is not applicable and stays zero.
 type ConsumerMeasurementGenerator struct {
 	cfg simulator.Configuration
 	// Last generated measurement
 	previousMeasurement *measurements.Measurement
+
+	clock Clock
+	rng   *rand.Rand
 }
 
-func NewConsumer(cfg simulator.Configuration) *ConsumerMeasurementGenerator {
+func NewConsumer(cfg simulator.Configuration, clock Clock, source rand.Source) *ConsumerMeasurementGenerator {
 	return &ConsumerMeasurementGenerator{
-		cfg: cfg,
+		cfg:   cfg,
+		clock: clock,
+		rng:   rand.New(source),
 	}
 }
 
@@ -25,12 +32,12 @@ func NewConsumer(cfg simulator.Configuration) *ConsumerMeasurementGenerator {
 func (c *ConsumerMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
 	if c.previousMeasurement == nil {
 		// This is the first measurement
-		measurement := zeroPowerMeasurement(0)
+		measurement := zeroPowerMeasurement(nil, c.clock.Now())
 		c.previousMeasurement = measurement
 		return measurement, nil
 	}
 
-	powerStep := getPowerStep(c.cfg.MaxPowerStep)
+	powerStep := getPowerStep(c.rng, c.cfg.MaxPowerStep)
 	power := c.previousMeasurement.Power.Value + powerStep
 	power = clamp(power, c.cfg.MinPower, c.cfg.MaxPower)
 
@@ -39,11 +46,9 @@ func (c *ConsumerMeasurementGenerator) GenerateMeasurement() (*measurements.Meas
 			Value: power,
 			Unit:  measurements.UnitWatt,
 		},
-		Time: time.Now(),
+		Time: c.clock.Now(),
 	}
 
-	c.calculateSoE(measurement)
-
 	c.previousMeasurement = measurement
 	return measurement, nil
 }
@@ -52,12 +57,6 @@ func (c *ConsumerMeasurementGenerator) GetEnergyType() domain.EnergyType {
 	return domain.EnergyTypeConsumer
 }
 
-func (c *ConsumerMeasurementGenerator) calculateSoE(measurement *measurements.Measurement) {
-	// Calculate energy between two measurements
-	timeElapsed := measurement.Time.Sub(c.previousMeasurement.Time).Seconds()
-	energyChange := ((measurement.Power.Value + c.previousMeasurement.Power.Value) / 2) * timeElapsed
-	energyPercentageChange := (energyChange / c.cfg.MaxPower) * 100
-	measurement.StateOfEnergy = c.previousMeasurement.StateOfEnergy + energyPercentageChange
-
-	measurement.StateOfEnergy = clamp(measurement.StateOfEnergy, 0, 100)
+func (c *ConsumerMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorConsumer
 }
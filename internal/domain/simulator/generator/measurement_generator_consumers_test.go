@@ -14,7 +14,7 @@ type consumerGeneratorTestSuite struct {
 }
 
 func (s *consumerGeneratorTestSuite) SetupSuite() {
-	s.generator = NewConsumer(motorCfg)
+	s.generator = NewConsumer(motorCfg, SystemClock, NewSource(1))
 }
 
 func (s *consumerGeneratorTestSuite) TestGenerateMeasurement() {
@@ -34,7 +34,6 @@ func (s *consumerGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 0,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 0,
 			},
 		},
 		{
@@ -44,17 +43,15 @@ func (s *consumerGeneratorTestSuite) TestGenerateMeasurement() {
 					Value: 30,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 30,
 			},
 		},
 		{
-			name: "State of charge is 100",
+			name: "State of energy is not applicable",
 			previousMeasurement: &measurements.Measurement{
 				Power: measurements.Power{
 					Value: 30,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 100,
 			},
 		},
 	}
@@ -79,7 +76,6 @@ func (s *consumerGeneratorTestSuite) TestGenerateMeasurement() {
 			} else {
 				s.NoError(err)
 
-				// Todo determine state of energy change?
 				// Determine output based on the test case
 				switch tt.name {
 				case "First measurement":
@@ -90,10 +86,10 @@ func (s *consumerGeneratorTestSuite) TestGenerateMeasurement() {
 				case "Generate measurement with negative MaxPowerStep":
 					s.Require().NotNil(tt.previousMeasurement)
 					s.InDelta(tt.previousMeasurement.Power.Value, measurement.Power.Value, s.generator.cfg.MaxPowerStep)
-				case "State of charge is 100":
+				case "State of energy is not applicable":
 					s.Require().NotNil(tt.previousMeasurement)
 					s.InDelta(tt.previousMeasurement.Power.Value, measurement.Power.Value, s.generator.cfg.MaxPowerStep)
-					s.Equal(tt.previousMeasurement.StateOfEnergy, measurement.StateOfEnergy)
+					s.Nil(measurement.StateOfEnergy)
 				}
 			}
 		})
@@ -1,25 +1,57 @@
 package generator
 
 import (
+	"math/rand/v2"
+
 	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
 	"asset-measurements-assignment/internal/domain/simulator"
 	"github.com/pkg/errors"
 )
 
+// ErrNoMeasurement is returned by the generators which have no measurement to publish at the moment, e.g. between the
+// recorded measurements of a replay. It is not a failure.
+var ErrNoMeasurement = errors.New("no measurement to publish")
+
 type MeasurementGenerator interface {
 	GenerateMeasurement() (*measurements.Measurement, error)
 	GetEnergyType() domain.EnergyType
+	// GetGeneratorType returns the kind of the generator, reported in the status of the worker
+	GetGeneratorType() simulator.GeneratorType
 }
 
-func GetGeneratorFromConfiguration(cfg simulator.Configuration) (MeasurementGenerator, error) {
+// GetGeneratorFromConfiguration creates the generator of the model of the asset, which tells the time of the
+// measurements with the clock and draws the random numbers from the source. Replays need the recorded measurements,
+// so they are created with NewReplay.
+func GetGeneratorFromConfiguration(cfg simulator.Configuration, clock Clock, source rand.Source) (MeasurementGenerator, error) {
+	if cfg.Mode == simulator.ModeReplay {
+		return nil, errors.New("replay generators require the recorded measurements")
+	}
+
+	// A load shape replaces the model of a consumer
+	if cfg.LoadShape != nil {
+		return NewLoadShape(cfg, clock, source), nil
+	}
+
+	// Asset types with a dedicated model
+	switch cfg.Type {
+	case domain.AssetTypeSolar:
+		return NewSolar(cfg, clock, source), nil
+	case domain.AssetTypeWind:
+		return NewWind(cfg, clock, source), nil
+	case domain.AssetTypeHeater:
+		return NewHeater(cfg, clock), nil
+	case domain.AssetTypeMotor:
+		return NewMotor(cfg, clock, source), nil
+	}
+
 	switch cfg.Type.GetEnergyType() {
 	case domain.EnergyTypeCombined:
-		return NewCombined(cfg), nil
+		return NewCombined(cfg, clock, source), nil
 	case domain.EnergyTypeConsumer:
-		return NewConsumer(cfg), nil
+		return NewConsumer(cfg, clock, source), nil
 	case domain.EnergyTypeProducer:
-		return NewProducer(cfg), nil
+		return NewProducer(cfg, clock, source), nil
 	default:
 		return nil, errors.New("invalid asset type")
 	}
@@ -72,32 +72,49 @@ func TestGetGeneratorFromConfiguration(t *testing.T) {
 		name         string
 		cfg          simulator.Configuration
 		expectedType domain.EnergyType
-		err          bool
+		// Kind of the generator reported by the worker
+		expectedGenerator simulator.GeneratorType
+		err               bool
 	}{
 		{
-			name:         "Combined",
-			cfg:          batteryCfg,
-			expectedType: domain.EnergyTypeCombined,
+			name:              "Combined",
+			cfg:               batteryCfg,
+			expectedType:      domain.EnergyTypeCombined,
+			expectedGenerator: simulator.GeneratorBattery,
 		},
 		{
-			name:         "Motor Consumer",
-			cfg:          motorCfg,
-			expectedType: domain.EnergyTypeConsumer,
+			name:              "Motor Consumer",
+			cfg:               motorCfg,
+			expectedType:      domain.EnergyTypeConsumer,
+			expectedGenerator: simulator.GeneratorMotor,
 		},
 		{
-			name:         "Heater Consumer",
-			cfg:          heaterCfg,
-			expectedType: domain.EnergyTypeConsumer,
+			name:              "Heater Consumer",
+			cfg:               heaterCfg,
+			expectedType:      domain.EnergyTypeConsumer,
+			expectedGenerator: simulator.GeneratorHeater,
 		},
 		{
-			name:         "Solar Producer",
-			cfg:          solarCfg,
-			expectedType: domain.EnergyTypeProducer,
+			name:              "Solar Producer",
+			cfg:               solarCfg,
+			expectedType:      domain.EnergyTypeProducer,
+			expectedGenerator: simulator.GeneratorSolar,
 		},
 		{
-			name:         "Wind Producer",
-			cfg:          windCfg,
-			expectedType: domain.EnergyTypeProducer,
+			name:              "Wind Producer",
+			cfg:               windCfg,
+			expectedType:      domain.EnergyTypeProducer,
+			expectedGenerator: simulator.GeneratorWind,
+		},
+		{
+			name: "Replay",
+			cfg: simulator.Configuration{
+				Type:   domain.AssetTypeSolar,
+				Mode:   simulator.ModeReplay,
+				Replay: &simulator.ReplayParameters{Source: simulator.ReplaySourceRecording, RecordingId: uuid.New().String()},
+			},
+			expectedType: "",
+			err:          true,
 		},
 		{
 			name: "Unknown",
@@ -111,7 +128,7 @@ func TestGetGeneratorFromConfiguration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			generatorFromConfiguration, err := GetGeneratorFromConfiguration(tt.cfg)
+			generatorFromConfiguration, err := GetGeneratorFromConfiguration(tt.cfg, SystemClock, NewSource(1))
 			if tt.err {
 				assert.Error(t, err)
 				assert.Nil(t, generatorFromConfiguration)
@@ -119,8 +136,35 @@ func TestGetGeneratorFromConfiguration(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, generatorFromConfiguration)
 				assert.Equal(t, tt.expectedType, generatorFromConfiguration.GetEnergyType())
+				assert.Equal(t, tt.expectedGenerator, generatorFromConfiguration.GetGeneratorType())
 				assert.Implements(t, (*MeasurementGenerator)(nil), generatorFromConfiguration)
 			}
 		})
 	}
+
+	// Solar assets follow the position of the sun instead of a random walk
+	solarGenerator, err := GetGeneratorFromConfiguration(solarCfg, SystemClock, NewSource(1))
+	assert.NoError(t, err)
+	assert.IsType(t, &SolarMeasurementGenerator{}, solarGenerator)
+
+	// Wind assets follow the power curve of the turbine
+	windGenerator, err := GetGeneratorFromConfiguration(windCfg, SystemClock, NewSource(1))
+	assert.NoError(t, err)
+	assert.IsType(t, &WindMeasurementGenerator{}, windGenerator)
+
+	// Heaters and motors follow their load profiles
+	heaterGenerator, err := GetGeneratorFromConfiguration(heaterCfg, SystemClock, NewSource(1))
+	assert.NoError(t, err)
+	assert.IsType(t, &HeaterMeasurementGenerator{}, heaterGenerator)
+
+	motorGenerator, err := GetGeneratorFromConfiguration(motorCfg, SystemClock, NewSource(1))
+	assert.NoError(t, err)
+	assert.IsType(t, &MotorMeasurementGenerator{}, motorGenerator)
+
+	// A load shape replaces the model of the consumer
+	shapedCfg := motorCfg
+	shapedCfg.LoadShape = &simulator.LoadShape{Daily: []float64{0.5}}
+	shapedGenerator, err := GetGeneratorFromConfiguration(shapedCfg, SystemClock, NewSource(1))
+	assert.NoError(t, err)
+	assert.IsType(t, &LoadShapeMeasurementGenerator{}, shapedGenerator)
 }
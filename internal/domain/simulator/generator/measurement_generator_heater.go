@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"math"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// heaterDutyCycle is the fraction of the time the heater runs at the coldest outdoor temperature with the default heat
+// loss.
+const heaterDutyCycle = 2.0 / 3
+
+// coldestHour is the hour of the day in UTC with the lowest outdoor temperature.
+const coldestHour = 4
+
+// HeaterMeasurementGenerator generates the power of a heater switched by a thermostat. The indoor temperature rises
+// while the heater runs and falls towards the outdoor temperature while it is off, so the heater runs longer when it is
+// colder outside.
+type HeaterMeasurementGenerator struct {
+	cfg        simulator.Configuration
+	parameters simulator.HeaterParameters
+
+	// Current indoor temperature in °C
+	indoorTemperature float64
+	// Whether the thermostat switched the heater on
+	heating bool
+	// Time of the last generated measurement
+	previousTime time.Time
+
+	clock Clock
+}
+
+// NewHeater creates a heater generator. The room starts at the set point with the heater off.
+func NewHeater(cfg simulator.Configuration, clock Clock) *HeaterMeasurementGenerator {
+	parameters := simulator.DefaultHeaterParameters()
+	if cfg.Heater != nil {
+		parameters = cfg.Heater.WithDefaults()
+	}
+
+	if parameters.HeatLoss == 0 {
+		coldest := parameters.OutdoorTemperature - parameters.OutdoorAmplitude
+		parameters.HeatLoss = heaterDutyCycle * cfg.MaxPower / (parameters.SetPoint - coldest)
+	}
+
+	return &HeaterMeasurementGenerator{
+		cfg:               cfg,
+		parameters:        parameters,
+		indoorTemperature: parameters.SetPoint,
+		clock:             clock,
+	}
+}
+
+// GenerateMeasurement updates the indoor temperature since the previous measurement and switches the heater when the
+// temperature leaves the hysteresis band around the set point.
+func (h *HeaterMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := h.clock.Now()
+	h.updateIndoorTemperature(now)
+
+	switch {
+	case h.indoorTemperature < h.parameters.SetPoint-h.parameters.Hysteresis/2:
+		h.heating = true
+	case h.indoorTemperature > h.parameters.SetPoint+h.parameters.Hysteresis/2:
+		h.heating = false
+	}
+
+	power := h.cfg.MinPower
+	if h.heating {
+		power = h.cfg.MaxPower
+	}
+
+	return &measurements.Measurement{
+		Power: measurements.Power{
+			Value: power,
+			Unit:  measurements.UnitWatt,
+		},
+		Time: now,
+	}, nil
+}
+
+func (h *HeaterMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeConsumer
+}
+
+func (h *HeaterMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorHeater
+}
+
+// updateIndoorTemperature moves the indoor temperature towards the temperature the room would reach with the current
+// state of the heater. The standby power doesn't heat the room.
+func (h *HeaterMeasurementGenerator) updateIndoorTemperature(now time.Time) {
+	previousTime := h.previousTime
+	h.previousTime = now
+	if previousTime.IsZero() || h.parameters.HeatLoss == 0 {
+		return
+	}
+
+	steadyTemperature := outdoorTemperature(h.parameters, now)
+	if h.heating {
+		steadyTemperature += h.cfg.MaxPower / h.parameters.HeatLoss
+	}
+
+	decay := math.Exp(-now.Sub(previousTime).Seconds() / h.parameters.ThermalTimeConstant.Seconds())
+	h.indoorTemperature = steadyTemperature + (h.indoorTemperature-steadyTemperature)*decay
+}
+
+// outdoorTemperature returns the outdoor temperature at the given time, which is the lowest at the coldest hour and the
+// highest twelve hours later.
+func outdoorTemperature(parameters simulator.HeaterParameters, t time.Time) float64 {
+	t = t.UTC()
+	hour := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	return parameters.OutdoorTemperature - parameters.OutdoorAmplitude*math.Cos(2*math.Pi*(hour-coldestHour)/24)
+}
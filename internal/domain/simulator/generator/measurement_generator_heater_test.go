@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutdoorTemperature(t *testing.T) {
+	parameters := simulator.HeaterParameters{OutdoorTemperature: 5, OutdoorAmplitude: 4}
+	day := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.InDelta(t, 1, outdoorTemperature(parameters, day.Add(4*time.Hour)), 0.001)
+	assert.InDelta(t, 9, outdoorTemperature(parameters, day.Add(16*time.Hour)), 0.001)
+	assert.InDelta(t, 5, outdoorTemperature(parameters, day.Add(10*time.Hour)), 0.001)
+}
+
+func TestHeaterMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	cfg := simulator.Configuration{
+		Type:     domain.AssetTypeHeater,
+		MinPower: 5,
+		MaxPower: 2000,
+		Heater: &simulator.HeaterParameters{
+			SetPoint:            20,
+			Hysteresis:          1,
+			OutdoorTemperature:  0,
+			OutdoorAmplitude:    5,
+			ThermalTimeConstant: 5 * time.Hour,
+		},
+	}
+	day := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Thermostat keeps the set point", func(t *testing.T) {
+		generator := NewHeater(cfg, SystemClock)
+		current := day
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		switches := 0
+		heating := false
+		for ; current.Before(day.Add(24 * time.Hour)); current = current.Add(10 * time.Second) {
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			assert.InDelta(t, 20, generator.indoorTemperature, 1)
+			if (measurement.Power.Value == 2000) != heating {
+				heating = !heating
+				switches++
+			}
+		}
+
+		assert.Greater(t, switches, 2)
+	})
+
+	t.Run("Heater runs longer when it is colder", func(t *testing.T) {
+		generator := NewHeater(cfg, SystemClock)
+		current := day
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		// Share of the measurements in each hour of the day, in which the heater runs
+		var running, samples [24]float64
+		for ; current.Before(day.Add(24 * time.Hour)); current = current.Add(10 * time.Second) {
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+			assert.Contains(t, []float64{5, 2000}, measurement.Power.Value)
+			assert.Equal(t, measurements.UnitWatt, measurement.Power.Unit)
+
+			if measurement.Power.Value == 2000 {
+				running[current.Hour()]++
+			}
+			samples[current.Hour()]++
+		}
+
+		// The room lags behind the outdoor temperature, so compare the hours after the coldest and the warmest hour
+		cold := (running[4] + running[5]) / (samples[4] + samples[5])
+		warm := (running[16] + running[17]) / (samples[16] + samples[17])
+		assert.Greater(t, cold, warm)
+		assert.InDelta(t, 2.0/3, cold, 0.15)
+		assert.InDelta(t, 0.4, warm, 0.15)
+	})
+
+	t.Run("Default parameters", func(t *testing.T) {
+		generator := NewHeater(simulator.Configuration{Type: domain.AssetTypeHeater, MaxPower: 2000}, SystemClock)
+		assert.Equal(t, simulator.DefaultSetPoint, generator.parameters.SetPoint)
+		assert.Equal(t, simulator.DefaultOutdoorTemperature, generator.parameters.OutdoorTemperature)
+		assert.InDelta(t, 2.0/3*2000/(21-0), generator.parameters.HeatLoss, 0.001)
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeConsumer), generator.GetEnergyType())
+		assert.Equal(t, simulator.GeneratorHeater, generator.GetGeneratorType())
+	})
+}
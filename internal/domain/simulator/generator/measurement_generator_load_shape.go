@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// LoadShapeMeasurementGenerator generates the power of a consumer following a user-supplied daily and weekly load
+// shape.
+type LoadShapeMeasurementGenerator struct {
+	cfg   simulator.Configuration
+	shape simulator.LoadShape
+
+	clock Clock
+	rng   *rand.Rand
+}
+
+func NewLoadShape(cfg simulator.Configuration, clock Clock, source rand.Source) *LoadShapeMeasurementGenerator {
+	var shape simulator.LoadShape
+	if cfg.LoadShape != nil {
+		shape = *cfg.LoadShape
+	}
+
+	return &LoadShapeMeasurementGenerator{
+		cfg:   cfg,
+		shape: shape,
+		clock: clock,
+		rng:   rand.New(source),
+	}
+}
+
+// GenerateMeasurement generates the power of the load shape at the current time with a random deviation.
+func (l *LoadShapeMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := l.clock.Now()
+
+	fraction := l.fraction(now)
+	if l.shape.Noise > 0 {
+		fraction = clamp(fraction+l.shape.Noise*l.rng.NormFloat64(), 0, 1)
+	}
+
+	return &measurements.Measurement{
+		Power: measurements.Power{
+			Value: l.cfg.MinPower + fraction*(l.cfg.MaxPower-l.cfg.MinPower),
+			Unit:  measurements.UnitWatt,
+		},
+		Time: now,
+	}, nil
+}
+
+func (l *LoadShapeMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeConsumer
+}
+
+func (l *LoadShapeMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorLoadShape
+}
+
+// fraction returns the fraction of the power range at the given time, interpolated between the daily values and
+// multiplied by the factor of the weekday.
+func (l *LoadShapeMeasurementGenerator) fraction(t time.Time) float64 {
+	if len(l.shape.Daily) == 0 {
+		return 0
+	}
+
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	position := t.Sub(midnight).Seconds() / (24 * time.Hour).Seconds() * float64(len(l.shape.Daily))
+
+	index := int(position)
+	weight := position - math.Floor(position)
+	next := (index + 1) % len(l.shape.Daily)
+	fraction := l.shape.Daily[index]*(1-weight) + l.shape.Daily[next]*weight
+
+	if len(l.shape.Weekly) == 7 {
+		// Monday is the first day of the week
+		fraction *= l.shape.Weekly[(int(t.Weekday())+6)%7]
+	}
+
+	return fraction
+}
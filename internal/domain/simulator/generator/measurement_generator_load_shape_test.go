@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadShapeMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	cfg := simulator.Configuration{
+		Type:     domain.AssetTypeMotor,
+		MinPower: 100,
+		MaxPower: 1100,
+		LoadShape: &simulator.LoadShape{
+			Daily:  []float64{0, 1, 0.5, 0.5},
+			Weekly: []float64{1, 1, 1, 1, 1, 0.5, 0},
+		},
+	}
+
+	// 1 January 2024 is a Monday
+	monday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		time          time.Time
+		expectedPower float64
+	}{
+		{
+			name:          "Midnight",
+			time:          monday,
+			expectedPower: 100,
+		},
+		{
+			name:          "Interpolated between the daily values",
+			time:          monday.Add(3 * time.Hour),
+			expectedPower: 600,
+		},
+		{
+			name:          "Daily value",
+			time:          monday.Add(6 * time.Hour),
+			expectedPower: 1100,
+		},
+		{
+			name:          "Interpolated towards the next midnight",
+			time:          monday.Add(21 * time.Hour),
+			expectedPower: 350,
+		},
+		{
+			name:          "Weekly factor",
+			time:          monday.AddDate(0, 0, 5).Add(6 * time.Hour),
+			expectedPower: 600,
+		},
+		{
+			name:          "Day off",
+			time:          monday.AddDate(0, 0, 6).Add(6 * time.Hour),
+			expectedPower: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewLoadShape(cfg, SystemClock, NewSource(1))
+			generator.clock = ClockFunc(func() time.Time { return tt.time })
+
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedPower, measurement.Power.Value, 0.001)
+			assert.Equal(t, tt.time, measurement.Time)
+			assert.Nil(t, measurement.StateOfEnergy)
+		})
+	}
+
+	t.Run("Noise stays within the power range", func(t *testing.T) {
+		noisy := cfg
+		noisy.LoadShape = &simulator.LoadShape{Daily: []float64{0.9}, Noise: 0.2}
+
+		generator := NewLoadShape(noisy, SystemClock, NewSource(1))
+		generator.clock = ClockFunc(func() time.Time { return monday })
+
+		for i := 0; i < 1000; i++ {
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, measurement.Power.Value, 100.0)
+			assert.LessOrEqual(t, measurement.Power.Value, 1100.0)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// MotorMeasurementGenerator generates the power of a motor which starts and stops after random periods. The motor
+// switches only at measurements, so every start is followed by a measurement of the inrush peak.
+type MotorMeasurementGenerator struct {
+	cfg        simulator.Configuration
+	parameters simulator.MotorParameters
+
+	// Whether the motor is running
+	running bool
+	// Time the motor last started
+	startTime time.Time
+	// Time at which the motor starts or stops next
+	switchTime time.Time
+
+	clock Clock
+	rng   *rand.Rand
+}
+
+// NewMotor creates a motor generator. The motor starts in a random state, running for the expected share of the time.
+func NewMotor(cfg simulator.Configuration, clock Clock, source rand.Source) *MotorMeasurementGenerator {
+	var parameters simulator.MotorParameters
+	if cfg.Motor != nil {
+		parameters = *cfg.Motor
+	}
+	parameters = parameters.WithDefaults()
+
+	if parameters.RatedPower == 0 {
+		parameters.RatedPower = cfg.MaxPower / parameters.InrushFactor
+	}
+
+	runningShare := parameters.OnDuration.Seconds() / (parameters.OnDuration + parameters.OffDuration).Seconds()
+
+	rng := rand.New(source)
+	return &MotorMeasurementGenerator{
+		cfg:        cfg,
+		parameters: parameters,
+		running:    rng.Float64() < runningShare,
+		clock:      clock,
+		rng:        rng,
+	}
+}
+
+// GenerateMeasurement switches the motor when its current period ended and generates its power.
+func (m *MotorMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := m.clock.Now()
+
+	switch {
+	case m.switchTime.IsZero():
+		// This is the first measurement, the motor has been in its state for a while
+		m.switchTime = now.Add(m.period())
+	case !now.Before(m.switchTime):
+		m.running = !m.running
+		if m.running {
+			m.startTime = now
+		}
+		m.switchTime = now.Add(m.period())
+	}
+
+	return &measurements.Measurement{
+		Power: measurements.Power{
+			Value: m.power(now),
+			Unit:  measurements.UnitWatt,
+		},
+		Time: now,
+	}, nil
+}
+
+func (m *MotorMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeConsumer
+}
+
+func (m *MotorMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorMotor
+}
+
+// period returns a random duration of the current state with the configured average.
+func (m *MotorMeasurementGenerator) period() time.Duration {
+	average := m.parameters.OffDuration
+	if m.running {
+		average = m.parameters.OnDuration
+	}
+
+	return time.Duration(m.rng.ExpFloat64() * float64(average))
+}
+
+// power returns the power of the motor at the given time. After the start, the power decays from the inrush peak to
+// the rated power.
+func (m *MotorMeasurementGenerator) power(now time.Time) float64 {
+	if !m.running {
+		return m.cfg.MinPower
+	}
+
+	power := m.parameters.RatedPower
+	if !m.startTime.IsZero() {
+		decay := math.Exp(-now.Sub(m.startTime).Seconds() / m.parameters.InrushDuration.Seconds())
+		power *= 1 + (m.parameters.InrushFactor-1)*decay
+	}
+
+	return clamp(power, m.cfg.MinPower, m.cfg.MaxPower)
+}
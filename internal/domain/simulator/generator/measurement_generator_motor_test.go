@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMotorMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	cfg := simulator.Configuration{
+		Type:     domain.AssetTypeMotor,
+		MinPower: 10,
+		MaxPower: 5000,
+		Motor: &simulator.MotorParameters{
+			RatedPower:     1000,
+			OnDuration:     10 * time.Minute,
+			OffDuration:    20 * time.Minute,
+			InrushFactor:   4,
+			InrushDuration: 2 * time.Second,
+		},
+	}
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Start has an inrush peak", func(t *testing.T) {
+		generator := NewMotor(cfg, SystemClock, NewSource(1))
+		current := start
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		starts := 0
+		running := 0
+		samples := 20000
+		wasRunning := generator.running
+		for i := 0; i < samples; i++ {
+			current = current.Add(10 * time.Second)
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			if !generator.running {
+				assert.Equal(t, 10.0, measurement.Power.Value)
+			} else {
+				running++
+				if !wasRunning {
+					// First measurement after the start
+					assert.Equal(t, 4000.0, measurement.Power.Value)
+					starts++
+				} else {
+					assert.GreaterOrEqual(t, measurement.Power.Value, 1000.0)
+					assert.Less(t, measurement.Power.Value, 4000.0)
+				}
+			}
+			wasRunning = generator.running
+		}
+
+		assert.Positive(t, starts)
+		assert.InDelta(t, 1.0/3, float64(running)/float64(samples), 0.15)
+	})
+
+	t.Run("Inrush peak decays to the rated power", func(t *testing.T) {
+		generator := NewMotor(cfg, SystemClock, NewSource(1))
+		generator.running = true
+		generator.startTime = start
+
+		assert.Equal(t, 4000.0, generator.power(start))
+		assert.Less(t, generator.power(start.Add(2*time.Second)), 4000.0)
+		assert.InDelta(t, 1000, generator.power(start.Add(time.Minute)), 0.001)
+	})
+
+	t.Run("Power is limited by MaxPower", func(t *testing.T) {
+		limited := cfg
+		limited.MaxPower = 2000
+
+		generator := NewMotor(limited, SystemClock, NewSource(1))
+		generator.running = true
+		generator.startTime = start
+		assert.Equal(t, 2000.0, generator.power(start))
+	})
+
+	t.Run("Default parameters", func(t *testing.T) {
+		generator := NewMotor(simulator.Configuration{Type: domain.AssetTypeMotor, MaxPower: 5000}, SystemClock, NewSource(1))
+		assert.Equal(t, 1000.0, generator.parameters.RatedPower)
+		assert.Equal(t, simulator.DefaultOnDuration, generator.parameters.OnDuration)
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeConsumer), generator.GetEnergyType())
+		assert.Equal(t, simulator.GeneratorMotor, generator.GetGeneratorType())
+	})
+}
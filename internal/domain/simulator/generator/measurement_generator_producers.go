@@ -1,8 +1,7 @@
 package generator
 
 import (
-	"math"
-	"time"
+	"math/rand/v2"
 
 	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
@@ -13,11 +12,16 @@ type ProducerMeasurementGenerator struct {
 	cfg simulator.Configuration
 	// Last generated measurement
 	previousMeasurement *measurements.Measurement
+
+	clock Clock
+	rng   *rand.Rand
 }
 
-func NewProducer(cfg simulator.Configuration) *ProducerMeasurementGenerator {
+func NewProducer(cfg simulator.Configuration, clock Clock, source rand.Source) *ProducerMeasurementGenerator {
 	return &ProducerMeasurementGenerator{
-		cfg: cfg,
+		cfg:   cfg,
+		clock: clock,
+		rng:   rand.New(source),
 	}
 }
 
@@ -26,12 +30,12 @@ func NewProducer(cfg simulator.Configuration) *ProducerMeasurementGenerator {
 func (p *ProducerMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
 	if p.previousMeasurement == nil {
 		// This is the first measurement
-		measurement := zeroPowerMeasurement(0)
+		measurement := zeroPowerMeasurement(nil, p.clock.Now())
 		p.previousMeasurement = measurement
 		return measurement, nil
 	}
 
-	powerStep := getPowerStep(p.cfg.MaxPowerStep)
+	powerStep := getPowerStep(p.rng, p.cfg.MaxPowerStep)
 	power := p.previousMeasurement.Power.Value + powerStep
 
 	// Assuming that min and max have negative sign, so we need to swap them
@@ -46,11 +50,9 @@ func (p *ProducerMeasurementGenerator) GenerateMeasurement() (*measurements.Meas
 			Value: power,
 			Unit:  measurements.UnitWatt,
 		},
-		Time: time.Now(),
+		Time: p.clock.Now(),
 	}
 
-	p.calculateSoE(measurement)
-
 	p.previousMeasurement = measurement
 	return measurement, nil
 }
@@ -59,12 +61,6 @@ func (p *ProducerMeasurementGenerator) GetEnergyType() domain.EnergyType {
 	return domain.EnergyTypeProducer
 }
 
-func (p *ProducerMeasurementGenerator) calculateSoE(measurement *measurements.Measurement) {
-	// Calculate energy between two measurements
-	timeElapsed := measurement.Time.Sub(p.previousMeasurement.Time).Seconds()
-	energyChange := ((measurement.Power.Value + p.previousMeasurement.Power.Value) / 2) * timeElapsed
-	energyPercentageChange := (energyChange / math.Abs(p.cfg.MaxPower)) * 100
-	measurement.StateOfEnergy = p.previousMeasurement.StateOfEnergy + energyPercentageChange
-
-	measurement.StateOfEnergy = clamp(measurement.StateOfEnergy, 0, 100)
+func (p *ProducerMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorProducer
 }
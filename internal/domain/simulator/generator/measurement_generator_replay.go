@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"slices"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// ReplayMeasurementGenerator plays back recorded measurements. The replay starts with the first measurement and
+// emits every recorded measurement once the recorded time since the first measurement, divided by the speed, passed.
+// When several measurements are due at once, because the measurement interval is longer than the recorded interval,
+// only the latest one is emitted.
+type ReplayMeasurementGenerator struct {
+	cfg        simulator.Configuration
+	parameters simulator.ReplayParameters
+
+	// Recorded measurements ordered by time
+	samples []measurements.Measurement
+	// Recorded duration of a loop, from the first measurement to the first measurement of the next loop
+	loopDuration time.Duration
+
+	// Time the replay started
+	start time.Time
+	// Index of the next measurement and the number of completed loops
+	next  int
+	loops int
+
+	clock Clock
+}
+
+// NewReplay creates a replay generator of the recorded measurements, which must not be empty.
+func NewReplay(cfg simulator.Configuration, clock Clock, samples []measurements.Measurement) *ReplayMeasurementGenerator {
+	var parameters simulator.ReplayParameters
+	if cfg.Replay != nil {
+		parameters = *cfg.Replay
+	}
+	parameters = parameters.WithDefaults()
+
+	samples = slices.Clone(samples)
+	slices.SortStableFunc(samples, func(a, b measurements.Measurement) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	// The next loop starts one average recorded interval after the last measurement
+	var loopDuration time.Duration
+	if len(samples) > 1 {
+		span := samples[len(samples)-1].Time.Sub(samples[0].Time)
+		loopDuration = span + span/time.Duration(len(samples)-1)
+	}
+	if loopDuration <= 0 {
+		loopDuration = time.Duration(float64(cfg.MeasurementInterval) * parameters.Speed)
+	}
+
+	return &ReplayMeasurementGenerator{
+		cfg:          cfg,
+		parameters:   parameters,
+		samples:      samples,
+		loopDuration: loopDuration,
+		clock:        clock,
+	}
+}
+
+// GenerateMeasurement returns the latest recorded measurement which is due. It returns ErrNoMeasurement if no
+// measurement is due since the previous one, or the replay ended.
+func (r *ReplayMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	if len(r.samples) == 0 {
+		return nil, ErrNoMeasurement
+	}
+
+	now := r.clock.Now()
+	if r.start.IsZero() {
+		r.start = now
+	}
+	elapsed := now.Sub(r.start)
+
+	if r.next == len(r.samples) {
+		if !r.parameters.Loop {
+			return nil, ErrNoMeasurement
+		}
+
+		r.next = 0
+		r.loops++
+	}
+
+	if r.offset(r.next, r.loops) > elapsed {
+		return nil, ErrNoMeasurement
+	}
+
+	// Skip to the latest due measurement
+	due, dueLoops := r.next, r.loops
+	for {
+		next, nextLoops := due+1, dueLoops
+		if next == len(r.samples) {
+			if !r.parameters.Loop {
+				break
+			}
+			next, nextLoops = 0, nextLoops+1
+		}
+
+		if r.offset(next, nextLoops) > elapsed {
+			break
+		}
+		due, dueLoops = next, nextLoops
+	}
+	r.next, r.loops = due+1, dueLoops
+
+	measurement := r.samples[due]
+	// The publisher assigns a new ID, as a looped measurement is a new measurement
+	measurement.Id = ""
+	if r.parameters.ShiftToNow {
+		measurement.Time = r.start.Add(r.offset(due, dueLoops))
+	}
+
+	return &measurement, nil
+}
+
+func (r *ReplayMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return r.cfg.Type.GetEnergyType()
+}
+
+func (r *ReplayMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorReplay
+}
+
+// offset returns the time since the start of the replay at which the measurement with the given index is due in the
+// given loop.
+func (r *ReplayMeasurementGenerator) offset(index, loops int) time.Duration {
+	recorded := r.samples[index].Time.Sub(r.samples[0].Time) + time.Duration(loops)*r.loopDuration
+	return time.Duration(float64(recorded) / r.parameters.Speed)
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	recorded := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	// One measurement per minute, given out of order
+	samples := []measurements.Measurement{
+		{Id: "3", Time: recorded.Add(2 * time.Minute), Power: measurements.Power{Value: 300, Unit: "W"}},
+		{Id: "1", Time: recorded, Power: measurements.Power{Value: 100, Unit: "W"}},
+		{Id: "2", Time: recorded.Add(time.Minute), Power: measurements.Power{Value: 200, Unit: "W"}},
+	}
+
+	newGenerator := func(parameters simulator.ReplayParameters) (*ReplayMeasurementGenerator, *time.Time) {
+		cfg := simulator.Configuration{
+			Type:                domain.AssetTypeBattery,
+			MeasurementInterval: time.Second,
+			Mode:                simulator.ModeReplay,
+			Replay:              &parameters,
+		}
+
+		current := start
+		generator := NewReplay(cfg, ClockFunc(func() time.Time { return current }), samples)
+		return generator, &current
+	}
+
+	// powers generates a measurement at each of the offsets from the start and returns the powers, 0 if none is due
+	powers := func(generator *ReplayMeasurementGenerator, current *time.Time, offsets ...time.Duration) []float64 {
+		var result []float64
+		for _, offset := range offsets {
+			*current = start.Add(offset)
+
+			measurement, err := generator.GenerateMeasurement()
+			if err != nil {
+				require.ErrorIs(t, err, ErrNoMeasurement)
+				result = append(result, 0)
+				continue
+			}
+			result = append(result, measurement.Power.Value)
+		}
+		return result
+	}
+
+	t.Run("Replay at the recorded speed", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording})
+
+		actual := powers(generator, current, 0, 30*time.Second, time.Minute, 90*time.Second, 2*time.Minute, 10*time.Minute)
+		assert.Equal(t, []float64{100, 0, 200, 0, 300, 0}, actual)
+	})
+
+	t.Run("Accelerated replay", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording, Speed: 60})
+
+		actual := powers(generator, current, 0, time.Second, 2*time.Second)
+		assert.Equal(t, []float64{100, 200, 300}, actual)
+	})
+
+	t.Run("Only the latest due measurement is emitted", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording})
+
+		actual := powers(generator, current, 0, 3*time.Minute)
+		assert.Equal(t, []float64{100, 300}, actual)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording, Loop: true})
+
+		// The next loop starts one recorded interval after the last measurement
+		actual := powers(generator, current, 0, time.Minute, 2*time.Minute, 3*time.Minute, 4*time.Minute, 8*time.Minute)
+		assert.Equal(t, []float64{100, 200, 300, 100, 200, 300}, actual)
+	})
+
+	t.Run("Keep the recorded time", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording})
+		*current = start.Add(time.Minute)
+
+		measurement, err := generator.GenerateMeasurement()
+		require.NoError(t, err)
+		assert.Equal(t, recorded, measurement.Time)
+		// The replayed measurement gets a new ID when published
+		assert.Empty(t, measurement.Id)
+	})
+
+	t.Run("Shift to now", func(t *testing.T) {
+		generator, current := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording, ShiftToNow: true, Loop: true})
+
+		measurement, err := generator.GenerateMeasurement()
+		require.NoError(t, err)
+		assert.Equal(t, start, measurement.Time)
+
+		// The time of a looped measurement is the time it was due
+		*current = start.Add(4*time.Minute + 10*time.Second)
+		measurement, err = generator.GenerateMeasurement()
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(4*time.Minute), measurement.Time)
+		assert.Equal(t, 200.0, measurement.Power.Value)
+	})
+
+	t.Run("Energy type of the asset", func(t *testing.T) {
+		generator, _ := newGenerator(simulator.ReplayParameters{Source: simulator.ReplaySourceRecording})
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeCombined), generator.GetEnergyType())
+		assert.Equal(t, simulator.GeneratorReplay, generator.GetGeneratorType())
+		assert.Equal(t, simulator.DefaultReplaySpeed, generator.parameters.Speed)
+	})
+}
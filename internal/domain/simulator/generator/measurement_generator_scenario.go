@@ -0,0 +1,203 @@
+package generator
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// ScenarioMeasurementGenerator plays the events of a scenario on top of a base generator. The scenario starts with the
+// first measurement. The power events (setPower, ramp and noise) change the power of the base measurement in the order
+// of the events, then the measurement is frozen or dropped by the freeze and offline events.
+type ScenarioMeasurementGenerator struct {
+	base     MeasurementGenerator
+	scenario simulator.Scenario
+	// Generators of the config events, by the index of the event
+	configured map[int]MeasurementGenerator
+
+	// Time the scenario started and the number of completed loops
+	start time.Time
+	loops int
+	// Power at the start of the ramps and the measurements at the start of the freezes, by the index of the event
+	rampFrom map[int]float64
+	frozen   map[int]measurements.Measurement
+
+	clock Clock
+	rng   *rand.Rand
+}
+
+// NewScenario creates a scenario generator on top of the base generator. The generators of the config events are
+// created upfront with configure, so a scenario with an invalid config fails before it starts.
+func NewScenario(
+	base MeasurementGenerator,
+	scenario simulator.Scenario,
+	configure func(config map[string]any) (MeasurementGenerator, error),
+	clock Clock,
+	source rand.Source,
+) (*ScenarioMeasurementGenerator, error) {
+	scenario.Events = slices.Clone(scenario.Events)
+	slices.SortStableFunc(scenario.Events, func(a, b simulator.ScenarioEvent) int {
+		return cmp.Compare(a.At, b.At)
+	})
+
+	configured := map[int]MeasurementGenerator{}
+	for i, event := range scenario.Events {
+		if event.Action != simulator.ActionConfig {
+			continue
+		}
+
+		generator, err := configure(event.Config)
+		if err != nil {
+			return nil, err
+		}
+		configured[i] = generator
+	}
+
+	return &ScenarioMeasurementGenerator{
+		base:       base,
+		scenario:   scenario,
+		configured: configured,
+		rampFrom:   map[int]float64{},
+		frozen:     map[int]measurements.Measurement{},
+		clock:      clock,
+		rng:        rand.New(source),
+	}, nil
+}
+
+// GenerateMeasurement generates the measurement of the generator of the latest config event, or the base generator,
+// and applies the events in progress. It returns ErrNoMeasurement while the asset is offline.
+func (s *ScenarioMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := s.clock.Now()
+	if s.start.IsZero() {
+		s.start = now
+	}
+	elapsed := now.Sub(s.start)
+
+	if s.scenario.Duration > 0 {
+		loops := int(elapsed / s.scenario.Duration)
+		if loops > 0 && !s.scenario.Loop {
+			// The scenario ended, the asset is back to its configuration
+			return s.base.GenerateMeasurement()
+		}
+
+		if loops != s.loops {
+			s.loops = loops
+			clear(s.rampFrom)
+			clear(s.frozen)
+		}
+		elapsed -= time.Duration(loops) * s.scenario.Duration
+	}
+
+	// Events which started, ordered by time
+	started := s.scenario.Events
+	for i, event := range s.scenario.Events {
+		if event.At > elapsed {
+			started = s.scenario.Events[:i]
+			break
+		}
+	}
+
+	generator := s.base
+	for i, event := range started {
+		if event.Action == simulator.ActionConfig {
+			generator = s.configured[i]
+		}
+	}
+
+	measurement, err := generator.GenerateMeasurement()
+	if err != nil {
+		return nil, err
+	}
+
+	measurement, err = s.applyPower(*measurement, started, elapsed)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range started {
+		if !isActive(event, elapsed) {
+			continue
+		}
+
+		switch event.Action {
+		case simulator.ActionOffline:
+			return nil, ErrNoMeasurement
+		case simulator.ActionFreeze:
+			frozen, ok := s.frozen[i]
+			if !ok {
+				frozen = *measurement
+				s.frozen[i] = frozen
+			}
+
+			frozen.Time = measurement.Time
+			measurement = &frozen
+		}
+	}
+
+	return measurement, nil
+}
+
+// applyPower applies the power events which started to the power of the measurement, converted to W.
+func (s *ScenarioMeasurementGenerator) applyPower(measurement measurements.Measurement, started []simulator.ScenarioEvent, elapsed time.Duration) (*measurements.Measurement, error) {
+	converted := false
+	for i, event := range started {
+		switch event.Action {
+		case simulator.ActionSetPower, simulator.ActionNoise:
+			if !isActive(event, elapsed) {
+				continue
+			}
+		case simulator.ActionRamp:
+			// The ramp holds the target power after its duration
+		default:
+			continue
+		}
+
+		if !converted {
+			var err error
+			measurement, err = measurement.ConvertTo(measurements.UnitWatt)
+			if err != nil {
+				return nil, err
+			}
+			converted = true
+		}
+
+		switch event.Action {
+		case simulator.ActionSetPower:
+			measurement.Power.Value = *event.Power
+		case simulator.ActionRamp:
+			from, ok := s.rampFrom[i]
+			if !ok {
+				from = measurement.Power.Value
+				if event.From != nil {
+					from = *event.From
+				}
+				s.rampFrom[i] = from
+			}
+
+			progress := min(1, float64(elapsed-event.At)/float64(event.Duration))
+			measurement.Power.Value = from + progress*(*event.Power-from)
+		case simulator.ActionNoise:
+			measurement.Power.Value += event.Noise * s.rng.NormFloat64()
+		}
+	}
+
+	return &measurement, nil
+}
+
+func (s *ScenarioMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return s.base.GetEnergyType()
+}
+
+func (s *ScenarioMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorScenario
+}
+
+// isActive checks if the event which started is still in progress.
+func isActive(event simulator.ScenarioEvent, elapsed time.Duration) bool {
+	return event.End() == 0 || elapsed < event.End()
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// constantGenerator generates a constant power, to tell the changes of the scenario apart.
+type constantGenerator struct {
+	power float64
+	clock Clock
+}
+
+func (c *constantGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	return &measurements.Measurement{
+		Power: measurements.Power{Value: c.power, Unit: measurements.UnitWatt},
+		Time:  c.clock.Now(),
+	}, nil
+}
+
+func (c *constantGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeConsumer
+}
+
+func (c *constantGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorConsumer
+}
+
+func TestScenarioMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	power := func(value float64) *float64 { return &value }
+
+	newGenerator := func(t *testing.T, scenario simulator.Scenario) (*ScenarioMeasurementGenerator, *time.Time) {
+		current := start
+		clock := ClockFunc(func() time.Time { return current })
+
+		configure := func(config map[string]any) (MeasurementGenerator, error) {
+			return &constantGenerator{power: config["power"].(float64), clock: clock}, nil
+		}
+
+		generator, err := NewScenario(&constantGenerator{power: 1000, clock: clock}, scenario, configure, clock, NewSource(1))
+		require.NoError(t, err)
+		return generator, &current
+	}
+
+	// powers generates a measurement every minute from the start and returns the powers, -1 if there is none
+	powers := func(t *testing.T, generator *ScenarioMeasurementGenerator, current *time.Time, minutes int) []float64 {
+		var result []float64
+		for i := 0; i < minutes; i++ {
+			*current = start.Add(time.Duration(i) * time.Minute)
+
+			measurement, err := generator.GenerateMeasurement()
+			if err != nil {
+				require.ErrorIs(t, err, ErrNoMeasurement)
+				result = append(result, -1)
+				continue
+			}
+			assert.Equal(t, *current, measurement.Time)
+			result = append(result, measurement.Power.Value)
+		}
+		return result
+	}
+
+	t.Run("Generator type", func(t *testing.T) {
+		generator, _ := newGenerator(t, simulator.Scenario{})
+
+		// The scenario reports its own kind, but the energy type of the asset
+		assert.Equal(t, simulator.GeneratorScenario, generator.GetGeneratorType())
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeConsumer), generator.GetEnergyType())
+	})
+
+	t.Run("Set power", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionSetPower, Power: power(200), Duration: 2 * time.Minute},
+			{At: 4 * time.Minute, Action: simulator.ActionSetPower, Power: power(300)},
+		}})
+
+		assert.Equal(t, []float64{1000, 200, 200, 1000, 300, 300}, powers(t, generator, current, 6))
+	})
+
+	t.Run("Ramp", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionRamp, Power: power(0), Duration: 4 * time.Minute},
+			{At: 6 * time.Minute, Action: simulator.ActionRamp, From: power(100), Power: power(300), Duration: 2 * time.Minute},
+		}})
+
+		// The ramp holds the target power
+		assert.Equal(t, []float64{1000, 1000, 750, 500, 250, 0, 100, 200, 300, 300}, powers(t, generator, current, 10))
+	})
+
+	t.Run("Freeze", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionRamp, Power: power(0), Duration: 4 * time.Minute},
+			{At: 2 * time.Minute, Action: simulator.ActionFreeze, Duration: 2 * time.Minute},
+		}})
+
+		assert.Equal(t, []float64{1000, 1000, 750, 750, 250, 0}, powers(t, generator, current, 6))
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionOffline, Duration: 2 * time.Minute},
+		}})
+
+		assert.Equal(t, []float64{1000, -1, -1, 1000}, powers(t, generator, current, 4))
+	})
+
+	t.Run("Noise", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionNoise, Noise: 50, Duration: 100 * time.Minute},
+		}})
+
+		actual := powers(t, generator, current, 102)
+		assert.Equal(t, 1000.0, actual[0])
+		assert.Equal(t, 1000.0, actual[101])
+
+		noisy := actual[1:101]
+		assert.NotEqual(t, noisy[0], noisy[1])
+		for _, value := range noisy {
+			assert.InDelta(t, 1000, value, 300)
+		}
+	})
+
+	t.Run("Change the configuration", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionConfig, Config: map[string]any{"power": 500.0}},
+			{At: 2 * time.Minute, Action: simulator.ActionConfig, Config: map[string]any{"power": 700.0}},
+		}})
+
+		assert.Equal(t, []float64{1000, 500, 700, 700}, powers(t, generator, current, 4))
+	})
+
+	t.Run("End of the scenario", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Duration: 2 * time.Minute, Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionSetPower, Power: power(200)},
+			{At: time.Minute, Action: simulator.ActionConfig, Config: map[string]any{"power": 500.0}},
+		}})
+
+		assert.Equal(t, []float64{1000, 200, 1000, 1000}, powers(t, generator, current, 4))
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		generator, current := newGenerator(t, simulator.Scenario{Duration: 3 * time.Minute, Loop: true, Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionRamp, Power: power(0), Duration: 2 * time.Minute},
+		}})
+
+		assert.Equal(t, []float64{1000, 1000, 500, 1000, 1000, 500, 1000}, powers(t, generator, current, 7))
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		clock := ClockFunc(time.Now)
+		configure := func(config map[string]any) (MeasurementGenerator, error) {
+			return nil, errors.New("invalid config")
+		}
+
+		_, err := NewScenario(&constantGenerator{clock: clock}, simulator.Scenario{Events: []simulator.ScenarioEvent{
+			{At: time.Minute, Action: simulator.ActionConfig, Config: map[string]any{"maxPower": "high"}},
+		}}, configure, clock, NewSource(1))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// cloudCorrelationTime is the time after which the cloud cover mostly forgets its previous value.
+const cloudCorrelationTime = 30 * time.Minute
+
+// SolarMeasurementGenerator generates the production of solar panels, following the position of the sun at the location
+// of the panels. The clear sky production is reduced by the cloud cover, which varies around the configured average.
+type SolarMeasurementGenerator struct {
+	cfg        simulator.Configuration
+	parameters simulator.SolarParameters
+
+	// Current cloud cover, between 0 and 1
+	cloudCover float64
+	// Time of the last generated measurement
+	previousTime time.Time
+
+	clock Clock
+	rng   *rand.Rand
+}
+
+// NewSolar creates a solar generator. Without solar parameters, the panels are located at latitude and longitude 0.
+func NewSolar(cfg simulator.Configuration, clock Clock, source rand.Source) *SolarMeasurementGenerator {
+	var parameters simulator.SolarParameters
+	if cfg.Solar != nil {
+		parameters = *cfg.Solar
+	}
+
+	if parameters.Capacity == 0 {
+		parameters.Capacity = math.Abs(cfg.MaxPower)
+	}
+
+	return &SolarMeasurementGenerator{
+		cfg:        cfg,
+		parameters: parameters,
+		cloudCover: parameters.CloudCover,
+		clock:      clock,
+		rng:        rand.New(source),
+	}
+}
+
+// GenerateMeasurement generates the production of the panels at the current time. The production is negative, as the
+// asset is a producer, and is limited by MaxPower.
+func (s *SolarMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := s.clock.Now()
+	s.updateCloudCover(now)
+
+	zenithCosine := solarZenithCosine(s.parameters.Latitude, s.parameters.Longitude, now)
+	irradiance := clearSkyIrradiance(zenithCosine) * cloudAttenuation(s.cloudCover)
+
+	power := 0.0
+	if irradiance > 0 {
+		power = -s.parameters.Capacity * irradiance / standardIrradiance
+	}
+
+	if s.cfg.MaxPower < 0 {
+		power = math.Max(power, s.cfg.MaxPower)
+	}
+
+	return &measurements.Measurement{
+		Power: measurements.Power{
+			Value: power,
+			Unit:  measurements.UnitWatt,
+		},
+		Time: now,
+	}, nil
+}
+
+func (s *SolarMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeProducer
+}
+
+func (s *SolarMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorSolar
+}
+
+// updateCloudCover moves the cloud cover randomly towards the average cloud cover. The longer the time since the
+// previous measurement, the less the cloud cover depends on its previous value, so the variability doesn't depend on
+// the measurement interval.
+func (s *SolarMeasurementGenerator) updateCloudCover(now time.Time) {
+	previousTime := s.previousTime
+	s.previousTime = now
+	if previousTime.IsZero() || s.parameters.CloudVariability == 0 {
+		return
+	}
+
+	correlation := math.Exp(-now.Sub(previousTime).Seconds() / cloudCorrelationTime.Seconds())
+	noise := s.parameters.CloudVariability * math.Sqrt(1-correlation*correlation) * s.rng.NormFloat64()
+
+	average := s.parameters.CloudCover
+	s.cloudCover = clamp(average+correlation*(s.cloudCover-average)+noise, 0, 1)
+}
@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolarZenithCosine(t *testing.T) {
+	equinoxNoon := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		latitude          float64
+		longitude         float64
+		time              time.Time
+		expectedElevation float64
+	}{
+		{
+			name:              "Equator at noon on the equinox",
+			time:              equinoxNoon,
+			expectedElevation: 90,
+		},
+		{
+			name:              "Equator at midnight on the equinox",
+			time:              equinoxNoon.Add(12 * time.Hour),
+			expectedElevation: -90,
+		},
+		{
+			name:              "Solar noon moves with the longitude",
+			longitude:         90,
+			time:              equinoxNoon.Add(-6 * time.Hour),
+			expectedElevation: 90,
+		},
+		{
+			name:              "Sun is lower at higher latitudes",
+			latitude:          60,
+			time:              equinoxNoon,
+			expectedElevation: 30,
+		},
+		{
+			name:              "Summer solstice at the Tropic of Cancer",
+			latitude:          23.44,
+			time:              time.Date(2024, time.June, 20, 12, 0, 0, 0, time.UTC),
+			expectedElevation: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenithCosine := solarZenithCosine(tt.latitude, tt.longitude, tt.time)
+			elevation := 90 - math.Acos(zenithCosine)/degreesToRadians
+
+			// The equation of time shifts the solar noon by a few minutes
+			assert.InDelta(t, tt.expectedElevation, elevation, 3)
+		})
+	}
+}
+
+func TestClearSkyIrradiance(t *testing.T) {
+	assert.Zero(t, clearSkyIrradiance(-0.5))
+	assert.Zero(t, clearSkyIrradiance(0))
+	assert.InDelta(t, 1037, clearSkyIrradiance(1), 1)
+	assert.Less(t, clearSkyIrradiance(0.5), clearSkyIrradiance(1))
+}
+
+func TestCloudAttenuation(t *testing.T) {
+	assert.Equal(t, 1.0, cloudAttenuation(0))
+	assert.InDelta(t, 0.25, cloudAttenuation(1), 0.001)
+	assert.Greater(t, cloudAttenuation(0.3), cloudAttenuation(0.8))
+}
+
+func TestSolarMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	cfg := simulator.Configuration{
+		Type:     domain.AssetTypeSolar,
+		MaxPower: -4000,
+		MinPower: 0,
+		Solar: &simulator.SolarParameters{
+			Latitude:  46.05,
+			Longitude: 14.5,
+			Capacity:  5000,
+		},
+	}
+
+	// Solar noon in Ljubljana is at around 11:00 UTC in June
+	day := time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	t.Run("No production at night", func(t *testing.T) {
+		generator := NewSolar(cfg, SystemClock, NewSource(1))
+		generator.clock = ClockFunc(func() time.Time { return day.Add(23 * time.Hour) })
+
+		measurement, err := generator.GenerateMeasurement()
+		require.NoError(t, err)
+		assert.Zero(t, measurement.Power.Value)
+		assert.False(t, math.Signbit(measurement.Power.Value))
+		assert.Equal(t, measurements.UnitWatt, measurement.Power.Unit)
+	})
+
+	t.Run("Production is limited by MaxPower at noon", func(t *testing.T) {
+		generator := NewSolar(cfg, SystemClock, NewSource(1))
+		generator.clock = ClockFunc(func() time.Time { return day.Add(11 * time.Hour) })
+
+		measurement, err := generator.GenerateMeasurement()
+		require.NoError(t, err)
+		assert.Equal(t, -4000.0, measurement.Power.Value)
+	})
+
+	t.Run("Diurnal curve", func(t *testing.T) {
+		unlimited := cfg
+		unlimited.MaxPower = -10000
+		generator := NewSolar(unlimited, SystemClock, NewSource(1))
+
+		var production []float64
+		for hour := 0; hour < 24; hour++ {
+			generator.clock = ClockFunc(func() time.Time { return day.Add(time.Duration(hour) * time.Hour) })
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+			production = append(production, -measurement.Power.Value)
+		}
+
+		// The sun rises at around 03:15 UTC and sets at around 18:50 UTC
+		assert.Zero(t, production[2])
+		assert.Positive(t, production[4])
+		assert.Positive(t, production[18])
+		assert.Zero(t, production[20])
+
+		// Production increases until the noon and decreases afterward
+		assert.Less(t, production[6], production[9])
+		assert.Less(t, production[9], production[11])
+		assert.Greater(t, production[11], production[13])
+		assert.Greater(t, production[13], production[16])
+	})
+
+	t.Run("Clouds reduce the production", func(t *testing.T) {
+		cloudy := cfg
+		cloudy.Solar = &simulator.SolarParameters{
+			Latitude:   46.05,
+			Longitude:  14.5,
+			Capacity:   5000,
+			CloudCover: 1,
+		}
+
+		clearGenerator := NewSolar(cfg, SystemClock, NewSource(1))
+		cloudyGenerator := NewSolar(cloudy, SystemClock, NewSource(1))
+		clearGenerator.clock = ClockFunc(func() time.Time { return day.Add(8 * time.Hour) })
+		cloudyGenerator.clock = clearGenerator.clock
+
+		clear, err := clearGenerator.GenerateMeasurement()
+		require.NoError(t, err)
+		overcast, err := cloudyGenerator.GenerateMeasurement()
+		require.NoError(t, err)
+
+		assert.InDelta(t, 0.25, overcast.Power.Value/clear.Power.Value, 0.001)
+	})
+
+	t.Run("Cloud cover varies around the average", func(t *testing.T) {
+		variable := cfg
+		variable.Solar = &simulator.SolarParameters{
+			Latitude:         46.05,
+			Longitude:        14.5,
+			CloudCover:       0.5,
+			CloudVariability: 0.2,
+		}
+
+		generator := NewSolar(variable, SystemClock, NewSource(1))
+		current := day.Add(8 * time.Hour)
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		sum := 0.0
+		samples := 2000
+		for i := 0; i < samples; i++ {
+			current = current.Add(10 * time.Minute)
+			_, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			assert.GreaterOrEqual(t, generator.cloudCover, 0.0)
+			assert.LessOrEqual(t, generator.cloudCover, 1.0)
+			sum += generator.cloudCover
+		}
+
+		assert.InDelta(t, 0.5, sum/float64(samples), 0.1)
+	})
+
+	t.Run("Capacity defaults to MaxPower", func(t *testing.T) {
+		generator := NewSolar(simulator.Configuration{Type: domain.AssetTypeSolar, MaxPower: -2000}, SystemClock, NewSource(1))
+		assert.Equal(t, 2000.0, generator.parameters.Capacity)
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeProducer), generator.GetEnergyType())
+		assert.Equal(t, simulator.GeneratorSolar, generator.GetGeneratorType())
+	})
+}
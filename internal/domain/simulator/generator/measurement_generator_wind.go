@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// WindMeasurementGenerator generates the production of a wind turbine. The wind speed is Weibull distributed and
+// changes gradually, and is converted to power by the power curve of the turbine.
+type WindMeasurementGenerator struct {
+	cfg        simulator.Configuration
+	parameters simulator.WindParameters
+
+	// Scale of the Weibull distribution, so the distribution has the configured mean
+	weibullScale float64
+	// Standard normal variable driving the wind speed, correlated between measurements
+	normalizedWindSpeed float64
+	// Time of the last generated measurement
+	previousTime time.Time
+
+	clock Clock
+	rng   *rand.Rand
+}
+
+// NewWind creates a wind generator. Without wind parameters, the default wind and power curve are used.
+func NewWind(cfg simulator.Configuration, clock Clock, source rand.Source) *WindMeasurementGenerator {
+	var parameters simulator.WindParameters
+	if cfg.Wind != nil {
+		parameters = *cfg.Wind
+	}
+	parameters = parameters.WithDefaults()
+
+	return &WindMeasurementGenerator{
+		cfg:          cfg,
+		parameters:   parameters,
+		weibullScale: parameters.MeanWindSpeed / math.Gamma(1+1/parameters.WeibullShape),
+		clock:        clock,
+		rng:          rand.New(source),
+	}
+}
+
+// GenerateMeasurement generates the production of the turbine at the current wind speed. The production is negative,
+// as the asset is a producer.
+func (w *WindMeasurementGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	now := w.clock.Now()
+	w.updateWindSpeed(now)
+
+	power := 0.0
+	if output := w.powerCurve(w.windSpeed()); output > 0 {
+		power = -output
+	}
+
+	return &measurements.Measurement{
+		Power: measurements.Power{
+			Value: power,
+			Unit:  measurements.UnitWatt,
+		},
+		Time: now,
+	}, nil
+}
+
+func (w *WindMeasurementGenerator) GetEnergyType() domain.EnergyType {
+	return domain.EnergyTypeProducer
+}
+
+func (w *WindMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	return simulator.GeneratorWind
+}
+
+// updateWindSpeed moves the normalized wind speed randomly towards zero, the median. The first wind speed is drawn
+// from the distribution, and the longer the time since the previous measurement, the less the wind speed depends on
+// its previous value.
+func (w *WindMeasurementGenerator) updateWindSpeed(now time.Time) {
+	previousTime := w.previousTime
+	w.previousTime = now
+	if previousTime.IsZero() {
+		w.normalizedWindSpeed = w.rng.NormFloat64()
+		return
+	}
+
+	correlation := math.Exp(-now.Sub(previousTime).Seconds() / w.parameters.CorrelationTime.Seconds())
+	w.normalizedWindSpeed = correlation*w.normalizedWindSpeed + math.Sqrt(1-correlation*correlation)*w.rng.NormFloat64()
+}
+
+// windSpeed maps the normalized wind speed to the Weibull distribution through its quantile function, so the wind
+// speed keeps the correlation of the normalized wind speed.
+func (w *WindMeasurementGenerator) windSpeed() float64 {
+	quantile := 0.5 * math.Erfc(-w.normalizedWindSpeed/math.Sqrt2)
+	return w.weibullScale * math.Pow(-math.Log(1-quantile), 1/w.parameters.WeibullShape)
+}
+
+// powerCurve returns the power of the turbine in W at the given wind speed. Below the rated speed, the power grows with
+// the cube of the wind speed, as the energy of the wind does.
+func (w *WindMeasurementGenerator) powerCurve(windSpeed float64) float64 {
+	ratedPower := math.Abs(w.cfg.MaxPower)
+	cutIn, rated, cutOut := w.parameters.CutInSpeed, w.parameters.RatedSpeed, w.parameters.CutOutSpeed
+
+	switch {
+	case windSpeed < cutIn || windSpeed >= cutOut:
+		return 0
+	case windSpeed >= rated:
+		return ratedPower
+	default:
+		return ratedPower * (math.Pow(windSpeed, 3) - math.Pow(cutIn, 3)) / (math.Pow(rated, 3) - math.Pow(cutIn, 3))
+	}
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindMeasurementGenerator_powerCurve(t *testing.T) {
+	generator := NewWind(simulator.Configuration{
+		Type:     domain.AssetTypeWind,
+		MaxPower: -2000,
+		Wind: &simulator.WindParameters{
+			CutInSpeed:  3,
+			RatedSpeed:  12,
+			CutOutSpeed: 25,
+		},
+	}, SystemClock, NewSource(1))
+
+	tests := []struct {
+		name      string
+		windSpeed float64
+		power     float64
+	}{
+		{
+			name:      "No wind",
+			windSpeed: 0,
+			power:     0,
+		},
+		{
+			name:      "Below cut-in speed",
+			windSpeed: 2.9,
+			power:     0,
+		},
+		{
+			name:      "At cut-in speed",
+			windSpeed: 3,
+			power:     0,
+		},
+		{
+			name:      "Between cut-in and rated speed",
+			windSpeed: 8,
+			power:     2000 * (512.0 - 27) / (1728 - 27),
+		},
+		{
+			name:      "At rated speed",
+			windSpeed: 12,
+			power:     2000,
+		},
+		{
+			name:      "Between rated and cut-out speed",
+			windSpeed: 20,
+			power:     2000,
+		},
+		{
+			name:      "At cut-out speed",
+			windSpeed: 25,
+			power:     0,
+		},
+		{
+			name:      "Storm",
+			windSpeed: 40,
+			power:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.power, generator.powerCurve(tt.windSpeed), 0.001)
+		})
+	}
+}
+
+func TestWindMeasurementGenerator_GenerateMeasurement(t *testing.T) {
+	cfg := simulator.Configuration{
+		Type:     domain.AssetTypeWind,
+		MaxPower: -2000,
+		Wind: &simulator.WindParameters{
+			MeanWindSpeed:   8,
+			CorrelationTime: time.Hour,
+		},
+	}
+
+	t.Run("Power is within the rated power", func(t *testing.T) {
+		generator := NewWind(cfg, SystemClock, NewSource(1))
+		current := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		for i := 0; i < 500; i++ {
+			current = current.Add(time.Minute)
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			assert.LessOrEqual(t, measurement.Power.Value, 0.0)
+			assert.GreaterOrEqual(t, measurement.Power.Value, -2000.0)
+			assert.False(t, math.Signbit(measurement.Power.Value) && measurement.Power.Value == 0)
+			assert.Equal(t, measurements.UnitWatt, measurement.Power.Unit)
+			assert.Equal(t, current, measurement.Time)
+		}
+	})
+
+	t.Run("Wind speed follows the Weibull distribution", func(t *testing.T) {
+		generator := NewWind(cfg, SystemClock, NewSource(1))
+		current := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		// Measurements a day apart are practically independent
+		sum := 0.0
+		samples := 5000
+		for i := 0; i < samples; i++ {
+			current = current.Add(24 * time.Hour)
+			_, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			speed := generator.windSpeed()
+			assert.GreaterOrEqual(t, speed, 0.0)
+			sum += speed
+		}
+
+		assert.InDelta(t, 8, sum/float64(samples), 0.3)
+	})
+
+	t.Run("Wind speed changes gradually", func(t *testing.T) {
+		generator := NewWind(cfg, SystemClock, NewSource(1))
+		current := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		generator.clock = ClockFunc(func() time.Time { return current })
+
+		_, err := generator.GenerateMeasurement()
+		require.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			previousSpeed := generator.windSpeed()
+
+			current = current.Add(time.Second)
+			_, err = generator.GenerateMeasurement()
+			require.NoError(t, err)
+
+			assert.InDelta(t, previousSpeed, generator.windSpeed(), 1)
+		}
+	})
+
+	t.Run("Default parameters", func(t *testing.T) {
+		generator := NewWind(simulator.Configuration{Type: domain.AssetTypeWind, MaxPower: -2000}, SystemClock, NewSource(1))
+		assert.Equal(t, simulator.DefaultMeanWindSpeed, generator.parameters.MeanWindSpeed)
+		assert.Equal(t, simulator.DefaultCutOutSpeed, generator.parameters.CutOutSpeed)
+		assert.Equal(t, domain.EnergyType(domain.EnergyTypeProducer), generator.GetEnergyType())
+		assert.Equal(t, simulator.GeneratorWind, generator.GetGeneratorType())
+	})
+}
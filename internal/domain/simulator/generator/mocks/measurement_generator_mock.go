@@ -8,6 +8,8 @@ import (
 	measurements "asset-measurements-assignment/internal/domain/measurements"
 
 	mock "github.com/stretchr/testify/mock"
+
+	simulator "asset-measurements-assignment/internal/domain/simulator"
 )
 
 // MockMeasurementGenerator is an autogenerated mock type for the MeasurementGenerator type
@@ -125,6 +127,51 @@ func (_c *MockMeasurementGenerator_GetEnergyType_Call) RunAndReturn(run func() d
 	return _c
 }
 
+// GetGeneratorType provides a mock function with given fields:
+func (_m *MockMeasurementGenerator) GetGeneratorType() simulator.GeneratorType {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGeneratorType")
+	}
+
+	var r0 simulator.GeneratorType
+	if rf, ok := ret.Get(0).(func() simulator.GeneratorType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(simulator.GeneratorType)
+	}
+
+	return r0
+}
+
+// MockMeasurementGenerator_GetGeneratorType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGeneratorType'
+type MockMeasurementGenerator_GetGeneratorType_Call struct {
+	*mock.Call
+}
+
+// GetGeneratorType is a helper method to define mock.On call
+func (_e *MockMeasurementGenerator_Expecter) GetGeneratorType() *MockMeasurementGenerator_GetGeneratorType_Call {
+	return &MockMeasurementGenerator_GetGeneratorType_Call{Call: _e.mock.On("GetGeneratorType")}
+}
+
+func (_c *MockMeasurementGenerator_GetGeneratorType_Call) Run(run func()) *MockMeasurementGenerator_GetGeneratorType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMeasurementGenerator_GetGeneratorType_Call) Return(_a0 simulator.GeneratorType) *MockMeasurementGenerator_GetGeneratorType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMeasurementGenerator_GetGeneratorType_Call) RunAndReturn(run func() simulator.GeneratorType) *MockMeasurementGenerator_GetGeneratorType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMeasurementGenerator creates a new instance of MockMeasurementGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMeasurementGenerator(t interface {
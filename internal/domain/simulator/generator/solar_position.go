@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Global horizontal irradiance in W/m2 at which the panels produce their capacity
+	standardIrradiance = 1000.0
+
+	degreesToRadians = math.Pi / 180
+)
+
+// solarZenithCosine returns the cosine of the solar zenith angle at the given location and time, using the NOAA
+// approximation of the equation of time and the solar declination. It is negative when the sun is below the horizon.
+func solarZenithCosine(latitude, longitude float64, t time.Time) float64 {
+	t = t.UTC()
+
+	// Fractional year in radians
+	hour := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	daysInYear := 365.0
+	if isLeapYear(t.Year()) {
+		daysInYear = 366
+	}
+	gamma := 2 * math.Pi / daysInYear * (float64(t.YearDay()-1) + (hour-12)/24)
+
+	// Equation of time in minutes and solar declination in radians
+	equationOfTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	declination := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	// True solar time in minutes and the hour angle, which is zero at the solar noon
+	trueSolarTime := hour*60 + equationOfTime + 4*longitude
+	hourAngle := (trueSolarTime/4 - 180) * degreesToRadians
+
+	latitudeRadians := latitude * degreesToRadians
+	return math.Sin(latitudeRadians)*math.Sin(declination) +
+		math.Cos(latitudeRadians)*math.Cos(declination)*math.Cos(hourAngle)
+}
+
+// clearSkyIrradiance returns the global horizontal irradiance in W/m2 under a clear sky, using the Haurwitz model.
+// The irradiance is zero between the sunset and the sunrise.
+func clearSkyIrradiance(zenithCosine float64) float64 {
+	if zenithCosine <= 0 {
+		return 0
+	}
+
+	return 1098 * zenithCosine * math.Exp(-0.057/zenithCosine)
+}
+
+// cloudAttenuation returns the fraction of the clear sky irradiance which reaches the ground at the given cloud cover,
+// using the Kasten-Czeplak model.
+func cloudAttenuation(cloudCover float64) float64 {
+	return 1 - 0.75*math.Pow(clamp(cloudCover, 0, 1), 3.4)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
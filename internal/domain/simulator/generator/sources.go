@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Clock tells the time of the generated measurements.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function returning the time to a Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the clock of the measurements generated in real time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// NewSource creates the source of the random numbers of a generator. Generators with the same configuration, seed and
+// clock generate the same measurements. A zero seed creates a randomly seeded source.
+func NewSource(seed int64) rand.Source {
+	if seed == 0 {
+		return rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+
+	return rand.NewPCG(uint64(seed), uint64(seed))
+}
+
+// maxSeed keeps the assigned seeds exact in JSON clients, which decode numbers as floating point numbers.
+const maxSeed = 1 << 53
+
+// NewSeed returns a random non-zero seed.
+func NewSeed() int64 {
+	return rand.Int64N(maxSeed-1) + 1
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeededGenerators(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 6, 0, 0, 0, time.UTC)
+
+	variableSolarCfg := solarCfg
+	variableSolarCfg.Solar = &simulator.SolarParameters{CloudCover: 0.5, CloudVariability: 0.3}
+
+	shapedCfg := motorCfg
+	shapedCfg.LoadShape = &simulator.LoadShape{Daily: []float64{0.2, 0.8}, Noise: 0.1}
+
+	configurations := map[string]simulator.Configuration{
+		"Battery":    batteryCfg,
+		"Motor":      motorCfg,
+		"Heater":     heaterCfg,
+		"Solar":      variableSolarCfg,
+		"Wind":       windCfg,
+		"Load shape": shapedCfg,
+	}
+
+	// generate returns the JSON of the measurements generated every minute from the start
+	generate := func(t *testing.T, cfg simulator.Configuration, seed int64) []byte {
+		current := start
+		generator, err := GetGeneratorFromConfiguration(cfg, ClockFunc(func() time.Time { return current }), NewSource(seed))
+		require.NoError(t, err)
+
+		var generated []any
+		for i := 0; i < 500; i++ {
+			current = current.Add(time.Minute)
+
+			measurement, err := generator.GenerateMeasurement()
+			require.NoError(t, err)
+			generated = append(generated, measurement)
+		}
+
+		data, err := json.Marshal(generated)
+		require.NoError(t, err)
+		return data
+	}
+
+	for name, cfg := range configurations {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, generate(t, cfg, 42), generate(t, cfg, 42))
+
+			// The heater has no random behaviour
+			if cfg.Type != heaterCfg.Type {
+				assert.NotEqual(t, generate(t, cfg, 42), generate(t, cfg, 43))
+			}
+		})
+	}
+
+	t.Run("Zero seed is random", func(t *testing.T) {
+		assert.NotEqual(t, generate(t, windCfg, 0), generate(t, windCfg, 0))
+	})
+}
@@ -0,0 +1,121 @@
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
+)
+
+// SimulationMode selects how the measurements of an asset are produced.
+type SimulationMode string
+
+const (
+	// ModeModel generates the measurements with the model of the asset type. It is the default mode.
+	ModeModel = SimulationMode("model")
+
+	// ModeReplay plays back recorded measurements.
+	ModeReplay = SimulationMode("replay")
+)
+
+// ReplaySourceType selects where the recorded measurements of a replay come from.
+type ReplaySourceType string
+
+const (
+	// ReplaySourceRecording replays a recording uploaded to the simulator.
+	ReplaySourceRecording = ReplaySourceType("recording")
+
+	// ReplaySourceHistory replays the measurements stored by the asset service.
+	ReplaySourceHistory = ReplaySourceType("history")
+)
+
+// DefaultReplaySpeed plays the measurements back at the recorded speed.
+const DefaultReplaySpeed = 1.0
+
+// ReplayParameters describe the recorded measurements a replay plays back and how.
+type ReplayParameters struct {
+	// Source of the recorded measurements
+	Source ReplaySourceType `json:"source"`
+
+	// RecordingId is the recording to replay from the recording source
+	RecordingId string `json:"recordingId,omitempty"`
+
+	// AssetId is the asset whose history is replayed from the history source. Defaults to the simulated asset.
+	AssetId string `json:"assetId,omitempty"`
+
+	// From and To limit the replayed history
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+
+	// Speed of the replay relative to the recorded speed, e.g. 60 replays an hour in a minute
+	Speed float64 `json:"speed,omitempty"`
+
+	// Loop starts the replay over after the last measurement
+	Loop bool `json:"loop,omitempty"`
+
+	// ShiftToNow moves the replayed measurements to the time of the replay. Otherwise, they keep the recorded time.
+	ShiftToNow bool `json:"shiftToNow,omitempty"`
+}
+
+// WithDefaults returns the parameters with the zero values replaced with the defaults.
+func (p ReplayParameters) WithDefaults() ReplayParameters {
+	if p.Speed == 0 {
+		p.Speed = DefaultReplaySpeed
+	}
+
+	return p
+}
+
+func (p *ReplayParameters) Validate() error {
+	switch p.Source {
+	case ReplaySourceRecording:
+		if p.RecordingId == "" {
+			return errors.New("recordingId is required for the recording source")
+		}
+	case ReplaySourceHistory:
+		if p.From.IsZero() || p.To.IsZero() || !p.From.Before(p.To) {
+			return errors.New("from must be before to for the history source")
+		}
+	default:
+		return errors.New("invalid replay source")
+	}
+
+	if p.Speed < 0 {
+		return errors.New("speed must not be negative")
+	}
+
+	return nil
+}
+
+// Recording is a time series of measurements uploaded to the simulator, which can be replayed.
+type Recording struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Measurements ordered by time
+	Measurements []measurements.Measurement `json:"measurements"`
+}
+
+type RecordingRepository interface {
+	CreateRecording(ctx context.Context, recording Recording) (*Recording, error)
+	GetRecording(ctx context.Context, recordingId string) (*Recording, error)
+}
+
+type RecordingService interface {
+	// CreateRecording stores the parsed rows of a CSV or NDJSON time series as a recording. The recording is rejected
+	// with ErrInvalidRecording if any of the rows is invalid.
+	CreateRecording(ctx context.Context, name string, rows []measurements.ImportRow) (*Recording, error)
+	GetRecording(ctx context.Context, recordingId string) (*Recording, error)
+}
+
+// HistorySource reads the measurements stored by the asset service.
+type HistorySource interface {
+	GetAssetMeasurements(ctx context.Context, assetId string, timeRange measurements.TimeRange) ([]measurements.Measurement, error)
+}
+
+// ReplaySource loads the recorded measurements replayed for the asset.
+type ReplaySource interface {
+	LoadReplay(ctx context.Context, assetId string, parameters ReplayParameters) ([]measurements.Measurement, error)
+}
@@ -2,9 +2,15 @@ package simulator
 
 import "context"
 
-type Repository interface {
+// ConfigurationReader reads the simulator configurations, e.g. for the asset service to know the measurement
+// interval of the assets.
+type ConfigurationReader interface {
 	GetAssetConfiguration(ctx context.Context, assetId string) (*Configuration, error)
 	GetConfigurations(ctx context.Context) ([]Configuration, error)
+}
+
+type Repository interface {
+	ConfigurationReader
 	CreateConfiguration(ctx context.Context, configuration Configuration) (*Configuration, error)
 	DeleteConfiguration(ctx context.Context, configurationId string) error
 }
@@ -0,0 +1,227 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ScenarioAction is the action of a scenario event.
+type ScenarioAction string
+
+const (
+	// ActionSetPower sets the power for the duration of the event
+	ActionSetPower = ScenarioAction("setPower")
+
+	// ActionRamp changes the power linearly to the target power over the duration of the event, and holds it afterwards
+	ActionRamp = ScenarioAction("ramp")
+
+	// ActionFreeze repeats the measurement at the start of the event for the duration of the event
+	ActionFreeze = ScenarioAction("freeze")
+
+	// ActionOffline publishes no measurements for the duration of the event
+	ActionOffline = ScenarioAction("offline")
+
+	// ActionNoise adds random noise to the power for the duration of the event
+	ActionNoise = ScenarioAction("noise")
+
+	// ActionConfig changes the configuration of the base generator from the time of the event
+	ActionConfig = ScenarioAction("config")
+)
+
+// Scenario is a script of timed events played on top of the generators of the assets, e.g. a passing cloud over a
+// solar plant or a battery fault.
+type Scenario struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// AssetIds are the assets whose workers run the scenario
+	AssetIds []string `json:"assetIds"`
+
+	// Duration of the scenario, after which the assets return to their configurations. Without it, the assets stay in
+	// the state of the last events until their workers are recreated.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Loop starts the scenario over at the end, requires the duration
+	Loop bool `json:"loop,omitempty"`
+
+	// Events of the scenario
+	Events []ScenarioEvent `json:"events"`
+}
+
+// ScenarioEvent is an action at a time since the start of the scenario.
+type ScenarioEvent struct {
+	// At is the time of the event since the start of the scenario
+	At time.Duration `json:"at"`
+
+	// Action of the event
+	Action ScenarioAction `json:"action"`
+
+	// Duration of the event. Without it, the event lasts until the end of the scenario. The ramp requires it.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Power in W set by setPower and reached by the ramp
+	Power *float64 `json:"power,omitempty"`
+
+	// From is the power in W the ramp starts at. Defaults to the power at the start of the ramp.
+	From *float64 `json:"from,omitempty"`
+
+	// Noise is the standard deviation of the noise in W
+	Noise float64 `json:"noise,omitempty"`
+
+	// Config is applied over the configuration of the asset by the config event, with the JSON fields of the
+	// configuration, e.g. {"maxPower": 2000}. The measurement interval can't be changed.
+	Config map[string]any `json:"config,omitempty"`
+}
+
+// End returns the time since the start of the scenario at which the event ends, zero if it lasts until the end of the
+// scenario.
+func (e ScenarioEvent) End() time.Duration {
+	if e.Duration == 0 {
+		return 0
+	}
+
+	return e.At + e.Duration
+}
+
+func (e *ScenarioEvent) Validate() error {
+	if e.At < 0 {
+		return errors.New("at must not be negative")
+	}
+
+	if e.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+
+	switch e.Action {
+	case ActionSetPower:
+		if e.Power == nil {
+			return errors.New("power is required by setPower")
+		}
+	case ActionRamp:
+		if e.Power == nil {
+			return errors.New("power is required by ramp")
+		}
+
+		if e.Duration == 0 {
+			return errors.New("duration is required by ramp")
+		}
+	case ActionFreeze, ActionOffline:
+	case ActionNoise:
+		if e.Noise <= 0 {
+			return errors.New("noise must be positive")
+		}
+	case ActionConfig:
+		if len(e.Config) == 0 {
+			return errors.New("config is required by config")
+		}
+
+		// The runner of the worker keeps ticking at the interval it was created with
+		if _, ok := e.Config["measurementInterval"]; ok {
+			return errors.New("measurementInterval can't be changed by config")
+		}
+	default:
+		return errors.New("invalid scenario action")
+	}
+
+	return nil
+}
+
+func (s *Scenario) Validate() error {
+	if s.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(s.AssetIds) == 0 {
+		return errors.New("at least one assetId is required")
+	}
+
+	if slices.Contains(s.AssetIds, "") {
+		return errors.New("assetIds must not be empty")
+	}
+
+	if len(s.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+
+	if s.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+
+	if s.Loop && s.Duration == 0 {
+		return errors.New("duration is required by a looped scenario")
+	}
+
+	for i := range s.Events {
+		err := s.Events[i].Validate()
+		if err != nil {
+			return errors.Wrapf(err, "event %d", i+1)
+		}
+
+		if s.Duration > 0 && s.Events[i].At >= s.Duration {
+			return errors.Errorf("event %d: at must be before the end of the scenario", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ApplyConfig returns the configuration with the fields of the config event applied over it. The identity of the
+// configuration and the asset type can't be changed.
+func (c Configuration) ApplyConfig(config map[string]any) (Configuration, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return c, err
+	}
+
+	changed := c
+	err = json.Unmarshal(data, &changed)
+	if err != nil {
+		return c, errors.Wrap(err, "invalid config")
+	}
+
+	changed.Id = c.Id
+	changed.AssetId = c.AssetId
+	changed.Version = c.Version
+	changed.Type = c.Type
+
+	err = changed.Validate()
+	if err != nil {
+		return c, errors.Wrap(err, "invalid config")
+	}
+
+	return changed, nil
+}
+
+// ScenarioRun reports the workers a scenario was attached to.
+type ScenarioRun struct {
+	ScenarioId string    `json:"scenarioId"`
+	AssetIds   []string  `json:"assetIds"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+type ScenarioRepository interface {
+	CreateScenario(ctx context.Context, scenario Scenario) (*Scenario, error)
+	GetScenario(ctx context.Context, scenarioId string) (*Scenario, error)
+	GetScenarios(ctx context.Context) ([]Scenario, error)
+	UpdateScenario(ctx context.Context, scenario Scenario) (*Scenario, error)
+	DeleteScenario(ctx context.Context, scenarioId string) error
+}
+
+type ScenarioService interface {
+	CreateScenario(ctx context.Context, scenario Scenario) (*Scenario, error)
+	GetScenario(ctx context.Context, scenarioId string) (*Scenario, error)
+	GetScenarios(ctx context.Context) ([]Scenario, error)
+	UpdateScenario(ctx context.Context, scenario Scenario) (*Scenario, error)
+	DeleteScenario(ctx context.Context, scenarioId string) error
+
+	// RunScenario recreates the workers of the assets of the scenario with the scenario on top of their generators.
+	// The scenario runs until the workers are recreated, e.g. by a new configuration.
+	RunScenario(ctx context.Context, scenarioId string) (*ScenarioRun, error)
+}
@@ -0,0 +1,150 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenario_Validate(t *testing.T) {
+	power := 100.0
+	valid := func() Scenario {
+		return Scenario{
+			Name:     "Passing cloud",
+			AssetIds: []string{uuid.New().String()},
+			Duration: time.Hour,
+			Events: []ScenarioEvent{
+				{At: 5 * time.Minute, Action: ActionRamp, Power: &power, Duration: time.Minute},
+				{At: 10 * time.Minute, Action: ActionOffline, Duration: time.Minute},
+				{At: 20 * time.Minute, Action: ActionConfig, Config: map[string]any{"maxPower": 2000}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(scenario *Scenario)
+		err    bool
+	}{
+		{
+			name:   "Valid scenario",
+			change: func(scenario *Scenario) {},
+		},
+		{
+			name:   "Valid looped scenario",
+			change: func(scenario *Scenario) { scenario.Loop = true },
+		},
+		{
+			name:   "Missing name",
+			change: func(scenario *Scenario) { scenario.Name = "" },
+			err:    true,
+		},
+		{
+			name:   "No assets",
+			change: func(scenario *Scenario) { scenario.AssetIds = nil },
+			err:    true,
+		},
+		{
+			name:   "No events",
+			change: func(scenario *Scenario) { scenario.Events = nil },
+			err:    true,
+		},
+		{
+			name:   "Loop without duration",
+			change: func(scenario *Scenario) { scenario.Loop, scenario.Duration = true, 0 },
+			err:    true,
+		},
+		{
+			name:   "Event after the end",
+			change: func(scenario *Scenario) { scenario.Events[2].At = 2 * time.Hour },
+			err:    true,
+		},
+		{
+			name:   "Ramp without duration",
+			change: func(scenario *Scenario) { scenario.Events[0].Duration = 0 },
+			err:    true,
+		},
+		{
+			name:   "Set power without power",
+			change: func(scenario *Scenario) { scenario.Events[0] = ScenarioEvent{Action: ActionSetPower} },
+			err:    true,
+		},
+		{
+			name:   "Noise without noise",
+			change: func(scenario *Scenario) { scenario.Events[1].Action = ActionNoise },
+			err:    true,
+		},
+		{
+			name:   "Empty config",
+			change: func(scenario *Scenario) { scenario.Events[2].Config = nil },
+			err:    true,
+		},
+		{
+			name:   "Config changing the measurement interval",
+			change: func(scenario *Scenario) { scenario.Events[2].Config = map[string]any{"measurementInterval": 1000000000} },
+			err:    true,
+		},
+		{
+			name:   "Invalid action",
+			change: func(scenario *Scenario) { scenario.Events[1].Action = "explode" },
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := valid()
+			tt.change(&scenario)
+
+			err := scenario.Validate()
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfiguration_ApplyConfig(t *testing.T) {
+	cfg := Configuration{
+		Id:                  uuid.New().String(),
+		AssetId:             uuid.New().String(),
+		Version:             "1",
+		Type:                domain.AssetTypeSolar,
+		MeasurementInterval: time.Second,
+		MaxPower:            -5000,
+		Seed:                42,
+	}
+
+	t.Run("Change the configuration", func(t *testing.T) {
+		changed, err := cfg.ApplyConfig(map[string]any{
+			"maxPower": -2000,
+			"solar":    map[string]any{"cloudCover": 0.8},
+		})
+		require.NoError(t, err)
+
+		expected := cfg
+		expected.MaxPower = -2000
+		expected.Solar = &SolarParameters{CloudCover: 0.8}
+		assert.Equal(t, expected, changed)
+	})
+
+	t.Run("The asset can't be changed", func(t *testing.T) {
+		changed, err := cfg.ApplyConfig(map[string]any{"assetId": "other", "type": "wind", "id": "other"})
+		require.NoError(t, err)
+		assert.Equal(t, cfg, changed)
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		_, err := cfg.ApplyConfig(map[string]any{"maxPower": 1000})
+		assert.Error(t, err)
+
+		_, err = cfg.ApplyConfig(map[string]any{"maxPower": "high"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/simulator/asset_simulation"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+type backfillService struct {
+	obs          observability.Observability
+	repository   simulator.Repository
+	manager      *asset_simulation.AssetSimulatorManager
+	publisher    asset_simulation.Publisher
+	replaySource simulator.ReplaySource
+
+	// The backfills are kept in memory, as they are only tracked while the simulator runs
+	mu        sync.Mutex
+	backfills map[string]simulator.Backfill
+}
+
+// StartBackfill creates a worker with a virtual clock for every asset and runs them in the background.
+func (b *backfillService) StartBackfill(ctx context.Context, request simulator.BackfillRequest) (*simulator.Backfill, error) {
+	ctx, cancel, logger := b.obs.LogSpan(ctx, "backfill.service.StartBackfill")
+	defer cancel()
+	logger.Info("Starting backfill", zap.Any("request", request))
+
+	err := request.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", simulator.ErrBackfillValidation, err)
+	}
+
+	// Create all the workers first, so the backfill doesn't start if any of the assets can't be simulated
+	workers := make([]asset_simulation.Runner, 0, len(request.AssetIds))
+	for _, assetId := range request.AssetIds {
+		configuration, err := b.repository.GetAssetConfiguration(ctx, assetId)
+		if err != nil {
+			return nil, err
+		}
+
+		if configuration == nil {
+			return nil, simulator.ErrNoConfigForAsset
+		}
+
+		// Every asset has its own clock, so the assets with short intervals don't wait for the others
+		clock := asset_simulation.NewVirtualClock(request.From, request.To, request.Speed)
+		gen, err := newGenerator(ctx, b.replaySource, *configuration, clock)
+		if err != nil {
+			return nil, err
+		}
+
+		worker, err := asset_simulation.NewRunner(
+			b.obs,
+			assetId,
+			configuration.MeasurementInterval,
+			gen,
+			b.publisher,
+			clock,
+			b.manager.Metrics(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		workers = append(workers, worker)
+	}
+
+	backfill := simulator.Backfill{
+		Id:              uuid.New().String(),
+		BackfillRequest: request,
+		State:           simulator.BackfillRunning,
+		StartedAt:       time.Now(),
+	}
+	b.store(backfill)
+	started := backfill
+
+	// The backfill outlives the request, it is stopped by the shutdown of the manager
+	go func() {
+		err := b.manager.RunWorkers(context.Background(), workers...)
+
+		finishedAt := time.Now()
+		backfill.FinishedAt = &finishedAt
+		backfill.State = simulator.BackfillCompleted
+		if err != nil {
+			b.obs.Log().With(zap.Error(err)).Error("Backfill failed", zap.String("backfillId", backfill.Id))
+			backfill.State = simulator.BackfillFailed
+			backfill.Error = err.Error()
+		}
+
+		b.store(backfill)
+	}()
+
+	return &started, nil
+}
+
+func (b *backfillService) GetBackfill(ctx context.Context, backfillId string) (*simulator.Backfill, error) {
+	_, cancel, logger := b.obs.LogSpan(ctx, "backfill.service.GetBackfill")
+	defer cancel()
+	logger.Info("Getting backfill", zap.String("backfillId", backfillId))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	backfill, ok := b.backfills[backfillId]
+	if !ok {
+		return nil, simulator.ErrBackfillNotFound
+	}
+
+	return &backfill, nil
+}
+
+func (b *backfillService) store(backfill simulator.Backfill) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.backfills[backfill.Id] = backfill
+}
+
+func NewBackfillService(
+	obs observability.Observability,
+	repository simulator.Repository,
+	manager *asset_simulation.AssetSimulatorManager,
+	publisher asset_simulation.Publisher,
+	replaySource simulator.ReplaySource,
+) simulator.BackfillService {
+	return &backfillService{
+		obs:          obs,
+		repository:   repository,
+		manager:      manager,
+		publisher:    publisher,
+		replaySource: replaySource,
+		backfills:    map[string]simulator.Backfill{},
+	}
+}
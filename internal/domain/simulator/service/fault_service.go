@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/simulator/asset_simulation"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+type faultService struct {
+	obs     observability.Observability
+	manager *asset_simulation.AssetSimulatorManager
+}
+
+func (f *faultService) InjectFault(ctx context.Context, assetId string, fault simulator.Fault) (*simulator.ActiveFault, error) {
+	_, cancel, logger := f.obs.LogSpan(ctx, "fault.service.InjectFault")
+	defer cancel()
+	logger.Info("Injecting fault", zap.String("assetId", assetId), zap.Any("fault", fault))
+
+	err := fault.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", simulator.ErrFaultValidation, err)
+	}
+
+	// Faults are injected into running workers only, they would end before a worker is created
+	if _, ok := f.manager.GetWorker(assetId); !ok {
+		return nil, simulator.ErrWorkerNotFound
+	}
+
+	active := f.manager.Faults().Inject(assetId, fault)
+	return &active, nil
+}
+
+func (f *faultService) GetFaults(ctx context.Context, assetId string) ([]simulator.ActiveFault, error) {
+	_, cancel, logger := f.obs.LogSpan(ctx, "fault.service.GetFaults")
+	defer cancel()
+	logger.Info("Getting faults", zap.String("assetId", assetId))
+
+	return f.manager.Faults().Get(assetId), nil
+}
+
+func (f *faultService) ClearFaults(ctx context.Context, assetId string) error {
+	_, cancel, logger := f.obs.LogSpan(ctx, "fault.service.ClearFaults")
+	defer cancel()
+	logger.Info("Clearing faults", zap.String("assetId", assetId))
+
+	f.manager.Faults().Clear(assetId)
+	return nil
+}
+
+func NewFaultService(obs observability.Observability, manager *asset_simulation.AssetSimulatorManager) simulator.FaultService {
+	return &faultService{
+		obs:     obs,
+		manager: manager,
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/pkg/errors"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+type recordingService struct {
+	obs        observability.Observability
+	repository simulator.RecordingRepository
+}
+
+func (r *recordingService) CreateRecording(ctx context.Context, name string, rows []measurements.ImportRow) (*simulator.Recording, error) {
+	ctx, cancel, logger := r.obs.LogSpan(ctx, "recording.service.CreateRecording", zap.Int("rows", len(rows)))
+	defer cancel()
+	logger.Info("Creating recording", zap.String("name", name))
+
+	recording := simulator.Recording{Name: name}
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = validateRecordedMeasurement(row.Measurement)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", simulator.ErrInvalidRecording, row.Line, err)
+		}
+
+		recording.Measurements = append(recording.Measurements, row.Measurement)
+	}
+
+	if len(recording.Measurements) == 0 {
+		return nil, fmt.Errorf("%w: no measurements", simulator.ErrInvalidRecording)
+	}
+
+	slices.SortStableFunc(recording.Measurements, func(a, b measurements.Measurement) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return r.repository.CreateRecording(ctx, recording)
+}
+
+func (r *recordingService) GetRecording(ctx context.Context, recordingId string) (*simulator.Recording, error) {
+	ctx, cancel, logger := r.obs.LogSpan(ctx, "recording.service.GetRecording")
+	defer cancel()
+	logger.Info("Getting recording", zap.String("recordingId", recordingId))
+
+	return r.repository.GetRecording(ctx, recordingId)
+}
+
+func validateRecordedMeasurement(measurement measurements.Measurement) error {
+	if measurement.Time.IsZero() {
+		return errors.New("measurement time is required")
+	}
+
+	return measurement.Validate()
+}
+
+func NewRecordingService(obs observability.Observability, repository simulator.RecordingRepository) simulator.RecordingService {
+	return &recordingService{
+		obs:        obs,
+		repository: repository,
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+type replaySource struct {
+	recordings simulator.RecordingRepository
+	history    simulator.HistorySource
+}
+
+// LoadReplay loads the recording or the history of the asset service to replay for the asset.
+func (r *replaySource) LoadReplay(ctx context.Context, assetId string, parameters simulator.ReplayParameters) ([]measurements.Measurement, error) {
+	var series []measurements.Measurement
+
+	switch parameters.Source {
+	case simulator.ReplaySourceRecording:
+		recording, err := r.recordings.GetRecording(ctx, parameters.RecordingId)
+		if err != nil {
+			return nil, err
+		}
+		series = recording.Measurements
+
+	case simulator.ReplaySourceHistory:
+		if r.history == nil {
+			return nil, simulator.ErrHistoryNotAvailable
+		}
+
+		// Replay the history of the simulated asset by default
+		if parameters.AssetId != "" {
+			assetId = parameters.AssetId
+		}
+
+		from, to := parameters.From, parameters.To
+		history, err := r.history.GetAssetMeasurements(ctx, assetId, measurements.TimeRange{From: &from, To: &to})
+		if err != nil {
+			return nil, err
+		}
+		series = history
+
+	default:
+		return nil, simulator.ErrConfigValidation
+	}
+
+	if len(series) == 0 {
+		return nil, simulator.ErrEmptyReplay
+	}
+
+	return series, nil
+}
+
+// NewReplaySource creates a replay source of the stored recordings and the history of the asset service.
+// The history is optional, without it only the recordings can be replayed.
+func NewReplaySource(recordings simulator.RecordingRepository, history simulator.HistorySource) simulator.ReplaySource {
+	return &replaySource{
+		recordings: recordings,
+		history:    history,
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/domain/simulator/generator"
+	"asset-measurements-assignment/internal/simulator/asset_simulation"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+type scenarioService struct {
+	obs                     observability.Observability
+	repository              simulator.ScenarioRepository
+	configurationRepository simulator.Repository
+	manager                 *asset_simulation.AssetSimulatorManager
+	publisher               asset_simulation.Publisher
+	replaySource            simulator.ReplaySource
+	// Clock of the workers and their generators
+	clock asset_simulation.Clock
+}
+
+func (s *scenarioService) CreateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.CreateScenario")
+	defer cancel()
+	logger.Info("Creating scenario", zap.String("name", scenario.Name))
+
+	err := scenario.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", simulator.ErrScenarioValidation, err)
+	}
+
+	return s.repository.CreateScenario(ctx, scenario)
+}
+
+func (s *scenarioService) GetScenario(ctx context.Context, scenarioId string) (*simulator.Scenario, error) {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.GetScenario")
+	defer cancel()
+	logger.Info("Getting scenario", zap.String("scenarioId", scenarioId))
+
+	return s.repository.GetScenario(ctx, scenarioId)
+}
+
+func (s *scenarioService) GetScenarios(ctx context.Context) ([]simulator.Scenario, error) {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.GetScenarios")
+	defer cancel()
+	logger.Info("Getting scenarios")
+
+	return s.repository.GetScenarios(ctx)
+}
+
+// UpdateScenario replaces the scenario. The workers already running the scenario keep the previous version.
+func (s *scenarioService) UpdateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.UpdateScenario")
+	defer cancel()
+	logger.Info("Updating scenario", zap.String("scenarioId", scenario.Id))
+
+	err := scenario.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", simulator.ErrScenarioValidation, err)
+	}
+
+	return s.repository.UpdateScenario(ctx, scenario)
+}
+
+func (s *scenarioService) DeleteScenario(ctx context.Context, scenarioId string) error {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.DeleteScenario")
+	defer cancel()
+	logger.Info("Deleting scenario", zap.String("scenarioId", scenarioId))
+
+	return s.repository.DeleteScenario(ctx, scenarioId)
+}
+
+func (s *scenarioService) RunScenario(ctx context.Context, scenarioId string) (*simulator.ScenarioRun, error) {
+	ctx, cancel, logger := s.obs.LogSpan(ctx, "scenario.service.RunScenario")
+	defer cancel()
+	logger.Info("Running scenario", zap.String("scenarioId", scenarioId))
+
+	scenario, err := s.repository.GetScenario(ctx, scenarioId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create all the workers first, so the scenario doesn't start if any of the assets can't run it
+	workers := make([]asset_simulation.Runner, 0, len(scenario.AssetIds))
+	for _, assetId := range scenario.AssetIds {
+		configuration, err := s.configurationRepository.GetAssetConfiguration(ctx, assetId)
+		if err != nil {
+			return nil, err
+		}
+
+		if configuration == nil {
+			return nil, simulator.ErrNoConfigForAsset
+		}
+
+		gen, err := s.newScenarioGenerator(ctx, *scenario, *configuration)
+		if err != nil {
+			return nil, err
+		}
+
+		worker, err := asset_simulation.NewRunner(
+			s.obs,
+			assetId,
+			configuration.MeasurementInterval,
+			s.manager.Faults().WrapGenerator(assetId, s.clock, gen),
+			s.publisher,
+			s.clock,
+			s.manager.Metrics(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		workers = append(workers, worker)
+	}
+
+	// Replace the workers of the assets
+	for _, worker := range workers {
+		_ = s.manager.RemoveWorker(worker.GetId())
+
+		err = s.manager.AddAndStartWorker(context.Background(), worker)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("Failed to add and start worker", zap.String("assetId", worker.GetId()))
+		}
+	}
+
+	return &simulator.ScenarioRun{
+		ScenarioId: scenario.Id,
+		AssetIds:   scenario.AssetIds,
+		StartedAt:  s.clock.Now(),
+	}, nil
+}
+
+// newScenarioGenerator creates the generator of the configuration with the scenario on top of it. The config events
+// are applied over the configuration.
+func (s *scenarioService) newScenarioGenerator(ctx context.Context, scenario simulator.Scenario, configuration simulator.Configuration) (generator.MeasurementGenerator, error) {
+	base, err := newGenerator(ctx, s.replaySource, configuration, s.clock)
+	if err != nil {
+		return nil, err
+	}
+
+	configure := func(config map[string]any) (generator.MeasurementGenerator, error) {
+		changed, err := configuration.ApplyConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", simulator.ErrScenarioValidation, err)
+		}
+
+		return newGenerator(ctx, s.replaySource, changed, s.clock)
+	}
+
+	return generator.NewScenario(base, scenario, configure, s.clock, generator.NewSource(configuration.Seed))
+}
+
+func NewScenarioService(
+	obs observability.Observability,
+	repository simulator.ScenarioRepository,
+	configurationRepository simulator.Repository,
+	manager *asset_simulation.AssetSimulatorManager,
+	publisher asset_simulation.Publisher,
+	replaySource simulator.ReplaySource,
+) simulator.ScenarioService {
+	return &scenarioService{
+		obs:                     obs,
+		repository:              repository,
+		configurationRepository: configurationRepository,
+		manager:                 manager,
+		publisher:               publisher,
+		replaySource:            replaySource,
+		clock:                   asset_simulation.SystemClock,
+	}
+}
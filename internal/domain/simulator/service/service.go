@@ -11,10 +11,13 @@ import (
 )
 
 type configService struct {
-	obs        observability.Observability
-	repository simulator.Repository
-	manager    *asset_simulation.AssetSimulatorManager
-	publisher  asset_simulation.Publisher
+	obs          observability.Observability
+	repository   simulator.Repository
+	manager      *asset_simulation.AssetSimulatorManager
+	publisher    asset_simulation.Publisher
+	replaySource simulator.ReplaySource
+	// Clock of the workers and their generators
+	clock asset_simulation.Clock
 }
 
 func (c *configService) StartWorkersFromDatabaseConfigurations(ctx context.Context) error {
@@ -30,7 +33,7 @@ func (c *configService) StartWorkersFromDatabaseConfigurations(ctx context.Conte
 
 	// Create workers from configurations
 	for _, config := range configs {
-		generator, err := generator.GetGeneratorFromConfiguration(config)
+		generator, err := c.newGenerator(ctx, config)
 		if err != nil {
 			c.obs.Log().Error("Failed to create generator", zap.Error(err))
 			continue
@@ -40,8 +43,10 @@ func (c *configService) StartWorkersFromDatabaseConfigurations(ctx context.Conte
 			c.obs,
 			config.AssetId,
 			config.MeasurementInterval,
-			generator,
+			c.manager.Faults().WrapGenerator(config.AssetId, c.clock, generator),
 			c.publisher,
+			c.clock,
+			c.manager.Metrics(),
 		)
 		if err != nil {
 			c.obs.Log().Error("Failed to create worker", zap.Error(err))
@@ -81,13 +86,24 @@ func (c *configService) CreateConfiguration(ctx context.Context, configuration s
 		return nil, simulator.ErrConfigValidation
 	}
 
+	// Every simulation can be reproduced with the seed of its configuration
+	if configuration.Seed == 0 {
+		configuration.Seed = generator.NewSeed()
+	}
+
+	// Create the generator first, so the configuration is not stored if the replay can't be loaded
+	gen, err := c.newGenerator(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+
 	config, err := c.repository.CreateConfiguration(ctx, configuration)
 	if err != nil {
 		return nil, err
 	}
 
 	// Recreate worker with new configuration after new configuration is created
-	err2 := c.recreateWorker(configuration)
+	err2 := c.recreateWorker(configuration, gen)
 	if err2 != nil {
 		logger.With(zap.Error(err2)).Error("Failed to recreate worker")
 	}
@@ -95,21 +111,42 @@ func (c *configService) CreateConfiguration(ctx context.Context, configuration s
 	return config, nil
 }
 
-// recreateWorker removes the worker from the manager and creates a new worker with the new configuration
-func (c *configService) recreateWorker(configuration simulator.Configuration) error {
-	_ = c.manager.RemoveWorker(configuration.AssetId)
+// newGenerator creates the generator of the configuration on the clock of the workers.
+func (c *configService) newGenerator(ctx context.Context, configuration simulator.Configuration) (generator.MeasurementGenerator, error) {
+	return newGenerator(ctx, c.replaySource, configuration, c.clock)
+}
+
+// newGenerator creates the generator of the configuration on the clock, seeded with the seed of the configuration.
+// Replays load the recorded measurements first.
+func newGenerator(ctx context.Context, replaySource simulator.ReplaySource, configuration simulator.Configuration, clock generator.Clock) (generator.MeasurementGenerator, error) {
+	if configuration.Mode != simulator.ModeReplay {
+		return generator.GetGeneratorFromConfiguration(configuration, clock, generator.NewSource(configuration.Seed))
+	}
 
-	gen, err := generator.GetGeneratorFromConfiguration(configuration)
+	if configuration.Replay == nil {
+		return nil, simulator.ErrConfigValidation
+	}
+
+	series, err := replaySource.LoadReplay(ctx, configuration.AssetId, *configuration.Replay)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return generator.NewReplay(configuration, clock, series), nil
+}
+
+// recreateWorker removes the worker from the manager and creates a new worker with the new configuration
+func (c *configService) recreateWorker(configuration simulator.Configuration, gen generator.MeasurementGenerator) error {
+	_ = c.manager.RemoveWorker(configuration.AssetId)
+
 	worker, err := asset_simulation.NewRunner(
 		c.obs,
 		configuration.AssetId,
 		configuration.MeasurementInterval,
-		gen,
+		c.manager.Faults().WrapGenerator(configuration.AssetId, c.clock, gen),
 		c.publisher,
+		c.clock,
+		c.manager.Metrics(),
 	)
 	if err != nil {
 		c.obs.Log().Error("Failed to create worker", zap.Error(err))
@@ -151,7 +188,14 @@ func (c *configService) DeleteConfiguration(ctx context.Context, assetId string,
 	}
 
 	// Recreate a worker with the previous configuration
-	workerErr := c.recreateWorker(*configuration)
+	gen, err := c.newGenerator(ctx, *configuration)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("Failed to create generator")
+		_ = c.manager.RemoveWorker(assetId)
+		return nil
+	}
+
+	workerErr := c.recreateWorker(*configuration, gen)
 	if workerErr != nil {
 		logger.With(zap.Error(workerErr)).Error("Failed to recreate worker")
 	}
@@ -159,11 +203,19 @@ func (c *configService) DeleteConfiguration(ctx context.Context, assetId string,
 	return nil
 }
 
-func NewConfigService(obs observability.Observability, repository simulator.Repository, manager *asset_simulation.AssetSimulatorManager, publisher asset_simulation.Publisher) simulator.ConfigService {
+func NewConfigService(
+	obs observability.Observability,
+	repository simulator.Repository,
+	manager *asset_simulation.AssetSimulatorManager,
+	publisher asset_simulation.Publisher,
+	replaySource simulator.ReplaySource,
+) simulator.ConfigService {
 	return &configService{
-		repository: repository,
-		obs:        obs,
-		manager:    manager,
-		publisher:  publisher,
+		repository:   repository,
+		obs:          obs,
+		manager:      manager,
+		publisher:    publisher,
+		replaySource: replaySource,
+		clock:        asset_simulation.SystemClock,
 	}
 }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/simulator/asset_simulation"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+type workerService struct {
+	obs     observability.Observability
+	manager *asset_simulation.AssetSimulatorManager
+}
+
+func (w *workerService) GetWorkers(ctx context.Context) ([]simulator.Worker, error) {
+	_, cancel, logger := w.obs.LogSpan(ctx, "worker.service.GetWorkers")
+	defer cancel()
+	logger.Info("Getting workers")
+
+	workers := []simulator.Worker{}
+	for _, worker := range w.manager.GetWorkers() {
+		workers = append(workers, worker.Status())
+	}
+
+	slices.SortFunc(workers, func(a, b simulator.Worker) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return workers, nil
+}
+
+func (w *workerService) PauseWorker(ctx context.Context, workerId string) (*simulator.Worker, error) {
+	_, cancel, logger := w.obs.LogSpan(ctx, "worker.service.PauseWorker")
+	defer cancel()
+	logger.Info("Pausing worker", zap.String("workerId", workerId))
+
+	return w.control(workerId, asset_simulation.Runner.Pause)
+}
+
+func (w *workerService) ResumeWorker(ctx context.Context, workerId string) (*simulator.Worker, error) {
+	_, cancel, logger := w.obs.LogSpan(ctx, "worker.service.ResumeWorker")
+	defer cancel()
+	logger.Info("Resuming worker", zap.String("workerId", workerId))
+
+	return w.control(workerId, asset_simulation.Runner.Resume)
+}
+
+func (w *workerService) RestartWorker(ctx context.Context, workerId string) (*simulator.Worker, error) {
+	_, cancel, logger := w.obs.LogSpan(ctx, "worker.service.RestartWorker")
+	defer cancel()
+	logger.Info("Restarting worker", zap.String("workerId", workerId))
+
+	err := w.manager.RestartWorker(workerId)
+	if err != nil {
+		return nil, toWorkerError(err)
+	}
+
+	return w.status(workerId)
+}
+
+func (w *workerService) Tick(ctx context.Context, workerId string) (*measurements.Measurement, error) {
+	ctx, cancel, logger := w.obs.LogSpan(ctx, "worker.service.Tick")
+	defer cancel()
+	logger.Info("Ticking worker", zap.String("workerId", workerId))
+
+	worker, ok := w.manager.GetWorker(workerId)
+	if !ok {
+		return nil, simulator.ErrWorkerNotFound
+	}
+
+	return worker.Tick(ctx)
+}
+
+// control changes the state of the worker and returns its status.
+func (w *workerService) control(workerId string, change func(asset_simulation.Runner) error) (*simulator.Worker, error) {
+	worker, ok := w.manager.GetWorker(workerId)
+	if !ok {
+		return nil, simulator.ErrWorkerNotFound
+	}
+
+	err := change(worker)
+	if err != nil {
+		return nil, toWorkerError(err)
+	}
+
+	status := worker.Status()
+	return &status, nil
+}
+
+func (w *workerService) status(workerId string) (*simulator.Worker, error) {
+	worker, ok := w.manager.GetWorker(workerId)
+	if !ok {
+		return nil, simulator.ErrWorkerNotFound
+	}
+
+	status := worker.Status()
+	return &status, nil
+}
+
+// toWorkerError maps the errors of the manager to the errors of the domain.
+func toWorkerError(err error) error {
+	switch {
+	case errors.Is(err, asset_simulation.ErrWorkerDoesntExist):
+		return simulator.ErrWorkerNotFound
+	case errors.Is(err, asset_simulation.ErrWorkerNotRunning):
+		return simulator.ErrWorkerNotRunning
+	default:
+		return err
+	}
+}
+
+func NewWorkerService(obs observability.Observability, manager *asset_simulation.AssetSimulatorManager) simulator.WorkerService {
+	return &workerService{
+		obs:     obs,
+		manager: manager,
+	}
+}
@@ -0,0 +1,70 @@
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+)
+
+// WorkerState is the state of the worker of an asset.
+type WorkerState string
+
+const (
+	// WorkerRunning generates and publishes a measurement at each interval
+	WorkerRunning = WorkerState("running")
+
+	// WorkerPaused keeps ticking, but doesn't generate measurements until it is resumed
+	WorkerPaused = WorkerState("paused")
+
+	// WorkerStopped doesn't tick, e.g. it wasn't started yet or it was stopped
+	WorkerStopped = WorkerState("stopped")
+)
+
+// GeneratorType is the kind of the generator of a worker, i.e. the model simulating the asset.
+type GeneratorType string
+
+const (
+	GeneratorSolar     = GeneratorType("solar")
+	GeneratorWind      = GeneratorType("wind")
+	GeneratorHeater    = GeneratorType("heater")
+	GeneratorMotor     = GeneratorType("motor")
+	GeneratorLoadShape = GeneratorType("loadShape")
+	GeneratorBattery   = GeneratorType("battery")
+
+	// GeneratorProducer and GeneratorConsumer are the generic models of the asset types without a dedicated model
+	GeneratorProducer = GeneratorType("producer")
+	GeneratorConsumer = GeneratorType("consumer")
+
+	// GeneratorReplay replays the recorded measurements of an asset
+	GeneratorReplay = GeneratorType("replay")
+
+	// GeneratorScenario runs a scenario on top of the model of the asset
+	GeneratorScenario = GeneratorType("scenario")
+)
+
+// Worker is the status of the worker simulating an asset.
+type Worker struct {
+	Id            string        `json:"id"`
+	State         WorkerState   `json:"state"`
+	Interval      time.Duration `json:"interval"`
+	GeneratorType GeneratorType `json:"generatorType"`
+
+	// LastMeasurement is the last measurement the worker generated
+	LastMeasurement *measurements.Measurement `json:"lastMeasurement,omitempty"`
+
+	// PublishErrors is the number of measurements the worker failed to publish
+	PublishErrors int64 `json:"publishErrors"`
+}
+
+type WorkerService interface {
+	GetWorkers(ctx context.Context) ([]Worker, error)
+	// PauseWorker stops the worker from generating measurements, without stopping it
+	PauseWorker(ctx context.Context, workerId string) (*Worker, error)
+	ResumeWorker(ctx context.Context, workerId string) (*Worker, error)
+	// RestartWorker stops the worker and starts it again, resumed
+	RestartWorker(ctx context.Context, workerId string) (*Worker, error)
+	// Tick generates and publishes one measurement on demand, regardless of the state of the worker. No measurement
+	// is returned if the generator didn't produce one, e.g. the asset is offline.
+	Tick(ctx context.Context, workerId string) (*measurements.Measurement, error)
+}
@@ -0,0 +1,90 @@
+package embedded
+
+import (
+	"context"
+
+	asset_service "asset-measurements-assignment/internal/asset-service"
+	"asset-measurements-assignment/internal/asset-service/inprocess"
+	assetMemory "asset-measurements-assignment/internal/asset-service/memory"
+	"asset-measurements-assignment/internal/domain/measurements/service"
+	"asset-measurements-assignment/internal/pkg/infrastructure/bus"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"asset-measurements-assignment/internal/simulator"
+	assetSimulation "asset-measurements-assignment/internal/simulator/asset_simulation"
+	simulatorInprocess "asset-measurements-assignment/internal/simulator/inprocess"
+	simulatorMemory "asset-measurements-assignment/internal/simulator/memory"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+// Default HTTP addresses of the services in the embedded mode, as both services run in the same process.
+const (
+	DefaultAssetServiceAddress = ":8080"
+	DefaultSimulatorAddress    = ":8081"
+)
+
+type Config struct {
+	AssetService asset_service.Config
+	Simulator    simulator.Config
+}
+
+// Run runs the asset service and the simulator in the same process, without any external infrastructure.
+// Assets, simulator configurations and measurements are kept in memory, and the measurements are passed from the
+// simulator to the asset service over an in-process bus. Everything is lost when the process stops.
+func Run(ctx context.Context, cfg Config) error {
+	if cfg.AssetService.Http.Address == "" {
+		cfg.AssetService.Http.Address = DefaultAssetServiceAddress
+	}
+
+	if cfg.Simulator.Http.Address == "" {
+		cfg.Simulator.Http.Address = DefaultSimulatorAddress
+	}
+
+	// The repositories are not traced
+	obs := observability.NewNoopObservability()
+	measurementBus := bus.New()
+	defer measurementBus.Close()
+
+	// The simulator configurations are shared, as the asset service reads the measurement intervals
+	configurationRepository := simulatorMemory.NewSimulatorConfigurationRepository(obs)
+	// The simulator replays the history of the asset service from the shared repository
+	measurementsRepository := assetMemory.NewMeasurementsRepository(obs)
+
+	assetInfrastructure := asset_service.Infrastructure{
+		AssetRepository:         assetMemory.NewAssetRepository(obs),
+		MeasurementsRepository:  measurementsRepository,
+		ConfigurationRepository: configurationRepository,
+		StartConsumer: func(ctx context.Context, obs observability.Observability, consumerService service.ConsumerService) error {
+			return inprocess.NewHandler(obs, measurementBus, consumerService).Start(ctx)
+		},
+	}
+
+	simulatorInfrastructure := simulator.Infrastructure{
+		ConfigurationRepository: configurationRepository,
+		RecordingRepository:     simulatorMemory.NewRecordingRepository(obs),
+		ScenarioRepository:      simulatorMemory.NewScenarioRepository(obs),
+		History:                 measurementsRepository,
+		NewPublisher: func(obs observability.Observability, encoding messages.Encoding, producer messages.Producer) (assetSimulation.Publisher, error) {
+			return simulatorInprocess.NewMeasurementPublisher(obs, measurementBus, encoding, producer), nil
+		},
+	}
+
+	// Stop both services if either of them fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- asset_service.RunWithInfrastructure(ctx, cfg.AssetService, assetInfrastructure)
+	}()
+	go func() {
+		errs <- simulator.RunWithInfrastructure(ctx, cfg.Simulator, simulatorInfrastructure)
+	}()
+
+	err := <-errs
+	cancel()
+	if secondErr := <-errs; err == nil {
+		err = secondErr
+	}
+
+	return err
+}
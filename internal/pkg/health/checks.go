@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/pkg/infrastructure/rabbitmq"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"gorm.io/gorm"
+)
+
+// PostgresCheck pings the Postgres database.
+func PostgresCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "postgres",
+		Check: func(ctx context.Context) (any, error) {
+			sqlDb, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, sqlDb.PingContext(ctx)
+		},
+	}
+}
+
+// MongoCheck pings the primary of the MongoDB deployment.
+func MongoCheck(client *mongo.Client) Check {
+	return Check{
+		Name: "mongo",
+		Check: func(ctx context.Context) (any, error) {
+			return nil, client.Ping(ctx, readpref.Primary())
+		},
+	}
+}
+
+// RabbitMQCheck reports the state of the shared RabbitMQ connection, or of the channel of a publisher or a consumer on
+// it, as tracked while they reconnect in the background.
+func RabbitMQCheck(state *rabbitmq.State) Check {
+	return Check{
+		Name: state.Name(),
+		Check: func(ctx context.Context) (any, error) {
+			since, err := state.Status()
+			if err != nil {
+				return RabbitMQStatus{DownSince: since}, err
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+// RabbitMQStatus is reported by the RabbitMQ check while the connection or the channel is down.
+type RabbitMQStatus struct {
+	DownSince time.Time `json:"downSince"`
+}
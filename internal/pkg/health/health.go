@@ -0,0 +1,172 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkTimeout limits the duration of a single check, so the probes respond before the Kubernetes probe timeout.
+const checkTimeout = 3 * time.Second
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Check checks a dependency of the service.
+type Check struct {
+	Name string
+
+	// Check returns the details of the dependency, if any, and an error if the dependency is not available
+	Check func(ctx context.Context) (any, error)
+}
+
+// CheckResult is the status of a single dependency.
+type CheckResult struct {
+	Status  Status `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// Report is the response of the health and readiness endpoints.
+// swagger:model
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Handler serves the liveness (/healthz) and readiness (/readyz) probes. The liveness probe only reports that the
+// process serves requests, so an outage of a dependency doesn't restart the service. The readiness probe runs the
+// dependency checks and additionally fails until the service is marked as ready and after it starts shutting down.
+type Handler struct {
+	mu     sync.RWMutex
+	checks []Check
+	ready  atomic.Bool
+}
+
+func NewHandler(checks ...Check) *Handler {
+	h := &Handler{}
+	h.AddChecks(checks...)
+	return h
+}
+
+// AddChecks adds the checks. A check replaces an existing check with the same name, so shared dependencies are
+// checked once.
+func (h *Handler) AddChecks(checks ...Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, check := range checks {
+		replaced := false
+		for i := range h.checks {
+			if h.checks[i].Name == check.Name {
+				h.checks[i] = check
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			h.checks = append(h.checks, check)
+		}
+	}
+}
+
+// SetReady marks the service as ready to receive traffic, or not.
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Check runs the checks concurrently and reports the status of each dependency.
+func (h *Handler) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := append([]Check(nil), h.checks...)
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	details, err := check.Check(ctx)
+	if err != nil {
+		return CheckResult{Status: StatusDown, Error: err.Error(), Details: details}
+	}
+
+	return CheckResult{Status: StatusUp, Details: details}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/healthz", h.Healthz)
+	router.GET("/readyz", h.Readyz)
+}
+
+// swagger:route GET /healthz health healthz
+// Liveness probe of the process, without the dependencies.
+// ---
+// responses:
+//
+//	200: Report
+func (h *Handler) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, Report{
+		Status: StatusUp,
+		Checks: map[string]CheckResult{},
+	})
+}
+
+// swagger:route GET /readyz health readyz
+// Readiness probe with the status of the dependencies. Fails while the service is starting or shutting down.
+// ---
+// responses:
+//
+//	200: Report
+//	503: Report
+func (h *Handler) Readyz(ctx *gin.Context) {
+	report := h.Check(ctx.Request.Context())
+
+	ready := CheckResult{Status: StatusUp}
+	if !h.ready.Load() {
+		ready = CheckResult{Status: StatusDown, Error: "service is starting or shutting down"}
+		report.Status = StatusDown
+	}
+	report.Checks["ready"] = ready
+
+	ctx.JSON(statusCode(report), report)
+}
+
+func statusCode(report Report) int {
+	if report.Status == StatusDown {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func check(name string, details any, err error) Check {
+	return Check{
+		Name: name,
+		Check: func(ctx context.Context) (any, error) {
+			return details, err
+		},
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		checks         []Check
+		ready          bool
+		path           string
+		expectedCode   int
+		expectedReport Report
+	}{
+		{
+			name:         "Alive",
+			checks:       []Check{check("postgres", nil, nil)},
+			path:         "/healthz",
+			expectedCode: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusUp,
+				Checks: map[string]CheckResult{},
+			},
+		},
+		{
+			name:         "Alive with dependency down",
+			checks:       []Check{check("rabbitmq", nil, errors.New("connection refused"))},
+			path:         "/healthz",
+			expectedCode: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusUp,
+				Checks: map[string]CheckResult{},
+			},
+		},
+		{
+			name:         "Ready with details",
+			checks:       []Check{check("postgres", nil, nil), check("workers", map[string]int{"running": 1}, nil)},
+			ready:        true,
+			path:         "/readyz",
+			expectedCode: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusUp,
+				Checks: map[string]CheckResult{
+					"postgres": {Status: StatusUp},
+					"workers":  {Status: StatusUp, Details: map[string]any{"running": float64(1)}},
+					"ready":    {Status: StatusUp},
+				},
+			},
+		},
+		{
+			name:         "Dependency down",
+			checks:       []Check{check("postgres", nil, nil), check("rabbitmq", nil, errors.New("connection refused"))},
+			ready:        true,
+			path:         "/readyz",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedReport: Report{
+				Status: StatusDown,
+				Checks: map[string]CheckResult{
+					"postgres": {Status: StatusUp},
+					"rabbitmq": {Status: StatusDown, Error: "connection refused"},
+					"ready":    {Status: StatusUp},
+				},
+			},
+		},
+		{
+			name:         "Ready",
+			checks:       []Check{check("postgres", nil, nil)},
+			ready:        true,
+			path:         "/readyz",
+			expectedCode: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusUp,
+				Checks: map[string]CheckResult{
+					"postgres": {Status: StatusUp},
+					"ready":    {Status: StatusUp},
+				},
+			},
+		},
+		{
+			name:         "Not ready",
+			checks:       []Check{check("postgres", nil, nil)},
+			path:         "/readyz",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedReport: Report{
+				Status: StatusDown,
+				Checks: map[string]CheckResult{
+					"postgres": {Status: StatusUp},
+					"ready":    {Status: StatusDown, Error: "service is starting or shutting down"},
+				},
+			},
+		},
+		{
+			name:         "Check replaced by name",
+			checks:       []Check{check("postgres", nil, errors.New("down")), check("postgres", nil, nil)},
+			ready:        true,
+			path:         "/readyz",
+			expectedCode: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusUp,
+				Checks: map[string]CheckResult{
+					"postgres": {Status: StatusUp},
+					"ready":    {Status: StatusUp},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.checks...)
+			handler.SetReady(tt.ready)
+
+			router := gin.New()
+			handler.RegisterRoutes(router)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+
+			var report Report
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedReport, report)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package bus
+
+import (
+	"context"
+	"sync"
+
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/pkg/errors"
+)
+
+// subscriptionBuffer is the number of messages buffered per subscriber before publishing blocks.
+const subscriptionBuffer = 1000
+
+var ErrClosed = errors.New("bus is closed")
+
+// Handler handles a message delivered to a subscriber. Errors are reported to the error handler of the subscription,
+// the message is not redelivered.
+type Handler func(ctx context.Context, message messages.Message) error
+
+// Bus is an in-process message bus, used in place of RabbitMQ when the services run in the same process.
+// Messages are routed by the routing key to all of its subscribers, and delivered to each subscriber in order.
+type Bus struct {
+	mu          sync.RWMutex
+	closed      bool
+	subscribers map[string][]chan messages.Message
+	wg          sync.WaitGroup
+}
+
+func New() *Bus {
+	return &Bus{
+		subscribers: map[string][]chan messages.Message{},
+	}
+}
+
+// Publish delivers the message to the subscribers of the routing key. If a subscriber falls behind,
+// Publish blocks until the message is buffered or the context is done.
+func (b *Bus) Publish(ctx context.Context, routingKey string, message messages.Message) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	for _, subscriber := range b.subscribers[routingKey] {
+		select {
+		case subscriber <- message:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe starts delivering the messages with the routing key to the handler, until the bus is closed.
+// Handler errors are passed to onError, if set.
+func (b *Bus) Subscribe(ctx context.Context, routingKey string, handler Handler, onError func(error)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	subscription := make(chan messages.Message, subscriptionBuffer)
+	b.subscribers[routingKey] = append(b.subscribers[routingKey], subscription)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		for message := range subscription {
+			err := handler(ctx, message)
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stops accepting messages and waits until the subscribers handle the buffered messages.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+
+	for _, subscribers := range b.subscribers {
+		for _, subscriber := range subscribers {
+			close(subscriber)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
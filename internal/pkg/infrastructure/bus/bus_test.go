@@ -0,0 +1,58 @@
+package bus
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	bus := New()
+	ctx := context.Background()
+
+	var (
+		mu       sync.Mutex
+		received = map[string][]string{}
+		failed   []error
+	)
+	subscribe := func(name, routingKey string) {
+		err := bus.Subscribe(ctx, routingKey, func(ctx context.Context, message messages.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name] = append(received[name], message.MessageId)
+
+			if message.MessageId == "fail" {
+				return errors.New("failed")
+			}
+			return nil
+		}, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, err)
+		})
+		assert.NoError(t, err)
+	}
+
+	subscribe("first", "measurement")
+	subscribe("second", "measurement")
+	subscribe("other", "other")
+
+	for _, id := range []string{"1", "2", "fail", "3"} {
+		assert.NoError(t, bus.Publish(ctx, "measurement", messages.Message{MessageId: id}))
+	}
+
+	// Close waits for the buffered messages to be handled
+	bus.Close()
+
+	assert.Equal(t, []string{"1", "2", "fail", "3"}, received["first"])
+	assert.Equal(t, []string{"1", "2", "fail", "3"}, received["second"])
+	assert.Empty(t, received["other"])
+	assert.Len(t, failed, 2)
+
+	assert.ErrorIs(t, bus.Publish(ctx, "measurement", messages.Message{}), ErrClosed)
+	assert.ErrorIs(t, bus.Subscribe(ctx, "measurement", nil, nil), ErrClosed)
+}
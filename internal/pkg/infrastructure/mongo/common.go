@@ -14,7 +14,7 @@ func GroupDateInterval(groupBy string) (bson.D, error) {
 	case "minute":
 		dateTruncParams = append(dateTruncParams, bson.E{Key: "unit", Value: "minute"})
 	case "15min":
-		dateTruncParams = append(dateTruncParams, bson.E{Key: "unit", Value: "minute"}, bson.E{Key: "minute", Value: 15})
+		dateTruncParams = append(dateTruncParams, bson.E{Key: "unit", Value: "minute"}, bson.E{Key: "binSize", Value: 15})
 	case "hour":
 		dateTruncParams = append(dateTruncParams, bson.E{Key: "unit", Value: "hour"})
 	default:
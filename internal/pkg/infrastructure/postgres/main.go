@@ -34,10 +34,20 @@ func Connect(obs observability.Observability, connectionString string) (*gorm.DB
 
 	// To consider: If not persisted in the same database, this migration should happen in main.go
 	// Migrate the schemas
-	err = db.AutoMigrate(&postgres3.Asset{}, &postgres2.SimulatorConfiguration{})
+	err = db.AutoMigrate(&postgres3.Asset{}, &postgres2.SimulatorConfiguration{}, &postgres2.Recording{}, &postgres2.Scenario{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to migrate schemas")
 	}
 
 	return db, nil
 }
+
+// Close closes the connections of the database.
+func Close(db *gorm.DB) error {
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDb.Close()
+}
@@ -1,6 +1,8 @@
 package rabbitmq
 
 import (
+	"fmt"
+
 	"github.com/wagslane/go-rabbitmq"
 	"github.com/xBlaz3kx/DevX/observability"
 )
@@ -16,21 +18,21 @@ func NewLogger(obs observability.Observability) rabbitmq.Logger {
 }
 
 func (l *logger) Fatalf(s string, i ...interface{}) {
-	l.obs.Log().Fatal(s)
+	l.obs.Log().Fatal(fmt.Sprintf(s, i...))
 }
 
 func (l *logger) Errorf(s string, i ...interface{}) {
-	l.obs.Log().Error(s)
+	l.obs.Log().Error(fmt.Sprintf(s, i...))
 }
 
 func (l *logger) Warnf(s string, i ...interface{}) {
-	l.obs.Log().Warn(s)
+	l.obs.Log().Warn(fmt.Sprintf(s, i...))
 }
 
 func (l *logger) Infof(s string, i ...interface{}) {
-	l.obs.Log().Info(s)
+	l.obs.Log().Info(fmt.Sprintf(s, i...))
 }
 
 func (l *logger) Debugf(s string, i ...interface{}) {
-	l.obs.Log().Debug(s)
+	l.obs.Log().Debug(fmt.Sprintf(s, i...))
 }
@@ -0,0 +1,117 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wagslane/go-rabbitmq"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+// Messages logged by the connection and channel managers of go-rabbitmq when the connection or the channel is lost and
+// recovered. The managers don't expose their state, so it is tracked from their logs.
+const (
+	lostPrefix      = "attempting to reconnect to amqp server"
+	recoveredPrefix = "successfully reconnected to amqp server"
+	closedPrefix    = "amqp connection closed gracefully"
+	unrecoverable   = "publisher closing, unable to recover"
+)
+
+// State is the state of the shared connection, or of the channel of a publisher or a consumer.
+type State struct {
+	name string
+
+	mu    sync.RWMutex
+	err   error
+	since time.Time
+}
+
+func NewState(name string) *State {
+	return &State{name: name, since: time.Now()}
+}
+
+func (s *State) Name() string {
+	return s.name
+}
+
+// Status returns since when the connection or the channel is in its state, and the reason it is down, nil if it is up.
+func (s *State) Status() (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.since, s.err
+}
+
+// Fail marks the connection or the channel as down, e.g. when a consumer stops.
+func (s *State) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err, s.since = err, time.Now()
+}
+
+// Recover marks the connection or the channel as up.
+func (s *State) Recover() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err, s.since = nil, time.Now()
+}
+
+// Logger wraps the logger of the connection, publisher or consumer to track its state.
+func (s *State) Logger(logger rabbitmq.Logger) rabbitmq.Logger {
+	return &stateLogger{Logger: logger, state: s}
+}
+
+type stateLogger struct {
+	rabbitmq.Logger
+	state *State
+}
+
+func (l *stateLogger) Fatalf(s string, i ...interface{}) {
+	if strings.HasPrefix(s, unrecoverable) {
+		l.state.Fail(errors.New(s))
+	}
+	l.Logger.Fatalf(s, i...)
+}
+
+func (l *stateLogger) Errorf(s string, i ...interface{}) {
+	if strings.HasPrefix(s, lostPrefix) {
+		l.state.Fail(errors.New(fmt.Sprintf(s, i...)))
+	}
+	l.Logger.Errorf(s, i...)
+}
+
+func (l *stateLogger) Warnf(s string, i ...interface{}) {
+	if strings.HasPrefix(s, recoveredPrefix) {
+		l.state.Recover()
+	}
+	l.Logger.Warnf(s, i...)
+}
+
+func (l *stateLogger) Infof(s string, i ...interface{}) {
+	if strings.HasPrefix(s, closedPrefix) {
+		l.state.Fail(errors.New("connection closed"))
+	}
+	l.Logger.Infof(s, i...)
+}
+
+// Connection is the connection shared by the publishers and the consumers, with its state.
+type Connection struct {
+	*rabbitmq.Conn
+	State *State
+}
+
+// Connect connects to RabbitMQ, reconnecting in the background when the connection is lost.
+func Connect(obs observability.Observability, url string, reconnectInterval time.Duration) (*Connection, error) {
+	state := NewState("rabbitmq")
+	conn, err := rabbitmq.NewConn(url,
+		rabbitmq.WithConnectionOptionsLogger(state.Logger(NewLogger(obs))),
+		rabbitmq.WithConnectionOptionsReconnectInterval(reconnectInterval),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{Conn: conn, State: state}, nil
+}
@@ -0,0 +1,33 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestState_Logger(t *testing.T) {
+	state := NewState("rabbitmq")
+	logger := state.Logger(NewLogger(observability.NewNoopObservability()))
+
+	_, err := state.Status()
+	assert.NoError(t, err)
+
+	// Messages of the connection and channel managers of go-rabbitmq
+	logger.Errorf("attempting to reconnect to amqp server after connection close with error: %v", "connection reset")
+	_, err = state.Status()
+	assert.EqualError(t, err, "attempting to reconnect to amqp server after connection close with error: connection reset")
+
+	logger.Errorf("error reconnecting to amqp server: %v", "connection refused")
+	_, err = state.Status()
+	assert.Error(t, err)
+
+	logger.Warnf("successfully reconnected to amqp server")
+	_, err = state.Status()
+	assert.NoError(t, err)
+
+	logger.Infof("amqp connection closed gracefully")
+	_, err = state.Status()
+	assert.EqualError(t, err, "connection closed")
+}
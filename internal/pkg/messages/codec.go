@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"encoding/json"
+	"mime"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
+)
+
+// Encoding of the measurement messages.
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ParseEncoding parses the encoding. If the encoding is empty, JSON is used.
+func ParseEncoding(encoding string) (Encoding, error) {
+	switch Encoding(encoding) {
+	case "", EncodingJSON:
+		return EncodingJSON, nil
+	case EncodingProtobuf:
+		return EncodingProtobuf, nil
+	default:
+		return "", errors.Errorf("invalid message encoding: %s", encoding)
+	}
+}
+
+// ContentType returns the content type of the encoding.
+func (e Encoding) ContentType() string {
+	switch e {
+	case EncodingProtobuf:
+		return ContentTypeProtobuf
+	default:
+		return ContentTypeJSON
+	}
+}
+
+// Encode encodes the envelope with the given encoding.
+func Encode(encoding Encoding, envelope MeasurementEnvelope) ([]byte, error) {
+	switch encoding {
+	case EncodingJSON:
+		return json.Marshal(envelope)
+	case EncodingProtobuf:
+		return marshalEnvelope(envelope)
+	default:
+		return nil, errors.Errorf("invalid message encoding: %s", encoding)
+	}
+}
+
+// Decode decodes the message body based on its content type.
+// JSON messages without a schema version are treated as legacy messages - the returned envelope
+// has the LegacySchemaVersion and no asset ID, which has to be taken from the message headers.
+// Protobuf messages always carry the envelope, even without a schema version.
+func Decode(contentType string, body []byte) (*MeasurementEnvelope, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var envelope *MeasurementEnvelope
+	switch mediaType {
+	case ContentTypeJSON:
+		envelope, err = decodeJSON(body)
+	case ContentTypeProtobuf:
+		envelope, err = unmarshalEnvelope(body)
+	default:
+		return nil, ErrUnsupportedContentType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+func decodeJSON(body []byte) (*MeasurementEnvelope, error) {
+	var envelope MeasurementEnvelope
+	err := json.Unmarshal(body, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	if envelope.SchemaVersion != LegacySchemaVersion {
+		return &envelope, nil
+	}
+
+	// Legacy message, the body contains only the measurement
+	var measurement measurements.Measurement
+	err = json.Unmarshal(body, &measurement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MeasurementEnvelope{
+		SchemaVersion: LegacySchemaVersion,
+		Measurement:   measurement,
+		legacy:        true,
+	}, nil
+}
@@ -0,0 +1,258 @@
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/stretchr/testify/suite"
+)
+
+type codecTestSuite struct {
+	suite.Suite
+	envelope MeasurementEnvelope
+}
+
+func (s *codecTestSuite) SetupSuite() {
+	stateOfEnergy := 42.5
+	measurement := measurements.Measurement{
+		Id: "message-1",
+		Power: measurements.Power{
+			Value: -1250.5,
+			Unit:  measurements.UnitWatt,
+		},
+		StateOfEnergy: &stateOfEnergy,
+		Metrics: measurements.Metrics{
+			measurements.MetricVoltage:     {Value: 230.1, Unit: measurements.UnitVolt},
+			measurements.MetricTemperature: {Value: 21.5, Unit: measurements.UnitCelsius},
+		},
+		Time: time.Date(2024, 10, 1, 12, 0, 0, 123456789, time.UTC),
+	}
+
+	s.envelope = NewMeasurementEnvelope("asset-1", Producer{Name: "simulator", Version: "0.0.1", InstanceId: "host"}, measurement)
+}
+
+func (s *codecTestSuite) TestEncodeDecode() {
+	tests := []struct {
+		name     string
+		encoding Encoding
+	}{
+		{
+			name:     "JSON",
+			encoding: EncodingJSON,
+		},
+		{
+			name:     "Protobuf",
+			encoding: EncodingProtobuf,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			body, err := Encode(tt.encoding, s.envelope)
+			s.Require().NoError(err)
+
+			envelope, err := Decode(tt.encoding.ContentType(), body)
+			s.Require().NoError(err)
+			s.Equal(s.envelope.SchemaVersion, envelope.SchemaVersion)
+			s.Equal(s.envelope.MessageId, envelope.MessageId)
+			s.Equal(s.envelope.AssetId, envelope.AssetId)
+			s.Equal(s.envelope.Producer, envelope.Producer)
+			s.Equal(s.envelope.Measurement.Id, envelope.Measurement.Id)
+			s.Equal(s.envelope.Measurement.Power, envelope.Measurement.Power)
+			s.Equal(s.envelope.Measurement.StateOfEnergy, envelope.Measurement.StateOfEnergy)
+			s.Equal(s.envelope.Measurement.Metrics, envelope.Measurement.Metrics)
+			s.True(s.envelope.Measurement.Time.Equal(envelope.Measurement.Time))
+			s.NoError(envelope.Validate())
+		})
+	}
+}
+
+func (s *codecTestSuite) TestProtobufStateOfEnergy() {
+	zero := 0.0
+	tests := []struct {
+		name          string
+		stateOfEnergy *float64
+	}{
+		{
+			name: "Without state of energy",
+		},
+		{
+			name:          "Zero state of energy",
+			stateOfEnergy: &zero,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			envelope := s.envelope
+			envelope.Measurement.StateOfEnergy = tt.stateOfEnergy
+
+			body, err := Encode(EncodingProtobuf, envelope)
+			s.Require().NoError(err)
+
+			decoded, err := Decode(ContentTypeProtobuf, body)
+			s.Require().NoError(err)
+			s.Equal(tt.stateOfEnergy, decoded.Measurement.StateOfEnergy)
+		})
+	}
+}
+
+func (s *codecTestSuite) TestDecode() {
+	tests := []struct {
+		name          string
+		contentType   string
+		body          string
+		schemaVersion uint32
+		err           error
+	}{
+		{
+			name:          "Legacy JSON message",
+			contentType:   "application/json",
+			body:          `{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z", "stateOfEnergy": 1.00}`,
+			schemaVersion: LegacySchemaVersion,
+		},
+		{
+			name:          "JSON envelope with charset",
+			contentType:   "application/json; charset=utf-8",
+			body:          `{"schemaVersion": 1, "assetId": "1", "messageId": "2", "measurement": {"power": {"value": 1000, "unit": "W"}}}`,
+			schemaVersion: CurrentSchemaVersion,
+		},
+		{
+			name:        "Unsupported content type",
+			contentType: "text/plain",
+			body:        `{}`,
+			err:         ErrUnsupportedContentType,
+		},
+		{
+			name:        "Invalid protobuf",
+			contentType: ContentTypeProtobuf,
+			body:        "\xff",
+			err:         errInvalidProtobuf,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			envelope, err := Decode(tt.contentType, []byte(tt.body))
+			if tt.err != nil {
+				s.ErrorIs(err, tt.err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tt.schemaVersion, envelope.SchemaVersion)
+			s.EqualValues(1000, envelope.Measurement.Power.Value)
+		})
+	}
+}
+
+func (s *codecTestSuite) TestDecodeMessage() {
+	legacyBody := `{"power": {"value": 1000, "unit": "W"}, "time": "2021-09-01T12:00:00Z"}`
+	withoutVersion := s.envelope
+	withoutVersion.SchemaVersion = LegacySchemaVersion
+	withoutVersion.MessageId = ""
+	withoutVersion.Measurement.Id = ""
+	protobufBody, err := Encode(EncodingProtobuf, withoutVersion)
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name    string
+		message Message
+		assetId string
+		id      string
+		err     error
+	}{
+		{
+			name: "Legacy JSON message",
+			message: Message{
+				ContentType: ContentTypeJSON,
+				MessageId:   "message-2",
+				Headers:     map[string]any{"assetId": "header"},
+				Body:        []byte(legacyBody),
+			},
+			assetId: "header",
+			id:      "message-2",
+		},
+		{
+			name: "Legacy JSON message without message ID",
+			message: Message{
+				ContentType: ContentTypeJSON,
+				Headers:     map[string]any{"assetId": "header"},
+				Body:        []byte(legacyBody),
+			},
+			assetId: "header",
+			id:      measurements.NewMeasurementId("header", time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC), 0),
+		},
+		{
+			name: "Legacy JSON message without asset ID",
+			message: Message{
+				ContentType: ContentTypeJSON,
+				Body:        []byte(legacyBody),
+			},
+			err: ErrMissingAssetId,
+		},
+		{
+			name: "Protobuf envelope without schema version",
+			message: Message{
+				ContentType: ContentTypeProtobuf,
+				Headers:     map[string]any{"assetId": "header"},
+				Body:        protobufBody,
+			},
+			assetId: "asset-1",
+			id:      measurements.NewMeasurementId("asset-1", s.envelope.Measurement.Time, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			assetId, measurement, err := DecodeMessage(tt.message)
+			if tt.err != nil {
+				s.ErrorIs(err, tt.err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tt.assetId, assetId)
+			s.Equal(tt.id, measurement.Id)
+		})
+	}
+}
+
+func (s *codecTestSuite) TestValidate() {
+	envelope := s.envelope
+	s.NoError(envelope.Validate())
+
+	envelope.AssetId = ""
+	s.ErrorIs(envelope.Validate(), ErrMissingAssetId)
+
+	envelope = s.envelope
+	envelope.SchemaVersion = CurrentSchemaVersion + 1
+	s.ErrorIs(envelope.Validate(), ErrUnsupportedSchemaVersion)
+}
+
+func (s *codecTestSuite) TestEncoderMeasurementId() {
+	encoder := NewMeasurementEncoder(EncodingJSON, s.envelope.Producer)
+	measurement := s.envelope.Measurement
+	measurement.Id = ""
+
+	// The first measurement with a timestamp gets the ID derived by the consumer and the import
+	first, err := encoder.Encode("asset-1", measurement)
+	s.Require().NoError(err)
+	s.Equal(measurements.NewMeasurementId("asset-1", measurement.Time, 0), first.MessageId)
+
+	// Another measurement with the same timestamp gets the next sequence number
+	second, err := encoder.Encode("asset-1", measurement)
+	s.Require().NoError(err)
+	s.Equal(measurements.NewMeasurementId("asset-1", measurement.Time, 1), second.MessageId)
+
+	// The sequence starts over with the next timestamp
+	measurement.Time = measurement.Time.Add(time.Second)
+	next, err := encoder.Encode("asset-1", measurement)
+	s.Require().NoError(err)
+	s.Equal(measurements.NewMeasurementId("asset-1", measurement.Time, 0), next.MessageId)
+}
+
+func TestCodec(t *testing.T) {
+	suite.Run(t, new(codecTestSuite))
+}
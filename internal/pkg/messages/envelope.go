@@ -0,0 +1,65 @@
+package messages
+
+import (
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LegacySchemaVersion is the schema version of the legacy messages, which carry only the measurement
+	// in the body and the asset ID in the assetId header.
+	LegacySchemaVersion = 0
+
+	// CurrentSchemaVersion is the schema version of the measurement envelope.
+	CurrentSchemaVersion = 1
+)
+
+var (
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+	ErrUnsupportedContentType   = errors.New("unsupported content type")
+	ErrMissingAssetId           = errors.New("asset id is required")
+)
+
+// Producer holds the information about the service that produced the message.
+type Producer struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	InstanceId string `json:"instanceId,omitempty"`
+}
+
+// MeasurementEnvelope is the versioned measurement message.
+type MeasurementEnvelope struct {
+	SchemaVersion uint32                   `json:"schemaVersion"`
+	MessageId     string                   `json:"messageId"`
+	AssetId       string                   `json:"assetId"`
+	Producer      Producer                 `json:"producer"`
+	Measurement   measurements.Measurement `json:"measurement"`
+
+	// Set for the legacy JSON messages, which carry the asset ID in the headers
+	legacy bool
+}
+
+// NewMeasurementEnvelope creates a new envelope with the current schema version.
+// The message ID is taken from the measurement ID.
+func NewMeasurementEnvelope(assetId string, producer Producer, measurement measurements.Measurement) MeasurementEnvelope {
+	return MeasurementEnvelope{
+		SchemaVersion: CurrentSchemaVersion,
+		MessageId:     measurement.Id,
+		AssetId:       assetId,
+		Producer:      producer,
+		Measurement:   measurement,
+	}
+}
+
+// Validate checks if the envelope can be processed.
+func (e *MeasurementEnvelope) Validate() error {
+	if e.SchemaVersion > CurrentSchemaVersion {
+		return ErrUnsupportedSchemaVersion
+	}
+
+	if e.AssetId == "" {
+		return ErrMissingAssetId
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package messages
+
+import (
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+)
+
+// Message is an encoded measurement message, independent of the transport.
+type Message struct {
+	ContentType string
+	MessageId   string
+	Headers     map[string]any
+	Body        []byte
+}
+
+// MeasurementEncoder wraps the measurements in versioned envelopes and encodes them into messages.
+type MeasurementEncoder struct {
+	encoding Encoding
+	producer Producer
+
+	// Per-asset sequence numbers of the measurements with the same timestamp, used for deriving the message IDs
+	mu        sync.Mutex
+	sequences map[string]timestampSequence
+}
+
+// timestampSequence is the next sequence number of the measurements of an asset with the timestamp.
+type timestampSequence struct {
+	timestamp time.Time
+	next      uint64
+}
+
+func NewMeasurementEncoder(encoding Encoding, producer Producer) *MeasurementEncoder {
+	return &MeasurementEncoder{
+		encoding:  encoding,
+		producer:  producer,
+		sequences: make(map[string]timestampSequence),
+	}
+}
+
+// Encode assigns a deterministic ID to the measurement, if it doesn't have one, so the consumer can deduplicate
+// redelivered measurements, and encodes it with the configured encoding.
+// The first measurement of the asset with a timestamp gets the sequence number 0, so its ID matches the ID derived
+// for the same measurement by the consumer and the import, and doesn't depend on the lifetime of the process.
+func (e *MeasurementEncoder) Encode(assetId string, measurement measurements.Measurement) (*Message, error) {
+	if measurement.Id == "" {
+		measurement.Id = measurements.NewMeasurementId(assetId, measurement.Time, e.nextSequence(assetId, measurement.Time))
+	}
+
+	envelope := NewMeasurementEnvelope(assetId, e.producer, measurement)
+	body, err := Encode(e.encoding, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ContentType: e.encoding.ContentType(),
+		MessageId:   measurement.Id,
+		// Keep the assetId in the headers for consumers that don't support the envelope yet
+		Headers: map[string]any{
+			"assetId":       assetId,
+			"schemaVersion": int32(envelope.SchemaVersion),
+		},
+		Body: body,
+	}, nil
+}
+
+// nextSequence returns the next sequence number of the measurements of the asset with the timestamp.
+// The sequence starts over with every new timestamp of the asset.
+func (e *MeasurementEncoder) nextSequence(assetId string, timestamp time.Time) uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sequence := e.sequences[assetId]
+	if !sequence.timestamp.Equal(timestamp) {
+		sequence = timestampSequence{timestamp: timestamp}
+	}
+
+	e.sequences[assetId] = timestampSequence{timestamp: timestamp, next: sequence.next + 1}
+	return sequence.next
+}
+
+// DecodeMessage decodes the message based on its content type and returns the asset ID and the measurement.
+// Legacy JSON messages carry only the measurement in the body, so the assetId is taken from the header.
+// Measurements without an ID get an ID by measurementId.
+func DecodeMessage(message Message) (string, *measurements.Measurement, error) {
+	envelope, err := Decode(message.ContentType, message.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Get assetId from header for legacy messages
+	if envelope.legacy {
+		envelope.AssetId, _ = message.Headers["assetId"].(string)
+	}
+
+	err = envelope.Validate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	measurement := envelope.Measurement
+	measurement.Id = measurementId(*envelope, message.MessageId)
+
+	return envelope.AssetId, &measurement, nil
+}
+
+// measurementId returns the ID of the measurement of the envelope. Without one, it falls back to the message ID of
+// the envelope, then to the message ID of the transport, and finally derives the ID from the asset and timestamp
+// for deduplication.
+func measurementId(envelope MeasurementEnvelope, messageId string) string {
+	switch {
+	case envelope.Measurement.Id != "":
+		return envelope.Measurement.Id
+	case envelope.MessageId != "":
+		return envelope.MessageId
+	case messageId != "":
+		return messageId
+	default:
+		return measurements.NewMeasurementId(envelope.AssetId, envelope.Measurement.Time, 0)
+	}
+}
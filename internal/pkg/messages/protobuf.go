@@ -0,0 +1,111 @@
+package messages
+
+import (
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/pkg/messages/measurementpb"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errInvalidProtobuf = errors.New("invalid protobuf message")
+
+func marshalEnvelope(envelope MeasurementEnvelope) ([]byte, error) {
+	return proto.Marshal(&measurementpb.MeasurementEnvelope{
+		SchemaVersion: envelope.SchemaVersion,
+		MessageId:     envelope.MessageId,
+		AssetId:       envelope.AssetId,
+		Producer: &measurementpb.Producer{
+			Name:       envelope.Producer.Name,
+			Version:    envelope.Producer.Version,
+			InstanceId: envelope.Producer.InstanceId,
+		},
+		Measurement: toProtoMeasurement(envelope.Measurement),
+	})
+}
+
+func toProtoMeasurement(measurement measurements.Measurement) *measurementpb.Measurement {
+	pb := &measurementpb.Measurement{
+		Power: &measurementpb.Power{
+			Value: measurement.Power.Value,
+			Unit:  string(measurement.Power.Unit),
+		},
+		// The field is optional, so a zero state of energy is encoded as well
+		StateOfEnergy: measurement.StateOfEnergy,
+	}
+
+	if !measurement.Time.IsZero() {
+		pb.Time = timestamppb.New(measurement.Time)
+	}
+
+	if len(measurement.Metrics) > 0 {
+		pb.Metrics = make(map[string]*measurementpb.Metric, len(measurement.Metrics))
+		for name, metric := range measurement.Metrics {
+			pb.Metrics[name] = &measurementpb.Metric{
+				Value: metric.Value,
+				Unit:  string(metric.Unit),
+			}
+		}
+	}
+
+	return pb
+}
+
+func unmarshalEnvelope(b []byte) (*MeasurementEnvelope, error) {
+	var pb measurementpb.MeasurementEnvelope
+	err := proto.Unmarshal(b, &pb)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidProtobuf, err.Error())
+	}
+
+	// The measurement has no ID of its own, the message ID identifies it
+	measurement := fromProtoMeasurement(pb.GetMeasurement())
+	measurement.Id = pb.GetMessageId()
+
+	return &MeasurementEnvelope{
+		SchemaVersion: pb.GetSchemaVersion(),
+		MessageId:     pb.GetMessageId(),
+		AssetId:       pb.GetAssetId(),
+		Producer: Producer{
+			Name:       pb.GetProducer().GetName(),
+			Version:    pb.GetProducer().GetVersion(),
+			InstanceId: pb.GetProducer().GetInstanceId(),
+		},
+		Measurement: measurement,
+	}, nil
+}
+
+func fromProtoMeasurement(pb *measurementpb.Measurement) measurements.Measurement {
+	if pb == nil {
+		return measurements.Measurement{}
+	}
+
+	measurement := measurements.Measurement{
+		Power: measurements.Power{
+			Value: pb.GetPower().GetValue(),
+			Unit:  measurements.Unit(pb.GetPower().GetUnit()),
+		},
+	}
+
+	if pb.StateOfEnergy != nil {
+		stateOfEnergy := pb.GetStateOfEnergy()
+		measurement.StateOfEnergy = &stateOfEnergy
+	}
+
+	// AsTime decodes the timestamp in UTC
+	if pb.GetTime() != nil {
+		measurement.Time = pb.GetTime().AsTime()
+	}
+
+	if len(pb.GetMetrics()) > 0 {
+		measurement.Metrics = make(measurements.Metrics, len(pb.GetMetrics()))
+		for name, metric := range pb.GetMetrics() {
+			measurement.Metrics[name] = measurements.Metric{
+				Value: metric.GetValue(),
+				Unit:  measurements.Unit(metric.GetUnit()),
+			}
+		}
+	}
+
+	return measurement
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons for skipping or discarding a consumed measurement.
+const (
+	ReasonAssetDisabled      = "asset_disabled"
+	ReasonDuplicate          = "duplicate"
+	ReasonDecodingFailed     = "decoding_failed"
+	ReasonInvalidMeasurement = "invalid_measurement"
+	ReasonAssetNotFound      = "asset_not_found"
+	ReasonStoringFailed      = "storing_failed"
+)
+
+// ConsumerMetrics counts the measurements consumed by the asset service and what happened to them.
+type ConsumerMetrics struct {
+	consumed  prometheus.Counter
+	stored    prometheus.Counter
+	skipped   *prometheus.CounterVec
+	discarded *prometheus.CounterVec
+}
+
+func NewConsumerMetrics(registry *prometheus.Registry) *ConsumerMetrics {
+	if registry == nil {
+		return nil
+	}
+
+	m := &ConsumerMetrics{
+		consumed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "asset_service_measurements_consumed_total",
+			Help: "Number of consumed measurement messages.",
+		}),
+		stored: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "asset_service_measurements_stored_total",
+			Help: "Number of stored measurements.",
+		}),
+		skipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "asset_service_measurements_skipped_total",
+			Help: "Number of acknowledged measurements which were not stored, by reason.",
+		}, []string{"reason"}),
+		discarded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "asset_service_measurements_discarded_total",
+			Help: "Number of rejected measurement messages, by reason.",
+		}, []string{"reason"}),
+	}
+	registry.MustRegister(m.consumed, m.stored, m.skipped, m.discarded)
+	return m
+}
+
+func (m *ConsumerMetrics) Consumed() {
+	if m == nil {
+		return
+	}
+
+	m.consumed.Inc()
+}
+
+func (m *ConsumerMetrics) Stored() {
+	if m == nil {
+		return
+	}
+
+	m.stored.Inc()
+}
+
+func (m *ConsumerMetrics) Skipped(reason string) {
+	if m == nil {
+		return
+	}
+
+	m.skipped.WithLabelValues(reason).Inc()
+}
+
+func (m *ConsumerMetrics) Discarded(reason string) {
+	if m == nil {
+		return
+	}
+
+	m.discarded.WithLabelValues(reason).Inc()
+}
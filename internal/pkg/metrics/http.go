@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels the requests which didn't match any route, so unknown paths don't create new series.
+const unmatchedRoute = "unmatched"
+
+type HttpMetrics struct {
+	requestDuration *prometheus.HistogramVec
+}
+
+func NewHttpMetrics(registry *prometheus.Registry) *HttpMetrics {
+	if registry == nil {
+		return nil
+	}
+
+	m := &HttpMetrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of the HTTP requests by route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+	registry.MustRegister(m.requestDuration)
+	return m
+}
+
+// Middleware records the latency of each request, labelled by the route template rather than the path.
+func (m *HttpMetrics) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if m == nil {
+			ctx.Next()
+			return
+		}
+
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.requestDuration.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+// NewRegistry creates a Prometheus registry with the Go runtime and process metrics, if the metrics are enabled.
+// The constructors in this package return nil metrics for a nil registry, and nil metrics record nothing, so the
+// components don't need to check whether the metrics are enabled.
+func NewRegistry(config observability.MetricsConfig) *prometheus.Registry {
+	if !config.Enabled {
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// RegisterRoutes exposes the metrics of the registry on /metrics, if the metrics are enabled.
+func RegisterRoutes(router gin.IRouter, registry *prometheus.Registry) {
+	if registry == nil {
+		return
+	}
+
+	router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})))
+}
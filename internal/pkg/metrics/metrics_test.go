@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestDisabledMetrics(t *testing.T) {
+	registry := NewRegistry(observability.MetricsConfig{Enabled: false})
+	assert.Nil(t, registry)
+
+	// Nil metrics record nothing
+	assert.NotPanics(t, func() {
+		NewConsumerMetrics(registry).Consumed()
+		NewConsumerMetrics(registry).Discarded(ReasonDecodingFailed)
+		NewPublisherMetrics(registry).Published(time.Second)
+		NewSimulatorMetrics(registry).TickLag(time.Second)
+	})
+
+	router := gin.New()
+	router.Use(NewHttpMetrics(registry).Middleware())
+	RegisterRoutes(router, registry)
+	router.GET("/assets", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/assets", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestHttpMetrics_Middleware(t *testing.T) {
+	registry := NewRegistry(observability.MetricsConfig{Enabled: true})
+	require.NotNil(t, registry)
+
+	router := gin.New()
+	router.Use(NewHttpMetrics(registry).Middleware())
+	RegisterRoutes(router, registry)
+	router.GET("/assets/:assetId", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/assets/1", "/assets/2", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Requests are labelled by the route, not the path
+	count, err := testutil.GatherAndCount(registry, "http_request_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `http_request_duration_seconds_count{method="GET",route="/assets/:assetId",status="200"} 2`)
+	assert.Contains(t, recorder.Body.String(), `http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, recorder.Body.String(), "go_goroutines")
+}
+
+func TestSimulatorMetrics(t *testing.T) {
+	registry := NewRegistry(observability.MetricsConfig{Enabled: true})
+	simulatorMetrics := NewSimulatorMetrics(registry)
+
+	simulatorMetrics.WorkerStarted()
+	simulatorMetrics.WorkerStarted()
+	simulatorMetrics.WorkerStopped()
+	simulatorMetrics.TickLag(10 * time.Millisecond)
+
+	expected := `
+# HELP simulator_workers_running Number of running simulator workers.
+# TYPE simulator_workers_running gauge
+simulator_workers_running 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "simulator_workers_running"))
+
+	count, err := testutil.GatherAndCount(registry, "simulator_tick_lag_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons for a failed publish of a measurement.
+const (
+	ReasonPublisherClosed = "publisher_closed"
+	ReasonEncodingFailed  = "encoding_failed"
+	ReasonPublishFailed   = "publish_failed"
+	ReasonNotConfirmed    = "not_confirmed"
+	ReasonRejected        = "rejected"
+)
+
+// PublisherMetrics measures the publishing of the simulated measurements.
+type PublisherMetrics struct {
+	publishDuration prometheus.Histogram
+	failures        *prometheus.CounterVec
+}
+
+func NewPublisherMetrics(registry *prometheus.Registry) *PublisherMetrics {
+	if registry == nil {
+		return nil
+	}
+
+	m := &PublisherMetrics{
+		publishDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "simulator_measurement_publish_duration_seconds",
+			Help:    "Latency of the published measurements, until confirmed by the broker.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "simulator_measurement_publish_failures_total",
+			Help: "Number of measurements which failed to publish, by reason.",
+		}, []string{"reason"}),
+	}
+	registry.MustRegister(m.publishDuration, m.failures)
+	return m
+}
+
+// Published records the latency of a successfully published measurement.
+func (m *PublisherMetrics) Published(duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.publishDuration.Observe(duration.Seconds())
+}
+
+func (m *PublisherMetrics) Failed(reason string) {
+	if m == nil {
+		return
+	}
+
+	m.failures.WithLabelValues(reason).Inc()
+}
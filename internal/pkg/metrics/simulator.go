@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SimulatorMetrics measures the simulator workers.
+type SimulatorMetrics struct {
+	runningWorkers prometheus.Gauge
+	tickLag        prometheus.Histogram
+}
+
+func NewSimulatorMetrics(registry *prometheus.Registry) *SimulatorMetrics {
+	if registry == nil {
+		return nil
+	}
+
+	m := &SimulatorMetrics{
+		runningWorkers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "simulator_workers_running",
+			Help: "Number of running simulator workers.",
+		}),
+		tickLag: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "simulator_tick_lag_seconds",
+			Help:    "Delay between a scheduled tick of a worker and the generation of its measurement.",
+			Buckets: []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10},
+		}),
+	}
+	registry.MustRegister(m.runningWorkers, m.tickLag)
+	return m
+}
+
+func (m *SimulatorMetrics) WorkerStarted() {
+	if m == nil {
+		return
+	}
+
+	m.runningWorkers.Inc()
+}
+
+func (m *SimulatorMetrics) WorkerStopped() {
+	if m == nil {
+		return
+	}
+
+	m.runningWorkers.Dec()
+}
+
+func (m *SimulatorMetrics) TickLag(lag time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.tickLag.Observe(lag.Seconds())
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	devxHttp "github.com/xBlaz3kx/DevX/http"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// Server serves the router of a DevX HTTP server. DevX's Run registers its own /healthz endpoint, which would
+// conflict with the health package, and its Shutdown can't be used, as the server doesn't keep the observability.
+type Server struct {
+	obs    observability.Observability
+	config devxHttp.Configuration
+	server *http.Server
+}
+
+func New(obs observability.Observability, config devxHttp.Configuration, handler http.Handler) *Server {
+	return &Server{
+		obs:    obs,
+		config: config,
+		server: &http.Server{Addr: config.Address, Handler: handler},
+	}
+}
+
+// Run starts serving in the background.
+func (s *Server) Run() {
+	go func() {
+		var err error
+		if s.config.TLS.IsEnabled {
+			err = s.server.ListenAndServeTLS(s.config.TLS.CertificatePath, s.config.TLS.PrivateKeyPath)
+		} else {
+			err = s.server.ListenAndServe()
+		}
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.obs.Log().Panic("HTTP server failed to start", zap.Error(err))
+		}
+	}()
+}
+
+// Shutdown stops accepting new connections and waits for the active requests until the context is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.obs.Log().Info("Shutting down the HTTP server")
+	return s.server.Shutdown(ctx)
+}
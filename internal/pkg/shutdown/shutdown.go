@@ -0,0 +1,47 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// DefaultDrainTimeout bounds the graceful shutdown when the drain timeout is not configured.
+const DefaultDrainTimeout = 15 * time.Second
+
+// Step is a step of the graceful shutdown, e.g. stopping the HTTP server or draining a consumer.
+type Step struct {
+	Name     string
+	Shutdown func(ctx context.Context) error
+}
+
+// Run runs the steps in order within the drain timeout. All steps share the deadline; once it passes, the remaining
+// steps still run to release their resources, but don't wait for anything in flight.
+func Run(obs observability.Observability, drainTimeout time.Duration, steps ...Step) error {
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, step := range steps {
+		if step.Shutdown == nil {
+			continue
+		}
+
+		obs.Log().Info("Shutting down", zap.String("step", step.Name))
+		err := step.Shutdown(ctx)
+		if err != nil {
+			obs.Log().With(zap.Error(err)).Warn("Graceful shutdown step failed", zap.String("step", step.Name))
+			errs = append(errs, fmt.Errorf("%s: %w", step.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
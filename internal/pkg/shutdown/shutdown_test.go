@@ -0,0 +1,62 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestRun(t *testing.T) {
+	obs := observability.NewNoopObservability()
+
+	t.Run("Runs the steps in order", func(t *testing.T) {
+		var order []string
+		step := func(name string) Step {
+			return Step{Name: name, Shutdown: func(ctx context.Context) error {
+				order = append(order, name)
+				return nil
+			}}
+		}
+
+		err := Run(obs, time.Second, step("http"), Step{Name: "skipped"}, step("consumer"), step("connection"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http", "consumer", "connection"}, order)
+	})
+
+	t.Run("Runs the remaining steps after a failure", func(t *testing.T) {
+		failure := errors.New("failed")
+		closed := false
+
+		err := Run(obs, time.Second,
+			Step{Name: "consumer", Shutdown: func(ctx context.Context) error { return failure }},
+			Step{Name: "connection", Shutdown: func(ctx context.Context) error {
+				closed = true
+				return nil
+			}},
+		)
+		assert.ErrorIs(t, err, failure)
+		assert.ErrorContains(t, err, "consumer")
+		assert.True(t, closed)
+	})
+
+	t.Run("Steps share the deadline", func(t *testing.T) {
+		var secondCtxErr error
+
+		err := Run(obs, 50*time.Millisecond,
+			Step{Name: "consumer", Shutdown: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+			Step{Name: "connection", Shutdown: func(ctx context.Context) error {
+				secondCtxErr = ctx.Err()
+				return nil
+			}},
+		)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, secondCtxErr, context.DeadlineExceeded)
+	})
+}
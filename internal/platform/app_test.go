@@ -0,0 +1,117 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	asset_service "asset-measurements-assignment/internal/asset-service"
+	assetMemory "asset-measurements-assignment/internal/asset-service/memory"
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/measurements/service"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"asset-measurements-assignment/internal/pkg/metrics"
+	"asset-measurements-assignment/internal/simulator"
+	assetSimulation "asset-measurements-assignment/internal/simulator/asset_simulation"
+	simulatorMemory "asset-measurements-assignment/internal/simulator/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestRun_NamespacedRoutes(t *testing.T) {
+	obs := observability.NewNoopObservability()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	configurationRepository := simulatorMemory.NewSimulatorConfigurationRepository(obs)
+	publisher := assetSimulation.NewMockPublisher(t)
+	published := make(chan string, 1)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, measurement measurements.Measurement, assetId string) {
+			select {
+			case published <- assetId:
+			default:
+			}
+		}).
+		Return(nil)
+
+	registry := metrics.NewRegistry(observability.MetricsConfig{Enabled: true})
+	router := gin.New()
+	router.Use(metrics.NewHttpMetrics(registry).Middleware())
+	metrics.RegisterRoutes(router, registry)
+
+	// Both services register routes under /assets/:assetId
+	err := asset_service.Start(ctx, obs, asset_service.Infrastructure{
+		AssetRepository:         assetMemory.NewAssetRepository(obs),
+		MeasurementsRepository:  assetMemory.NewMeasurementsRepository(obs),
+		ConfigurationRepository: configurationRepository,
+		StartConsumer: func(ctx context.Context, obs observability.Observability, consumerService service.ConsumerService) error {
+			return nil
+		},
+	}, router.Group(AssetServicePrefix))
+	require.NoError(t, err)
+
+	simulation, err := simulator.Start(ctx, obs, simulator.Config{}, simulator.Infrastructure{
+		ConfigurationRepository: configurationRepository,
+		RecordingRepository:     simulatorMemory.NewRecordingRepository(obs),
+		ScenarioRepository:      simulatorMemory.NewScenarioRepository(obs),
+		NewPublisher: func(obs observability.Observability, encoding messages.Encoding, producer messages.Producer) (assetSimulation.Publisher, error) {
+			return publisher, nil
+		},
+	}, router.Group(SimulatorPrefix), registry)
+	require.NoError(t, err)
+
+	// The workers are stopped after the context is done, as when the platform shuts down
+	defer func() {
+		cancel()
+		assert.NoError(t, simulation.Shutdown(context.Background()))
+	}()
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return recorder
+	}
+
+	response := request(http.MethodPost, "/asset-service/assets", `{"name":"battery","type":"battery","enabled":true}`)
+	require.Equal(t, http.StatusCreated, response.Code)
+
+	var asset struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &asset))
+
+	response = request(http.MethodPost, fmt.Sprintf("/simulator/assets/%s/config", asset.ID),
+		`{"type":"battery","measurementInterval":200000000,"maxPower":1000,"minPower":-1000,"maxPowerStep":100}`)
+	assert.Equal(t, http.StatusCreated, response.Code)
+
+	response = request(http.MethodGet, fmt.Sprintf("/simulator/assets/%s/config", asset.ID), "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = request(http.MethodGet, fmt.Sprintf("/asset-service/assets/%s", asset.ID), "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = request(http.MethodGet, "/assets", "")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	select {
+	case assetId := <-published:
+		assert.Equal(t, asset.ID, assetId)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no measurement was published")
+	}
+
+	// The metrics of both services are labelled by the namespaced route
+	response = request(http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "simulator_workers_running 1")
+	assert.Contains(t, response.Body.String(), `http_request_duration_seconds_count{method="POST",route="/asset-service/assets",status="201"} 1`)
+	assert.Contains(t, response.Body.String(), `http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+}
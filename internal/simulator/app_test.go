@@ -0,0 +1,76 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	assetSimulation "asset-measurements-assignment/internal/simulator/asset_simulation"
+	"asset-measurements-assignment/internal/simulator/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestSimulation_WorkersCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		assetIds       []string
+		runningWorkers []string
+		expected       WorkersStatus
+		err            bool
+	}{
+		{
+			name:           "Worker running the latest version",
+			assetIds:       []string{"1", "1"},
+			runningWorkers: []string{"1"},
+			expected:       WorkersStatus{Running: 1, Expected: 1},
+		},
+		{
+			name:           "Worker not running",
+			assetIds:       []string{"1", "1", "2"},
+			runningWorkers: []string{"1"},
+			expected:       WorkersStatus{Running: 1, Expected: 2},
+			err:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obs := observability.NewNoopObservability()
+			ctx := context.Background()
+
+			configurationRepository := memory.NewSimulatorConfigurationRepository(obs)
+			for _, assetId := range tt.assetIds {
+				_, err := configurationRepository.CreateConfiguration(ctx, simulator.Configuration{
+					AssetId:             assetId,
+					Type:                "solar",
+					MeasurementInterval: time.Second,
+				})
+				require.NoError(t, err)
+			}
+
+			workerManager := assetSimulation.NewAssetSimulatorManager(obs, nil)
+			for _, workerId := range tt.runningWorkers {
+				worker := assetSimulation.NewMockRunner(t)
+				worker.EXPECT().GetId().Return(workerId)
+				worker.EXPECT().IsRunning().Return(true)
+				require.NoError(t, workerManager.AddWorker(worker))
+			}
+
+			simulation := &Simulation{
+				workerManager:           workerManager,
+				configurationRepository: configurationRepository,
+			}
+
+			details, err := simulation.WorkersCheck().Check(ctx)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package asset_simulation
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock drives the ticks of a runner and tells the time of the generated measurements.
+type Clock interface {
+	Now() time.Time
+	// NewTicker returns a ticker which ticks every interval.
+	NewTicker(interval time.Duration) Ticker
+}
+
+// Ticker delivers the ticks of a Clock.
+type Ticker interface {
+	// C returns the channel of the ticks. The channel is closed when the clock ends, which stops the runner.
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock ticks in real time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(interval time.Duration) Ticker {
+	return systemTicker{ticker: time.NewTicker(interval)}
+}
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t systemTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// VirtualClock runs from a start to an end time independently of the system time. With a zero speed, the virtual
+// time advances to the next tick as soon as the runner asks for it. Otherwise, the next tick follows the processed
+// tick after the interval divided by the speed, so the virtual time runs speed times faster than the real time.
+// The ticks start at the start time, and the tick channel is closed after the last tick before the end.
+type VirtualClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	end   time.Time
+	speed float64
+	// Whether the clock advanced to a tick, which the next ticker doesn't repeat
+	ticked bool
+}
+
+func NewVirtualClock(start, end time.Time, speed float64) *VirtualClock {
+	return &VirtualClock{
+		now:   start,
+		end:   end,
+		speed: speed,
+	}
+}
+
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker of the virtual time. The clock supports one ticker at a time. The first tick is at the
+// current time, unless the clock already advanced to it, e.g. when the runner restarts, then an interval later.
+func (c *VirtualClock) NewTicker(interval time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	next := c.now
+	if c.ticked {
+		next = next.Add(interval)
+	}
+
+	return &virtualTicker{
+		clock:    c,
+		interval: interval,
+		next:     next,
+		ticks:    make(chan time.Time, 1),
+	}
+}
+
+// advance moves the virtual time to the tick, unless the tick is after the end.
+func (c *VirtualClock) advance(tick time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tick.After(c.end) {
+		return false
+	}
+
+	c.now = tick
+	c.ticked = true
+	return true
+}
+
+// virtualTicker advances the virtual clock when the runner asks for the next tick, so the clock doesn't advance while
+// the runner is still generating the measurement of the previous tick.
+type virtualTicker struct {
+	clock    *VirtualClock
+	interval time.Duration
+	// Channel of the ticks, holding the next tick until it is received
+	ticks chan time.Time
+
+	mu   sync.Mutex
+	next time.Time
+	// The next tick is delivered by the timer, after the interval divided by the speed
+	timer     *time.Timer
+	scheduled bool
+	stopped   bool
+	ended     bool
+}
+
+// C returns the channel of the ticks, the same channel on every call. The next tick is delivered once the previous
+// tick was received, so a runner asking for the tick on every pass of its loop doesn't skip any.
+func (t *virtualTicker) C() <-chan time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped || t.ended || t.scheduled || len(t.ticks) > 0 {
+		return t.ticks
+	}
+
+	if t.clock.speed <= 0 {
+		t.deliver()
+		return t.ticks
+	}
+
+	t.scheduled = true
+	t.timer = time.AfterFunc(time.Duration(float64(t.interval)/t.clock.speed), func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if t.stopped {
+			return
+		}
+
+		t.scheduled = false
+		t.deliver()
+	})
+	return t.ticks
+}
+
+// deliver advances the clock to the next tick and sends it, or closes the channel after the end of the clock. It must
+// be called with the lock held, while the channel is empty.
+func (t *virtualTicker) deliver() {
+	if !t.clock.advance(t.next) {
+		t.ended = true
+		close(t.ticks)
+		return
+	}
+
+	t.ticks <- t.next
+	t.next = t.next.Add(t.interval)
+}
+
+func (t *virtualTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
@@ -0,0 +1,100 @@
+package asset_simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualClock(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	// receiveAll receives the ticks until the channel is closed, checking the clock follows them
+	receiveAll := func(t *testing.T, clock *VirtualClock, ticker Ticker) []time.Time {
+		var ticks []time.Time
+		for {
+			select {
+			case tick, ok := <-ticker.C():
+				if !ok {
+					return ticks
+				}
+				assert.Equal(t, tick, clock.Now())
+				ticks = append(ticks, tick)
+			case <-time.After(time.Second):
+				require.Fail(t, "tick not received")
+			}
+		}
+	}
+
+	t.Run("As fast as possible", func(t *testing.T) {
+		clock := NewVirtualClock(from, to, 0)
+		assert.Equal(t, from, clock.Now())
+
+		ticker := clock.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		ticks := receiveAll(t, clock, ticker)
+		assert.Equal(t, []time.Time{from, from.Add(time.Hour), from.Add(2 * time.Hour), to}, ticks)
+
+		// The clock stays at the last tick
+		assert.Equal(t, to, clock.Now())
+	})
+
+	t.Run("Tick not received", func(t *testing.T) {
+		clock := NewVirtualClock(from, to, 0)
+		ticker := clock.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		// Asking for the tick without receiving it, e.g. when another case of the runner's select wins, doesn't
+		// skip the tick nor advance the clock further
+		ticks := ticker.C()
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, ticks, ticker.C())
+		}
+		assert.Equal(t, from, clock.Now())
+
+		assert.Equal(t, []time.Time{from, from.Add(time.Hour), from.Add(2 * time.Hour), to}, receiveAll(t, clock, ticker))
+	})
+
+	t.Run("Multiple of the real time", func(t *testing.T) {
+		// An hour of virtual time lasts 10ms
+		clock := NewVirtualClock(from, to, float64(time.Hour/(10*time.Millisecond)))
+		ticker := clock.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		start := time.Now()
+		ticks := receiveAll(t, clock, ticker)
+		assert.Len(t, ticks, 4)
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("New ticker after a tick", func(t *testing.T) {
+		clock := NewVirtualClock(from, to, 0)
+		ticker := clock.NewTicker(time.Hour)
+		assert.Equal(t, from, <-ticker.C())
+		ticker.Stop()
+
+		// A restarted runner doesn't repeat the tick it already processed
+		ticker = clock.NewTicker(time.Hour)
+		defer ticker.Stop()
+		assert.Equal(t, []time.Time{from.Add(time.Hour), from.Add(2 * time.Hour), to}, receiveAll(t, clock, ticker))
+	})
+
+	t.Run("Stopped ticker", func(t *testing.T) {
+		clock := NewVirtualClock(from, to, float64(time.Hour/time.Millisecond))
+		ticker := clock.NewTicker(time.Hour)
+
+		ticks := ticker.C()
+		ticker.Stop()
+
+		select {
+		case <-ticks:
+			assert.Fail(t, "tick received after stop")
+		case <-time.After(20 * time.Millisecond):
+		}
+		assert.Equal(t, from, clock.Now())
+	})
+}
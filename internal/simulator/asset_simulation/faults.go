@@ -0,0 +1,307 @@
+package asset_simulation
+
+import (
+	"context"
+	errors2 "errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// MessagePublisher is implemented by the publishers which can publish a modified message of the measurement. The fault
+// injection uses it to publish malformed messages.
+type MessagePublisher interface {
+	PublishMessage(ctx context.Context, measurement measurements.Measurement, assetId string, modify func(message *messages.Message)) error
+}
+
+var ErrMalformedMessagesNotSupported = errors.New("publisher doesn't support malformed messages")
+
+// Faults keeps the faults injected into the workers of the assets. The generators and the publisher of the workers are
+// wrapped to apply them, so a running worker can be switched into a fault.
+type Faults struct {
+	mu sync.Mutex
+	// Faults of each asset by type. The ones which ended are removed on Inject and Get
+	faults map[string]map[simulator.FaultType]simulator.ActiveFault
+	// Clocks of the workers of the assets, so the faults start and end in the time of the worker
+	clocks map[string]Clock
+	// Clock of the assets without a worker
+	clock Clock
+	rng   *rand.Rand
+}
+
+func NewFaults(clock Clock, source rand.Source) *Faults {
+	return &Faults{
+		faults: map[string]map[simulator.FaultType]simulator.ActiveFault{},
+		clocks: map[string]Clock{},
+		clock:  clock,
+		rng:    rand.New(source),
+	}
+}
+
+// now returns the time of the worker of the asset. The lock must be held.
+func (f *Faults) now(assetId string) time.Time {
+	if clock, ok := f.clocks[assetId]; ok {
+		return clock.Now()
+	}
+	return f.clock.Now()
+}
+
+// Inject starts the fault for the worker of the asset, replacing the previous fault of the same type.
+func (f *Faults) Inject(assetId string, fault simulator.Fault) simulator.ActiveFault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id := range f.faults {
+		f.removeEnded(id, f.now(id))
+	}
+
+	now := f.now(assetId)
+
+	active := simulator.ActiveFault{
+		AssetId:   assetId,
+		Fault:     fault.WithDefaults(),
+		StartedAt: now,
+		Until:     now.Add(fault.Duration),
+	}
+
+	if f.faults[assetId] == nil {
+		f.faults[assetId] = map[simulator.FaultType]simulator.ActiveFault{}
+	}
+	f.faults[assetId][fault.Type] = active
+
+	return active
+}
+
+// Get returns the faults of the asset which didn't end, ordered by their start.
+func (f *Faults) Get(assetId string) []simulator.ActiveFault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.removeEnded(assetId, f.now(assetId))
+
+	faults := []simulator.ActiveFault{}
+	for _, fault := range f.faults[assetId] {
+		faults = append(faults, fault)
+	}
+
+	slices.SortFunc(faults, func(a, b simulator.ActiveFault) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return faults
+}
+
+// removeEnded removes the faults of the asset which ended. The lock must be held.
+func (f *Faults) removeEnded(assetId string, now time.Time) {
+	for faultType, fault := range f.faults[assetId] {
+		if !now.Before(fault.Until) {
+			delete(f.faults[assetId], faultType)
+		}
+	}
+
+	if len(f.faults[assetId]) == 0 {
+		delete(f.faults, assetId)
+	}
+}
+
+// Clear ends all faults of the asset.
+func (f *Faults) Clear(assetId string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.faults, assetId)
+}
+
+// active returns the fault of the type of the asset, if it didn't end.
+func (f *Faults) active(assetId string, faultType simulator.FaultType) (simulator.ActiveFault, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fault, ok := f.faults[assetId][faultType]
+	if !ok || !f.now(assetId).Before(fault.Until) {
+		return simulator.ActiveFault{}, false
+	}
+
+	return fault, true
+}
+
+// affects checks if the fault of the type of the asset didn't end, and draws whether it affects the sample.
+func (f *Faults) affects(assetId string, faultType simulator.FaultType) (simulator.ActiveFault, bool) {
+	fault, ok := f.active(assetId, faultType)
+	if !ok {
+		return fault, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fault, f.rng.Float64() < fault.Probability
+}
+
+// WrapGenerator wraps the generator of the worker of the asset with the faults of the measurements: stuck, drift and
+// spikes. The faults of the asset start and end on the clock of the worker.
+func (f *Faults) WrapGenerator(assetId string, clock Clock, generator MeasurementGenerator) MeasurementGenerator {
+	f.mu.Lock()
+	f.clocks[assetId] = clock
+	f.mu.Unlock()
+
+	return &faultyGenerator{
+		MeasurementGenerator: generator,
+		assetId:              assetId,
+		faults:               f,
+	}
+}
+
+// WrapPublisher wraps the publisher of the workers with the faults of the delivery: dropped, duplicated and
+// out-of-order samples, malformed JSON and wrong content type.
+func (f *Faults) WrapPublisher(publisher Publisher) Publisher {
+	return &faultyPublisher{
+		Publisher: publisher,
+		faults:    f,
+		held:      map[string]measurements.Measurement{},
+	}
+}
+
+type faultyGenerator struct {
+	MeasurementGenerator
+	assetId string
+	faults  *Faults
+
+	// Measurement repeated by the stuck fault, which started at stuckSince
+	stuck      *measurements.Measurement
+	stuckSince time.Time
+}
+
+func (g *faultyGenerator) GenerateMeasurement() (*measurements.Measurement, error) {
+	measurement, err := g.MeasurementGenerator.GenerateMeasurement()
+	if err != nil {
+		return nil, err
+	}
+
+	if fault, ok := g.faults.active(g.assetId, simulator.FaultStuck); ok {
+		if g.stuck == nil || !g.stuckSince.Equal(fault.StartedAt) {
+			g.stuck, g.stuckSince = measurement, fault.StartedAt
+		}
+
+		stuck := *g.stuck
+		stuck.Time = measurement.Time
+		return &stuck, nil
+	}
+
+	if fault, ok := g.faults.active(g.assetId, simulator.FaultDrift); ok {
+		minutes := max(0, measurement.Time.Sub(fault.StartedAt).Minutes())
+		measurement.Power.Value += fault.Magnitude * minutes
+	}
+
+	if fault, ok := g.faults.affects(g.assetId, simulator.FaultSpikes); ok {
+		measurement.Power.Value += fault.Magnitude
+	}
+
+	return measurement, nil
+}
+
+type faultyPublisher struct {
+	Publisher
+	faults *Faults
+
+	// Measurements held back by the out-of-order fault, by asset
+	mu   sync.Mutex
+	held map[string]measurements.Measurement
+}
+
+func (p *faultyPublisher) Publish(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+	if _, ok := p.faults.affects(assetId, simulator.FaultDropped); ok {
+		return nil
+	}
+
+	// The held measurement is published after the next one
+	held, ok := p.take(assetId)
+	if !ok {
+		if _, outOfOrder := p.faults.affects(assetId, simulator.FaultOutOfOrder); outOfOrder {
+			p.hold(assetId, measurement)
+			return nil
+		}
+	}
+
+	err := p.publish(ctx, measurement, assetId)
+	if ok {
+		err = errors2.Join(err, p.publish(ctx, held, assetId))
+	}
+
+	return err
+}
+
+// publish publishes the measurement, twice if it is duplicated.
+func (p *faultyPublisher) publish(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+	if _, ok := p.faults.affects(assetId, simulator.FaultDuplicated); !ok {
+		return p.publishMessage(ctx, measurement, assetId)
+	}
+
+	// The duplicate has the same ID, so the consumer can deduplicate it
+	if measurement.Id == "" {
+		measurement.Id = uuid.New().String()
+	}
+
+	err := p.publishMessage(ctx, measurement, assetId)
+	if err != nil {
+		return err
+	}
+
+	return p.publishMessage(ctx, measurement, assetId)
+}
+
+// publishMessage publishes the message of the measurement, malformed by the faults of the messages.
+func (p *faultyPublisher) publishMessage(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+	var modify func(message *messages.Message)
+	if _, ok := p.faults.affects(assetId, simulator.FaultMalformedJson); ok {
+		modify = malformJson
+	} else if _, ok := p.faults.affects(assetId, simulator.FaultWrongContentType); ok {
+		modify = swapContentType
+	}
+
+	if modify == nil {
+		return p.Publisher.Publish(ctx, measurement, assetId)
+	}
+
+	publisher, ok := p.Publisher.(MessagePublisher)
+	if !ok {
+		return ErrMalformedMessagesNotSupported
+	}
+
+	return publisher.PublishMessage(ctx, measurement, assetId, modify)
+}
+
+func (p *faultyPublisher) hold(assetId string, measurement measurements.Measurement) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.held[assetId] = measurement
+}
+
+func (p *faultyPublisher) take(assetId string) (measurements.Measurement, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	measurement, ok := p.held[assetId]
+	delete(p.held, assetId)
+	return measurement, ok
+}
+
+// malformJson truncates the message, which is labelled as JSON.
+func malformJson(message *messages.Message) {
+	message.ContentType = messages.ContentTypeJSON
+	message.Body = message.Body[:len(message.Body)/2]
+}
+
+// swapContentType labels the message with the content type of the other encoding.
+func swapContentType(message *messages.Message) {
+	if message.ContentType == messages.ContentTypeJSON {
+		message.ContentType = messages.ContentTypeProtobuf
+	} else {
+		message.ContentType = messages.ContentTypeJSON
+	}
+}
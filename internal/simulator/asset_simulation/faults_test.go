@@ -0,0 +1,240 @@
+package asset_simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/domain/simulator/generator"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type faultsTestSuite struct {
+	suite.Suite
+	start   time.Time
+	current time.Time
+	clock   *MockClock
+	faults  *Faults
+}
+
+func (s *faultsTestSuite) SetupTest() {
+	s.start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s.current = s.start
+
+	s.clock = NewMockClock(s.T())
+	s.clock.EXPECT().Now().RunAndReturn(func() time.Time { return s.current }).Maybe()
+	s.faults = NewFaults(s.clock, generator.NewSource(1))
+}
+
+// generate generates a measurement every minute from the start with the wrapped generator, whose power counts up
+// from 100, and returns the powers
+func (s *faultsTestSuite) generate(minutes int) []float64 {
+	power := 100.0
+	mockGenerator := NewMockMeasurementGenerator(s.T())
+	mockGenerator.EXPECT().GenerateMeasurement().RunAndReturn(func() (*measurements.Measurement, error) {
+		power++
+		return &measurements.Measurement{Power: measurements.Power{Value: power, Unit: "W"}, Time: s.current}, nil
+	})
+	wrapped := s.faults.WrapGenerator("1", s.clock, mockGenerator)
+
+	var powers []float64
+	for i := 0; i < minutes; i++ {
+		s.current = s.start.Add(time.Duration(i) * time.Minute)
+
+		measurement, err := wrapped.GenerateMeasurement()
+		s.Require().NoError(err)
+		s.Equal(s.current, measurement.Time)
+		powers = append(powers, measurement.Power.Value)
+	}
+	return powers
+}
+
+// publish publishes the measurements of the minutes with the wrapped publisher and returns the published minutes
+func (s *faultsTestSuite) publish(minutes int) []int {
+	var published []int
+	mockPublisher := NewMockPublisher(s.T())
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything, "1").
+		RunAndReturn(func(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+			published = append(published, int(measurement.Time.Sub(s.start)/time.Minute))
+			return nil
+		}).Maybe()
+	wrapped := s.faults.WrapPublisher(mockPublisher)
+
+	for i := 0; i < minutes; i++ {
+		measurement := measurements.Measurement{Time: s.start.Add(time.Duration(i) * time.Minute)}
+		s.Require().NoError(wrapped.Publish(context.Background(), measurement, "1"))
+	}
+	return published
+}
+
+func (s *faultsTestSuite) TestNoFaults() {
+	s.Equal([]float64{101, 102, 103}, s.generate(3))
+	s.Equal([]int{0, 1, 2}, s.publish(3))
+}
+
+func (s *faultsTestSuite) TestStuck() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultStuck, Duration: 2 * time.Minute})
+	s.Equal([]float64{101, 101, 103, 104}, s.generate(4))
+}
+
+func (s *faultsTestSuite) TestDrift() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultDrift, Duration: 3 * time.Minute, Magnitude: 10})
+	s.Equal([]float64{101, 112, 123, 104}, s.generate(4))
+}
+
+func (s *faultsTestSuite) TestSpikes() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultSpikes, Duration: 2 * time.Minute, Magnitude: 1000, Probability: 1})
+	s.Equal([]float64{1101, 1102, 103}, s.generate(3))
+}
+
+func (s *faultsTestSuite) TestFaultsOfOtherAssets() {
+	s.faults.Inject("2", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Hour})
+	s.faults.Inject("2", simulator.Fault{Type: simulator.FaultDropped, Duration: time.Hour})
+	s.Equal([]float64{101, 102}, s.generate(2))
+	s.Equal([]int{0, 1}, s.publish(2))
+}
+
+func (s *faultsTestSuite) TestDropped() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultDropped, Duration: time.Hour})
+	s.Empty(s.publish(3))
+
+	// Half of the samples are dropped
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultDropped, Duration: time.Hour, Probability: 0.5})
+	s.InDelta(500, len(s.publish(1000)), 100)
+}
+
+func (s *faultsTestSuite) TestDuplicated() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultDuplicated, Duration: time.Hour})
+
+	var ids []string
+	mockPublisher := NewMockPublisher(s.T())
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything, "1").
+		RunAndReturn(func(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+			ids = append(ids, measurement.Id)
+			return nil
+		})
+
+	wrapped := s.faults.WrapPublisher(mockPublisher)
+	s.Require().NoError(wrapped.Publish(context.Background(), measurements.Measurement{Time: s.start}, "1"))
+
+	s.Len(ids, 2)
+	s.NotEmpty(ids[0])
+	s.Equal(ids[0], ids[1])
+}
+
+func (s *faultsTestSuite) TestOutOfOrder() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultOutOfOrder, Duration: time.Hour})
+	s.Equal([]int{1, 0, 3, 2}, s.publish(5))
+}
+
+func (s *faultsTestSuite) TestMalformedMessages() {
+	body := []byte(`{"schemaVersion":1,"assetId":"1"}`)
+
+	tests := []struct {
+		name     string
+		fault    simulator.FaultType
+		expected messages.Message
+	}{
+		{
+			name:     "Malformed JSON",
+			fault:    simulator.FaultMalformedJson,
+			expected: messages.Message{ContentType: messages.ContentTypeJSON, Body: body[:len(body)/2]},
+		},
+		{
+			name:     "Wrong content type",
+			fault:    simulator.FaultWrongContentType,
+			expected: messages.Message{ContentType: messages.ContentTypeProtobuf, Body: body},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.faults.Clear("1")
+			s.faults.Inject("1", simulator.Fault{Type: tt.fault, Duration: time.Hour})
+
+			mockPublisher := NewMockMessagePublisher(s.T())
+			mockPublisher.EXPECT().PublishMessage(mock.Anything, mock.Anything, "1", mock.Anything).
+				RunAndReturn(func(ctx context.Context, measurement measurements.Measurement, assetId string, modify func(*messages.Message)) error {
+					message := messages.Message{ContentType: messages.ContentTypeJSON, Body: body}
+					modify(&message)
+					s.Equal(tt.expected, message)
+					return nil
+				})
+
+			wrapped := s.faults.WrapPublisher(messagePublisher{MockPublisher: NewMockPublisher(s.T()), MockMessagePublisher: mockPublisher})
+			s.NoError(wrapped.Publish(context.Background(), measurements.Measurement{Time: s.start}, "1"))
+
+			// The publishers which can't publish malformed messages fail
+			wrapped = s.faults.WrapPublisher(NewMockPublisher(s.T()))
+			s.ErrorIs(wrapped.Publish(context.Background(), measurements.Measurement{Time: s.start}, "1"), ErrMalformedMessagesNotSupported)
+		})
+	}
+}
+
+func (s *faultsTestSuite) TestGetAndClear() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Minute})
+	s.current = s.start.Add(time.Second)
+	spikes := s.faults.Inject("1", simulator.Fault{Type: simulator.FaultSpikes, Duration: time.Hour, Magnitude: 10})
+
+	s.Equal(simulator.DefaultSpikeProbability, spikes.Probability)
+	s.Equal(s.current.Add(time.Hour), spikes.Until)
+	s.Len(s.faults.Get("1"), 2)
+	s.Empty(s.faults.Get("2"))
+
+	// The ended faults are not returned
+	s.current = s.start.Add(time.Minute)
+	s.Equal([]simulator.ActiveFault{spikes}, s.faults.Get("1"))
+
+	s.faults.Clear("1")
+	s.Empty(s.faults.Get("1"))
+}
+
+func (s *faultsTestSuite) TestRemoveEnded() {
+	s.faults.Inject("1", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Minute})
+	s.faults.Inject("2", simulator.Fault{Type: simulator.FaultDropped, Duration: time.Minute})
+	s.faults.Inject("2", simulator.Fault{Type: simulator.FaultDrift, Duration: time.Hour})
+
+	// Get removes the ended faults of the asset
+	s.current = s.start.Add(time.Minute)
+	s.Empty(s.faults.Get("1"))
+	s.NotContains(s.faults.faults, "1")
+	s.Len(s.faults.faults["2"], 2)
+
+	// Inject removes the ended faults of all assets
+	s.faults.Inject("3", simulator.Fault{Type: simulator.FaultSpikes, Duration: time.Hour})
+	s.Len(s.faults.faults["2"], 1)
+	s.Contains(s.faults.faults["2"], simulator.FaultDrift)
+
+	s.current = s.start.Add(time.Hour * 2)
+	s.faults.Inject("3", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Hour})
+	s.NotContains(s.faults.faults, "2")
+	s.Len(s.faults.faults["3"], 1)
+}
+
+func (s *faultsTestSuite) TestClockOfWorker() {
+	// The worker of the asset runs on a virtual clock a day behind
+	virtual := NewVirtualClock(s.start.Add(-time.Hour*24), s.start, 0)
+	s.faults.WrapGenerator("2", virtual, NewMockMeasurementGenerator(s.T()))
+
+	fault := s.faults.Inject("2", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Hour})
+	s.Equal(virtual.Now(), fault.StartedAt)
+	s.Len(s.faults.Get("2"), 1)
+
+	// The assets without a worker use the clock of the faults
+	fault = s.faults.Inject("3", simulator.Fault{Type: simulator.FaultStuck, Duration: time.Hour})
+	s.Equal(s.current, fault.StartedAt)
+}
+
+// messagePublisher is a publisher which can publish malformed messages
+type messagePublisher struct {
+	*MockPublisher
+	*MockMessagePublisher
+}
+
+func TestFaults(t *testing.T) {
+	suite.Run(t, new(faultsTestSuite))
+}
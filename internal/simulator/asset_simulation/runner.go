@@ -2,10 +2,14 @@ package asset_simulation
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/domain/simulator/generator"
+	"asset-measurements-assignment/internal/pkg/metrics"
 	"github.com/pkg/errors"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.uber.org/zap"
@@ -17,26 +21,41 @@ type Publisher interface {
 
 type MeasurementGenerator interface {
 	GenerateMeasurement() (*measurements.Measurement, error)
-	GetEnergyType() domain.EnergyType
+	GetGeneratorType() simulator.GeneratorType
 }
 
 type runner struct {
 	obs       observability.Observability
 	generator MeasurementGenerator
-	stopChan  chan bool
 	interval  time.Duration
 	publisher Publisher
+	clock     Clock
 	id        string
-	isRunning bool
+	metrics   *metrics.SimulatorMetrics
+
+	// The stop channel is created by each start, so a stopped runner can be started again
+	mu          sync.Mutex
+	stopChan    chan struct{}
+	restartChan chan struct{}
+	isRunning   atomic.Bool
+	isPaused    atomic.Bool
+
+	// Measurements of the ticks and the ticks on demand are generated one at a time, as the generators aren't
+	// thread-safe
+	publishMu       sync.Mutex
+	lastMeasurement atomic.Pointer[measurements.Measurement]
+	publishErrors   atomic.Int64
 }
 
-// NewRunner Creates a new Runner instance.
+// NewRunner Creates a new Runner instance, which ticks on the clock. The clock should be the clock of the generator.
 func NewRunner(
 	obs observability.Observability,
 	id string,
 	interval time.Duration,
 	generator MeasurementGenerator,
 	publisher Publisher,
+	clock Clock,
+	metrics *metrics.SimulatorMetrics,
 ) (Runner, error) {
 	if generator == nil {
 		return nil, errors.New("generator is required")
@@ -46,6 +65,10 @@ func NewRunner(
 		return nil, errors.New("publisher is required")
 	}
 
+	if clock == nil {
+		return nil, errors.New("clock is required")
+	}
+
 	if interval <= time.Millisecond*100 {
 		return nil, errors.New("interval must be greater than 100ms")
 	}
@@ -55,23 +78,24 @@ func NewRunner(
 	}
 
 	return &runner{
-		obs:       obs,
-		stopChan:  make(chan bool),
-		isRunning: false,
-		generator: generator,
-		publisher: publisher,
-		id:        id,
-		interval:  interval,
+		obs:         obs,
+		generator:   generator,
+		publisher:   publisher,
+		clock:       clock,
+		id:          id,
+		interval:    interval,
+		metrics:     metrics,
+		restartChan: make(chan struct{}, 1),
 	}, nil
 }
 
-// Start creates a ticker and generates a measurement at each tick.
+// Start creates a ticker on the clock and generates a measurement at each tick, unless the runner is paused.
 // The measurement is then published via a Publisher.
 func (s *runner) Start(ctx context.Context) error {
 	s.obs.Log().Debug(
 		"Starting simulator runner",
 		zap.String("id", s.id),
-		zap.String("generatorType", string(s.generator.GetEnergyType())),
+		zap.String("generatorType", string(s.generator.GetGeneratorType())),
 	)
 
 	if s.interval <= time.Millisecond*100 {
@@ -82,20 +106,46 @@ func (s *runner) Start(ctx context.Context) error {
 		return errors.New("id is required")
 	}
 
-	ticker := time.NewTicker(s.interval)
-	defer ticker.Stop()
+	s.mu.Lock()
+	if s.isRunning.Load() {
+		s.mu.Unlock()
+		return ErrWorkerAlreadyRunning
+	}
+
+	stopChan := make(chan struct{})
+	s.stopChan = stopChan
+	s.isRunning.Store(true)
+	s.isPaused.Store(false)
+	s.mu.Unlock()
+	defer s.isRunning.Store(false)
 
-	s.isRunning = true
+	ticker := s.clock.NewTicker(s.interval)
 	defer func() {
-		s.isRunning = false
+		ticker.Stop()
 	}()
 
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stopChan:
 			return nil
-		case <-ticker.C:
-			s.publishMessage(ctx)
+		case <-s.restartChan:
+			s.obs.Log().Debug("Restarting simulator runner", zap.String("id", s.id))
+			ticker.Stop()
+			ticker = s.clock.NewTicker(s.interval)
+			s.isPaused.Store(false)
+		case tick, ok := <-ticker.C():
+			if !ok {
+				s.obs.Log().Debug("Simulator runner reached the end of the clock", zap.String("id", s.id))
+				return nil
+			}
+
+			if s.isPaused.Load() {
+				continue
+			}
+
+			// The ticker drops the ticks while the previous measurement is being published
+			s.metrics.TickLag(s.clock.Now().Sub(tick))
+			_, _ = s.publishMessage(ctx)
 		case <-ctx.Done():
 			if !errors.Is(ctx.Err(), context.Canceled) {
 				s.obs.Log().With(zap.Error(ctx.Err())).Error("Context error")
@@ -107,40 +157,119 @@ func (s *runner) Start(ctx context.Context) error {
 	}
 }
 
-// publishMessage generates a random measurement and publishes it via a Publisher.
-func (s *runner) publishMessage(ctx context.Context) {
+// publishMessage generates a random measurement and publishes it via a Publisher. No measurement is returned if the
+// generator didn't produce one.
+func (s *runner) publishMessage(ctx context.Context) (*measurements.Measurement, error) {
 	spanCtx, cancel2 := s.obs.Span(ctx, "simulator.runner.publishMessage")
 	defer cancel2()
 
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
 	// Generate measurement
 	measurement, err := s.generator.GenerateMeasurement()
+	if errors.Is(err, generator.ErrNoMeasurement) {
+		return nil, nil
+	}
+
 	if err != nil {
 		s.obs.Log().With(zap.Error(err)).Error("Failed to generate random measurement")
-		return
+		return nil, err
 	}
 
+	last := *measurement
+	s.lastMeasurement.Store(&last)
+
 	// Publish message
 	err = s.publisher.Publish(spanCtx, *measurement, s.GetId())
 	if err != nil {
+		s.publishErrors.Add(1)
 		s.obs.Log().With(zap.Error(err)).Error("Failed to publish measurement")
+		return measurement, err
 	}
+
+	return measurement, nil
 }
 
 func (s *runner) IsRunning() bool {
-	return s.isRunning
+	return s.isRunning.Load()
 }
 
 func (s *runner) Stop() error {
-	if !s.isRunning {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isRunning.Load() {
 		return errors.New("worker is already stopped")
 	}
 
-	// Stop the worker
-	s.stopChan <- true
-	close(s.stopChan)
+	// Stop the worker, without waiting for it to receive the signal, as it may be returning already
+	select {
+	case <-s.stopChan:
+	default:
+		close(s.stopChan)
+	}
+	return nil
+}
+
+// Pause stops generating measurements at the ticks until the runner is resumed or restarted.
+func (s *runner) Pause() error {
+	if !s.isRunning.Load() {
+		return ErrWorkerNotRunning
+	}
+
+	s.isPaused.Store(true)
+	return nil
+}
+
+func (s *runner) Resume() error {
+	if !s.isRunning.Load() {
+		return ErrWorkerNotRunning
+	}
+
+	s.isPaused.Store(false)
+	return nil
+}
+
+// Restart starts the ticker of the running runner over and resumes it.
+func (s *runner) Restart() error {
+	if !s.isRunning.Load() {
+		return ErrWorkerNotRunning
+	}
+
+	// A pending restart is enough
+	select {
+	case s.restartChan <- struct{}{}:
+	default:
+	}
 	return nil
 }
 
+// Tick generates and publishes a measurement on demand, whether the runner is running or not.
+func (s *runner) Tick(ctx context.Context) (*measurements.Measurement, error) {
+	return s.publishMessage(ctx)
+}
+
+// Status returns the state of the runner with the last measurement and the number of publishing errors.
+func (s *runner) Status() simulator.Worker {
+	state := simulator.WorkerStopped
+	switch {
+	case s.isRunning.Load() && s.isPaused.Load():
+		state = simulator.WorkerPaused
+	case s.isRunning.Load():
+		state = simulator.WorkerRunning
+	}
+
+	return simulator.Worker{
+		Id:              s.id,
+		State:           state,
+		Interval:        s.interval,
+		GeneratorType:   s.generator.GetGeneratorType(),
+		LastMeasurement: s.lastMeasurement.Load(),
+		PublishErrors:   s.publishErrors.Load(),
+	}
+}
+
 func (s *runner) GetId() string {
 	return s.id
 }
@@ -3,9 +3,12 @@
 package asset_simulation
 
 import (
+	measurements "asset-measurements-assignment/internal/domain/measurements"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	simulator "asset-measurements-assignment/internal/domain/simulator"
 )
 
 // MockRunner is an autogenerated mock type for the Runner type
@@ -111,6 +114,141 @@ func (_c *MockRunner_IsRunning_Call) RunAndReturn(run func() bool) *MockRunner_I
 	return _c
 }
 
+// Pause provides a mock function with given fields:
+func (_m *MockRunner) Pause() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRunner_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type MockRunner_Pause_Call struct {
+	*mock.Call
+}
+
+// Pause is a helper method to define mock.On call
+func (_e *MockRunner_Expecter) Pause() *MockRunner_Pause_Call {
+	return &MockRunner_Pause_Call{Call: _e.mock.On("Pause")}
+}
+
+func (_c *MockRunner_Pause_Call) Run(run func()) *MockRunner_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRunner_Pause_Call) Return(_a0 error) *MockRunner_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRunner_Pause_Call) RunAndReturn(run func() error) *MockRunner_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restart provides a mock function with given fields:
+func (_m *MockRunner) Restart() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRunner_Restart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restart'
+type MockRunner_Restart_Call struct {
+	*mock.Call
+}
+
+// Restart is a helper method to define mock.On call
+func (_e *MockRunner_Expecter) Restart() *MockRunner_Restart_Call {
+	return &MockRunner_Restart_Call{Call: _e.mock.On("Restart")}
+}
+
+func (_c *MockRunner_Restart_Call) Run(run func()) *MockRunner_Restart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRunner_Restart_Call) Return(_a0 error) *MockRunner_Restart_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRunner_Restart_Call) RunAndReturn(run func() error) *MockRunner_Restart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields:
+func (_m *MockRunner) Resume() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRunner_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockRunner_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+func (_e *MockRunner_Expecter) Resume() *MockRunner_Resume_Call {
+	return &MockRunner_Resume_Call{Call: _e.mock.On("Resume")}
+}
+
+func (_c *MockRunner_Resume_Call) Run(run func()) *MockRunner_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRunner_Resume_Call) Return(_a0 error) *MockRunner_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRunner_Resume_Call) RunAndReturn(run func() error) *MockRunner_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *MockRunner) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -157,6 +295,51 @@ func (_c *MockRunner_Start_Call) RunAndReturn(run func(context.Context) error) *
 	return _c
 }
 
+// Status provides a mock function with given fields:
+func (_m *MockRunner) Status() simulator.Worker {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 simulator.Worker
+	if rf, ok := ret.Get(0).(func() simulator.Worker); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(simulator.Worker)
+	}
+
+	return r0
+}
+
+// MockRunner_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockRunner_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *MockRunner_Expecter) Status() *MockRunner_Status_Call {
+	return &MockRunner_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *MockRunner_Status_Call) Run(run func()) *MockRunner_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRunner_Status_Call) Return(_a0 simulator.Worker) *MockRunner_Status_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRunner_Status_Call) RunAndReturn(run func() simulator.Worker) *MockRunner_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stop provides a mock function with given fields:
 func (_m *MockRunner) Stop() error {
 	ret := _m.Called()
@@ -202,6 +385,64 @@ func (_c *MockRunner_Stop_Call) RunAndReturn(run func() error) *MockRunner_Stop_
 	return _c
 }
 
+// Tick provides a mock function with given fields: ctx
+func (_m *MockRunner) Tick(ctx context.Context) (*measurements.Measurement, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tick")
+	}
+
+	var r0 *measurements.Measurement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*measurements.Measurement, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *measurements.Measurement); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*measurements.Measurement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRunner_Tick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tick'
+type MockRunner_Tick_Call struct {
+	*mock.Call
+}
+
+// Tick is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRunner_Expecter) Tick(ctx interface{}) *MockRunner_Tick_Call {
+	return &MockRunner_Tick_Call{Call: _e.mock.On("Tick", ctx)}
+}
+
+func (_c *MockRunner_Tick_Call) Run(run func(ctx context.Context)) *MockRunner_Tick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRunner_Tick_Call) Return(_a0 *measurements.Measurement, _a1 error) *MockRunner_Tick_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRunner_Tick_Call) RunAndReturn(run func(context.Context) (*measurements.Measurement, error)) *MockRunner_Tick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRunner creates a new instance of MockRunner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRunner(t interface {
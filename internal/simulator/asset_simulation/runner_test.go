@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"asset-measurements-assignment/internal/domain"
 	"asset-measurements-assignment/internal/domain/measurements"
+	simulatorDomain "asset-measurements-assignment/internal/domain/simulator"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -36,6 +36,7 @@ func (s *runnerTestSuite) TestNewRunner() {
 		interval  time.Duration
 		generator MeasurementGenerator
 		publisher Publisher
+		clock     Clock
 		err       bool
 	}{
 		{
@@ -44,6 +45,7 @@ func (s *runnerTestSuite) TestNewRunner() {
 			interval:  time.Second,
 			generator: NewMockMeasurementGenerator(s.T()),
 			publisher: NewMockPublisher(s.T()),
+			clock:     SystemClock,
 			err:       false,
 		},
 		{
@@ -52,6 +54,7 @@ func (s *runnerTestSuite) TestNewRunner() {
 			interval:  time.Second,
 			generator: NewMockMeasurementGenerator(s.T()),
 			publisher: NewMockPublisher(s.T()),
+			clock:     SystemClock,
 			err:       true,
 		},
 		{
@@ -60,6 +63,7 @@ func (s *runnerTestSuite) TestNewRunner() {
 			interval:  time.Millisecond,
 			generator: NewMockMeasurementGenerator(s.T()),
 			publisher: NewMockPublisher(s.T()),
+			clock:     SystemClock,
 			err:       true,
 		},
 		{
@@ -68,6 +72,16 @@ func (s *runnerTestSuite) TestNewRunner() {
 			interval:  time.Millisecond,
 			generator: NewMockMeasurementGenerator(s.T()),
 			publisher: nil,
+			clock:     SystemClock,
+			err:       true,
+		},
+		{
+			name:      "Nil clock",
+			id:        "4",
+			interval:  time.Second,
+			generator: NewMockMeasurementGenerator(s.T()),
+			publisher: NewMockPublisher(s.T()),
+			clock:     nil,
 			err:       true,
 		},
 		{
@@ -76,13 +90,14 @@ func (s *runnerTestSuite) TestNewRunner() {
 			interval:  time.Millisecond,
 			generator: nil,
 			publisher: NewMockPublisher(s.T()),
+			clock:     SystemClock,
 			err:       true,
 		},
 	}
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			simulator, err := NewRunner(s.obs, tt.id, tt.interval, tt.generator, tt.publisher)
+			simulator, err := NewRunner(s.obs, tt.id, tt.interval, tt.generator, tt.publisher, tt.clock, nil)
 			if tt.err {
 				s.Assert().Error(err)
 			} else {
@@ -96,6 +111,7 @@ func (s *runnerTestSuite) TestNewRunner() {
 
 func (s *runnerTestSuite) Test_publishMessage() {
 	currentTime := time.Now()
+	stateOfEnergy := 100.0
 
 	tests := []struct {
 		name        string
@@ -110,7 +126,7 @@ func (s *runnerTestSuite) Test_publishMessage() {
 					Value: 110,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 100,
+				StateOfEnergy: &stateOfEnergy,
 				Time:          currentTime,
 			},
 		},
@@ -128,7 +144,7 @@ func (s *runnerTestSuite) Test_publishMessage() {
 					Value: 100,
 					Unit:  measurements.UnitWatt,
 				},
-				StateOfEnergy: 100,
+				StateOfEnergy: &stateOfEnergy,
 				Time:          currentTime,
 			},
 		},
@@ -136,7 +152,7 @@ func (s *runnerTestSuite) Test_publishMessage() {
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			simulator, err := NewRunner(s.obs, tt.id, time.Second, s.mockGenerator, s.publisherMock)
+			simulator, err := NewRunner(s.obs, tt.id, time.Second, s.mockGenerator, s.publisherMock, SystemClock, nil)
 			s.Require().NoError(err)
 
 			switch tt.name {
@@ -179,13 +195,13 @@ func (s *runnerTestSuite) TestStart() {
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			simulator, err := NewRunner(s.obs, tt.id, tt.interval, s.mockGenerator, s.publisherMock)
+			simulator, err := NewRunner(s.obs, tt.id, tt.interval, s.mockGenerator, s.publisherMock, SystemClock, nil)
 			s.Require().NoError(err)
 
 			switch tt.name {
 			case "Successful start":
 				measurement := measurements.Measurement{}
-				s.mockGenerator.EXPECT().GetEnergyType().Return(domain.EnergyTypeConsumer)
+				s.mockGenerator.EXPECT().GetGeneratorType().Return(simulatorDomain.GeneratorConsumer)
 				s.mockGenerator.EXPECT().GenerateMeasurement().Return(&measurement, nil)
 				s.publisherMock.EXPECT().Publish(mock.Anything, measurement, tt.id).Return(nil)
 			}
@@ -212,13 +228,150 @@ func (s *runnerTestSuite) TestStart() {
 			time.Sleep(tt.interval * 4)
 			s.False(simulator.IsRunning())
 
-			s.mockGenerator.AssertNumberOfCalls(t, "GetEnergyType", 1)
+			s.mockGenerator.AssertNumberOfCalls(t, "GetGeneratorType", 1)
 			s.mockGenerator.AssertNumberOfCalls(t, "GenerateMeasurement", 3)
 			s.publisherMock.AssertNumberOfCalls(t, "Publish", 3)
 		})
 	}
 }
 
+func (s *runnerTestSuite) TestStartOnClock() {
+	ticks := make(chan time.Time)
+	tickTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	ticker := NewMockTicker(s.T())
+	ticker.EXPECT().C().Return(ticks)
+	ticker.EXPECT().Stop().Return().Once()
+
+	clock := NewMockClock(s.T())
+	clock.EXPECT().NewTicker(time.Minute).Return(ticker).Once()
+	clock.EXPECT().Now().Return(tickTime)
+
+	simulator, err := NewRunner(s.obs, "1", time.Minute, s.mockGenerator, s.publisherMock, clock, nil)
+	s.Require().NoError(err)
+
+	measurement := measurements.Measurement{Time: tickTime}
+	s.mockGenerator.EXPECT().GetGeneratorType().Return(simulatorDomain.GeneratorConsumer)
+	s.mockGenerator.EXPECT().GenerateMeasurement().Return(&measurement, nil).Times(3)
+	s.publisherMock.EXPECT().Publish(mock.Anything, measurement, "1").Return(nil).Times(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- simulator.Start(ctx)
+	}()
+
+	// Every tick of the clock publishes a measurement, without waiting for the interval
+	for i := 0; i < 3; i++ {
+		ticks <- tickTime
+	}
+
+	cancel()
+	s.NoError(<-done)
+	s.False(simulator.IsRunning())
+}
+
+func (s *runnerTestSuite) TestControl() {
+	ticks := make(chan time.Time)
+	tickTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	ticker := NewMockTicker(s.T())
+	ticker.EXPECT().C().Return(ticks)
+	ticker.EXPECT().Stop().Return().Times(2)
+
+	clock := NewMockClock(s.T())
+	clock.EXPECT().NewTicker(time.Minute).Return(ticker).Times(2)
+	clock.EXPECT().Now().Return(tickTime)
+
+	simulator, err := NewRunner(s.obs, "1", time.Minute, s.mockGenerator, s.publisherMock, clock, nil)
+	s.Require().NoError(err)
+
+	// The runner can't be controlled before it starts
+	s.ErrorIs(simulator.Pause(), ErrWorkerNotRunning)
+	s.ErrorIs(simulator.Restart(), ErrWorkerNotRunning)
+
+	measurement := measurements.Measurement{Time: tickTime, Power: measurements.Power{Value: 1, Unit: measurements.UnitWatt}}
+	s.mockGenerator.EXPECT().GetGeneratorType().Return(simulatorDomain.GeneratorConsumer)
+	s.mockGenerator.EXPECT().GenerateMeasurement().Return(&measurement, nil).Times(3)
+	s.publisherMock.EXPECT().Publish(mock.Anything, measurement, "1").Return(nil).Once()
+	s.publisherMock.EXPECT().Publish(mock.Anything, measurement, "1").Return(errors.New("publish failed")).Once()
+	s.publisherMock.EXPECT().Publish(mock.Anything, measurement, "1").Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- simulator.Start(ctx)
+	}()
+
+	ticks <- tickTime
+
+	// The paused runner skips the ticks
+	s.NoError(simulator.Pause())
+	ticks <- tickTime
+	s.Equal(simulatorDomain.WorkerPaused, simulator.Status().State)
+
+	// The tick on demand publishes regardless of the pause
+	tick, err := simulator.Tick(context.Background())
+	s.Error(err)
+	s.Equal(&measurement, tick)
+
+	status := simulator.Status()
+	s.Equal(simulatorDomain.Worker{
+		Id:              "1",
+		State:           simulatorDomain.WorkerPaused,
+		Interval:        time.Minute,
+		GeneratorType:   simulatorDomain.GeneratorConsumer,
+		LastMeasurement: &measurement,
+		PublishErrors:   1,
+	}, status)
+
+	s.NoError(simulator.Resume())
+	s.Equal(simulatorDomain.WorkerRunning, simulator.Status().State)
+
+	// The restart creates a new ticker and resumes the runner
+	s.NoError(simulator.Pause())
+	s.NoError(simulator.Restart())
+	s.Eventually(func() bool {
+		return simulator.Status().State == simulatorDomain.WorkerRunning
+	}, time.Second, time.Millisecond)
+
+	ticks <- tickTime
+
+	cancel()
+	s.NoError(<-done)
+	s.Equal(simulatorDomain.WorkerStopped, simulator.Status().State)
+}
+
+func (s *runnerTestSuite) TestStartOnVirtualClock() {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewVirtualClock(from, from.Add(time.Hour), 0)
+
+	simulator, err := NewRunner(s.obs, "1", 15*time.Minute, s.mockGenerator, s.publisherMock, clock, nil)
+	s.Require().NoError(err)
+
+	// The measurements carry the virtual time
+	s.mockGenerator.EXPECT().GetGeneratorType().Return(simulatorDomain.GeneratorConsumer)
+	s.mockGenerator.EXPECT().GenerateMeasurement().RunAndReturn(func() (*measurements.Measurement, error) {
+		return &measurements.Measurement{Time: clock.Now()}, nil
+	})
+
+	var published []time.Time
+	s.publisherMock.EXPECT().Publish(mock.Anything, mock.Anything, "1").RunAndReturn(func(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+		published = append(published, measurement.Time)
+		return nil
+	})
+
+	// The runner stops at the end of the clock
+	s.NoError(simulator.Start(context.Background()))
+	s.Equal([]time.Time{
+		from,
+		from.Add(15 * time.Minute),
+		from.Add(30 * time.Minute),
+		from.Add(45 * time.Minute),
+		from.Add(time.Hour),
+	}, published)
+}
+
 func (s *runnerTestSuite) TestStop() {
 	s.T().Skip("Not fully working yet")
 	tests := []struct {
@@ -240,13 +393,13 @@ func (s *runnerTestSuite) TestStop() {
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
-			simulator, err := NewRunner(s.obs, tt.id, tt.interval, s.mockGenerator, s.publisherMock)
+			simulator, err := NewRunner(s.obs, tt.id, tt.interval, s.mockGenerator, s.publisherMock, SystemClock, nil)
 			s.Require().NoError(err)
 
 			switch tt.name {
 			case "Started and stopped":
 				measurement := measurements.Measurement{}
-				s.mockGenerator.EXPECT().GetEnergyType().Return(domain.EnergyTypeConsumer)
+				s.mockGenerator.EXPECT().GetGeneratorType().Return(simulatorDomain.GeneratorConsumer)
 				s.mockGenerator.EXPECT().GenerateMeasurement().Return(&measurement, nil)
 				s.publisherMock.EXPECT().Publish(mock.Anything, measurement, tt.id).Return(nil)
 
@@ -271,7 +424,7 @@ func (s *runnerTestSuite) TestStop() {
 				time.Sleep(tt.interval * 2)
 				s.False(simulator.IsRunning())
 
-				s.mockGenerator.AssertNumberOfCalls(t, "GetEnergyType", 1)
+				s.mockGenerator.AssertNumberOfCalls(t, "GetGeneratorType", 1)
 				s.mockGenerator.AssertNumberOfCalls(t, "GenerateMeasurement", 1)
 				s.publisherMock.AssertNumberOfCalls(t, "Publish", 1)
 			case "Runner not started":
@@ -2,8 +2,13 @@ package asset_simulation
 
 import (
 	"context"
+	errors2 "errors"
 	"sync"
 
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"asset-measurements-assignment/internal/domain/simulator/generator"
+	"asset-measurements-assignment/internal/pkg/metrics"
 	"github.com/pkg/errors"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.uber.org/zap"
@@ -14,13 +19,21 @@ type Runner interface {
 	Stop() error
 	GetId() string
 	IsRunning() bool
+	Pause() error
+	Resume() error
+	Restart() error
+	Tick(ctx context.Context) (*measurements.Measurement, error)
+	Status() simulator.Worker
 }
 
 var (
-	ErrWorkerAlreadyExists = errors.New("worker already exists")
-	ErrWorkerIdCantBeEmpty = errors.New("worker ID cannot be empty")
-	ErrWorkerDoesntExist   = errors.New("worker doesn't exist")
-	ErrUnableToStop        = errors.New("unable to stop worker")
+	ErrWorkerAlreadyExists  = errors.New("worker already exists")
+	ErrWorkerIdCantBeEmpty  = errors.New("worker ID cannot be empty")
+	ErrWorkerDoesntExist    = errors.New("worker doesn't exist")
+	ErrUnableToStop         = errors.New("unable to stop worker")
+	ErrWorkerNotRunning     = errors.New("worker is not running")
+	ErrWorkerAlreadyRunning = errors.New("worker is already running")
+	ErrManagerShutDown      = errors.New("worker manager is shut down")
 )
 
 // AssetSimulatorManager manages runners for asset simulation.
@@ -30,13 +43,66 @@ type AssetSimulatorManager struct {
 	mu      sync.Mutex
 	obs     observability.Observability
 	workers map[string]Runner
+	metrics *metrics.SimulatorMetrics
+	faults  *Faults
+
+	// Cancelled on shutdown, stops the workers regardless of the context they were started with
+	shutdownCtx context.Context
+	shutdown    context.CancelFunc
 }
 
-func NewAssetSimulatorManager(obs observability.Observability) *AssetSimulatorManager {
+func NewAssetSimulatorManager(obs observability.Observability, metrics *metrics.SimulatorMetrics) *AssetSimulatorManager {
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
 	return &AssetSimulatorManager{
-		obs:     obs,
-		workers: make(map[string]Runner),
+		obs:         obs,
+		workers:     make(map[string]Runner),
+		metrics:     metrics,
+		faults:      NewFaults(SystemClock, generator.NewSource(0)),
+		shutdownCtx: shutdownCtx,
+		shutdown:    shutdown,
+	}
+}
+
+// Metrics returns the metrics of the workers, recorded by the runners.
+func (wm *AssetSimulatorManager) Metrics() *metrics.SimulatorMetrics {
+	return wm.metrics
+}
+
+// Faults returns the faults injected into the workers, applied by the wrapped generators and publisher.
+func (wm *AssetSimulatorManager) Faults() *Faults {
+	return wm.faults
+}
+
+// track adds the workers about to start to the workers the shutdown waits for, unless the manager is shut down. It
+// must be called with the lock held, which the shutdown takes before it starts waiting.
+func (wm *AssetSimulatorManager) track(workers int) error {
+	if wm.shutdownCtx.Err() != nil {
+		return ErrManagerShutDown
+	}
+
+	wm.wg.Add(workers)
+	return nil
+}
+
+// runWorker runs the worker until it stops, its context is done or the manager shuts down.
+func (wm *AssetSimulatorManager) runWorker(ctx context.Context, workerId string, worker Runner) error {
+	defer wm.wg.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(wm.shutdownCtx, cancel)
+	defer stop()
+
+	wm.metrics.WorkerStarted()
+	defer wm.metrics.WorkerStopped()
+
+	wm.obs.Log().Debug("Starting worker", zap.String("workerId", workerId))
+	err := worker.Start(ctx)
+	if err != nil {
+		wm.obs.Log().With(zap.Error(err)).Error("Unable to start worker")
 	}
+
+	return err
 }
 
 // AddAndStartWorker adds a worker to the manager and starts it
@@ -61,18 +127,14 @@ func (wm *AssetSimulatorManager) AddAndStartWorker(ctx context.Context, worker R
 		}
 	}
 
+	err := wm.track(1)
+	if err != nil {
+		return err
+	}
+
 	// Start worker in a goroutine
 	wm.workers[workerId] = worker
-	wm.wg.Add(1)
-	go func() {
-		defer wm.wg.Done()
-
-		wm.obs.Log().Debug("Starting worker", zap.String("workerId", workerId))
-		err := worker.Start(ctx)
-		if err != nil {
-			wm.obs.Log().With(zap.Error(err)).Error("Unable to start worker")
-		}
-	}()
+	go wm.runWorker(ctx, workerId, worker)
 	return nil
 }
 
@@ -119,6 +181,30 @@ func (wm *AssetSimulatorManager) RemoveWorker(workerId string) error {
 	return nil
 }
 
+// RestartWorker restarts a running worker, or starts a stopped worker again.
+func (wm *AssetSimulatorManager) RestartWorker(workerId string) error {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	wm.obs.Log().Debug("Restarting worker", zap.String("workerId", workerId))
+
+	worker, ok := wm.workers[workerId]
+	if !ok {
+		return ErrWorkerDoesntExist
+	}
+
+	if worker.IsRunning() {
+		return worker.Restart()
+	}
+
+	err := wm.track(1)
+	if err != nil {
+		return err
+	}
+
+	go wm.runWorker(context.Background(), workerId, worker)
+	return nil
+}
+
 // GetWorker returns a worker by its ID
 func (wm *AssetSimulatorManager) GetWorker(workerId string) (Runner, bool) {
 	wm.mu.Lock()
@@ -141,25 +227,71 @@ func (wm *AssetSimulatorManager) GetWorkers() []Runner {
 	return workers
 }
 
+// RunningWorkers returns the number of running workers
+func (wm *AssetSimulatorManager) RunningWorkers() int {
+	running := 0
+	for _, worker := range wm.GetWorkers() {
+		if worker.IsRunning() {
+			running++
+		}
+	}
+
+	return running
+}
+
 // StartWorkers starts all workers, each worker in a separate goroutine
 func (wm *AssetSimulatorManager) StartWorkers(ctx context.Context) {
-	workers := wm.GetWorkers()
-	wm.wg.Add(len(workers))
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
 
-	wm.obs.Log().Info("Starting workers", zap.Int("count", len(workers)))
+	wm.obs.Log().Info("Starting workers", zap.Int("count", len(wm.workers)))
 
-	for _, worker := range workers {
+	err := wm.track(len(wm.workers))
+	if err != nil {
+		wm.obs.Log().With(zap.Error(err)).Error("Unable to start workers")
+		return
+	}
+
+	for workerId, worker := range wm.workers {
 		// Start worker in a goroutine
-		go func() {
-			defer wm.wg.Done()
+		go wm.runWorker(ctx, workerId, worker)
+	}
+}
 
-			wm.obs.Log().Debug("Starting worker", zap.String("workerId", worker.GetId()))
-			err := worker.Start(ctx)
-			if err != nil {
-				wm.obs.Log().With(zap.Error(err)).Error("Unable to start worker")
-			}
+// RunWorkers runs the workers until all of them stop, e.g. at the end of their virtual clocks. The workers are not added
+// to the manager, so they can run alongside the workers of the same assets. An error is returned if any of the workers
+// failed, or they were stopped because the context is done or the manager shuts down.
+func (wm *AssetSimulatorManager) RunWorkers(ctx context.Context, workers ...Runner) error {
+	wm.obs.Log().Info("Running workers", zap.Int("count", len(workers)))
+
+	wm.mu.Lock()
+	err := wm.track(len(workers))
+	wm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(workers))
+	var done sync.WaitGroup
+	done.Add(len(workers))
+
+	for i, worker := range workers {
+		go func() {
+			defer done.Done()
+			errs[i] = wm.runWorker(ctx, worker.GetId(), worker)
 		}()
 	}
+	done.Wait()
+
+	if err := wm.shutdownCtx.Err(); err != nil {
+		errs = append(errs, errors.New("workers stopped by the shutdown"))
+	}
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors2.Join(errs...)
 }
 
 // StopAll stops all workers
@@ -180,6 +312,29 @@ func (wm *AssetSimulatorManager) StopAll() {
 	wm.wg.Wait()
 }
 
+// Shutdown stops all workers, including the ones that are still starting, and waits until they return or the
+// context is done. Workers can't be started after the shutdown.
+func (wm *AssetSimulatorManager) Shutdown(ctx context.Context) error {
+	wm.mu.Lock()
+	wm.obs.Log().Info("Shutting down workers", zap.Int("count", len(wm.workers)))
+	// No workers are started after the shutdown, so the wait group isn't added to while waiting
+	wm.shutdown()
+	wm.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		wm.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "workers didn't stop in time")
+	}
+}
+
 // RemoveAll stops and removes all workers
 func (wm *AssetSimulatorManager) RemoveAll() {
 	wm.mu.Lock()
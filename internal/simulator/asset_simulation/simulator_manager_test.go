@@ -3,8 +3,10 @@ package asset_simulation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/xBlaz3kx/DevX/observability"
@@ -17,7 +19,7 @@ type simulatorManagerTestSuite struct {
 }
 
 func (s *simulatorManagerTestSuite) SetupTest() {
-	s.manager = NewAssetSimulatorManager(observability.NewNoopObservability())
+	s.manager = NewAssetSimulatorManager(observability.NewNoopObservability(), nil)
 	s.workerMock = NewMockRunner(s.T())
 }
 
@@ -187,6 +189,52 @@ func (s *simulatorManagerTestSuite) TestRemoveWorker() {
 	}
 }
 
+func (s *simulatorManagerTestSuite) TestRestartWorker() {
+	tests := []struct {
+		name     string
+		workerId string
+		err      error
+	}{
+		{
+			name:     "Should restart running worker",
+			workerId: "1",
+			err:      nil,
+		},
+		{
+			name:     "Should start stopped worker",
+			workerId: "2",
+			err:      nil,
+		},
+		{
+			name:     "Worker doesn't exist",
+			workerId: "3",
+			err:      ErrWorkerDoesntExist,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			switch tt.name {
+			case "Should restart running worker":
+				s.manager.workers[tt.workerId] = s.workerMock
+				s.workerMock.EXPECT().IsRunning().Return(true).Once()
+				s.workerMock.EXPECT().Restart().Return(nil).Once()
+			case "Should start stopped worker":
+				s.manager.workers[tt.workerId] = s.workerMock
+				s.workerMock.EXPECT().IsRunning().Return(false).Once()
+				s.workerMock.EXPECT().Start(mock.Anything).Return(nil).Once()
+			}
+
+			err := s.manager.RestartWorker(tt.workerId)
+			if tt.err == nil {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, tt.err)
+			}
+		})
+	}
+}
+
 func (s *simulatorManagerTestSuite) TestGetWorker() {
 	s.T().Skip()
 	tests := []struct {
@@ -327,6 +375,97 @@ func (s *simulatorManagerTestSuite) TestStopAll() {
 	}
 }
 
+func (s *simulatorManagerTestSuite) TestRunningWorkers() {
+	s.Equal(0, s.manager.RunningWorkers())
+
+	s.workerMock.EXPECT().IsRunning().Return(true)
+
+	stopped := NewMockRunner(s.T())
+	stopped.EXPECT().IsRunning().Return(false)
+	stopped.EXPECT().Stop().Return(nil).Maybe()
+
+	s.manager.workers["running"] = s.workerMock
+	s.manager.workers["stopped"] = stopped
+
+	s.Equal(1, s.manager.RunningWorkers())
+}
+
 func TestSimulatorManager(t *testing.T) {
 	suite.Run(t, new(simulatorManagerTestSuite))
 }
+
+func TestAssetSimulatorManager_Shutdown(t *testing.T) {
+	manager := NewAssetSimulatorManager(observability.NewNoopObservability(), nil)
+
+	// Started with a context that is never cancelled
+	worker := NewMockRunner(t)
+	worker.EXPECT().GetId().Return("1")
+	worker.EXPECT().Start(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	assert.NoError(t, manager.AddAndStartWorker(context.Background(), worker))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, manager.Shutdown(ctx))
+
+	// A worker that doesn't stop exceeds the deadline
+	manager = NewAssetSimulatorManager(observability.NewNoopObservability(), nil)
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	stuckWorker := NewMockRunner(t)
+	stuckWorker.EXPECT().GetId().Return("2")
+	stuckWorker.EXPECT().Start(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		<-stuck
+		return nil
+	})
+	assert.NoError(t, manager.AddAndStartWorker(context.Background(), stuckWorker))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, manager.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestAssetSimulatorManager_RunWorkers(t *testing.T) {
+	manager := NewAssetSimulatorManager(observability.NewNoopObservability(), nil)
+
+	// The workers stop by themselves, e.g. at the end of their virtual clocks
+	finished := NewMockRunner(t)
+	finished.EXPECT().GetId().Return("1")
+	finished.EXPECT().Start(mock.Anything).Return(nil)
+
+	failed := NewMockRunner(t)
+	failed.EXPECT().GetId().Return("2")
+	failed.EXPECT().Start(mock.Anything).Return(errors.New("failed"))
+
+	assert.NoError(t, manager.RunWorkers(context.Background(), finished))
+	assert.Error(t, manager.RunWorkers(context.Background(), finished, failed))
+
+	// The workers run alongside the workers of the manager
+	assert.Empty(t, manager.GetWorkers())
+
+	// The shutdown stops the workers and fails the run
+	started := make(chan struct{})
+	running := NewMockRunner(t)
+	running.EXPECT().GetId().Return("3")
+	running.EXPECT().Start(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- manager.RunWorkers(context.Background(), running)
+	}()
+
+	<-started
+	assert.NoError(t, manager.Shutdown(context.Background()))
+	assert.Error(t, <-done)
+
+	// No workers are started after the shutdown
+	assert.ErrorIs(t, manager.RunWorkers(context.Background(), NewMockRunner(t)), ErrManagerShutDown)
+	assert.ErrorIs(t, manager.AddAndStartWorker(context.Background(), finished), ErrManagerShutDown)
+}
@@ -0,0 +1,73 @@
+package assetservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/pkg/errors"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// requestTimeout limits the time to read the history of an asset.
+const requestTimeout = time.Minute
+
+// HistoryClient reads the measurements stored by the asset service over its HTTP API.
+type HistoryClient struct {
+	obs     observability.Observability
+	baseUrl string
+	client  *http.Client
+}
+
+// NewHistoryClient creates a client of the asset service at the given base URL, e.g. http://asset-service.
+func NewHistoryClient(obs observability.Observability, baseUrl string) *HistoryClient {
+	return &HistoryClient{
+		obs:     obs,
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// GetAssetMeasurements returns the measurements of the asset within the time range.
+func (h *HistoryClient) GetAssetMeasurements(ctx context.Context, assetId string, timeRange measurements.TimeRange) ([]measurements.Measurement, error) {
+	ctx, cancel := h.obs.Span(ctx, "assetservice.client.GetAssetMeasurements", zap.String("assetId", assetId))
+	defer cancel()
+
+	query := url.Values{}
+	if timeRange.From != nil {
+		query.Set("from", timeRange.From.Format(time.RFC3339Nano))
+	}
+	if timeRange.To != nil {
+		query.Set("to", timeRange.To.Format(time.RFC3339Nano))
+	}
+
+	requestUrl := fmt.Sprintf("%s/assets/%s/measurements?%s", h.baseUrl, url.PathEscape(assetId), query.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the history request")
+	}
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request the history")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("asset service responded with status %d", response.StatusCode)
+	}
+
+	var history []measurements.Measurement
+	err = json.NewDecoder(response.Body).Decode(&history)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the history")
+	}
+
+	return history, nil
+}
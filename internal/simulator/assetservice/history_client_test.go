@@ -0,0 +1,54 @@
+package assetservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xBlaz3kx/DevX/observability"
+)
+
+func TestHistoryClient_GetAssetMeasurements(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	stateOfEnergy := 50.0
+	history := []measurements.Measurement{
+		{Power: measurements.Power{Value: 100, Unit: measurements.UnitWatt}, Time: from},
+		{Power: measurements.Power{Value: 200, Unit: measurements.UnitWatt}, StateOfEnergy: &stateOfEnergy, Time: from.Add(time.Minute)},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/assets/asset-1/measurements":
+			assert.Equal(t, from.Format(time.RFC3339Nano), r.URL.Query().Get("from"))
+			assert.Equal(t, to.Format(time.RFC3339Nano), r.URL.Query().Get("to"))
+			_ = json.NewEncoder(w).Encode(history)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHistoryClient(observability.NewNoopObservability(), server.URL+"/")
+
+	t.Run("History of the asset", func(t *testing.T) {
+		result, err := client.GetAssetMeasurements(context.Background(), "asset-1", measurements.TimeRange{From: &from, To: &to})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, 200.0, result[1].Power.Value)
+		assert.Equal(t, &stateOfEnergy, result[1].StateOfEnergy)
+		assert.Nil(t, result[0].StateOfEnergy)
+		assert.True(t, from.Add(time.Minute).Equal(result[1].Time))
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		_, err := client.GetAssetMeasurements(context.Background(), "asset-2", measurements.TimeRange{From: &from, To: &to})
+		assert.Error(t, err)
+	})
+}
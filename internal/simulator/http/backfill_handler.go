@@ -0,0 +1,80 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/gin-gonic/gin"
+)
+
+type BackfillHandler struct {
+	service simulator.BackfillService
+}
+
+func NewBackfillHandler(service simulator.BackfillService) *BackfillHandler {
+	return &BackfillHandler{service: service}
+}
+
+func (b *BackfillHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/simulations/backfill", b.CreateBackfill)
+	router.GET("/simulations/backfill/:backfillId", b.GetBackfill)
+}
+
+// swagger:route POST /simulations/backfill simulator createBackfill
+// Simulate the measurements of the assets in a historical time window. The backfill runs in the background.
+// ---
+//
+//	Parameters:
+//	 + name: backfillRequest
+//	   in: body
+//	   required: true
+//	   type: CreateBackfill
+//
+//	 responses:
+//	   202: Backfill
+//	   400: errorResponse
+//	   404: errorResponse
+//	   500: errorResponse
+func (b *BackfillHandler) CreateBackfill(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	var request CreateBackfill
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	backfill, err := b.service.StartBackfill(reqCtx, request.toDomainRequest())
+	switch {
+	case errors.Is(err, simulator.ErrBackfillValidation):
+		ctx.JSON(badRequest(err))
+		return
+	case err != nil:
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, toBackfill(*backfill))
+}
+
+// swagger:route GET /simulations/backfill/{backfillId} simulator getBackfill
+// Get the progress of the backfill by id
+// ---
+//
+//	responses:
+//	  200: Backfill
+//	  404: errorResponse
+//	  500: errorResponse
+func (b *BackfillHandler) GetBackfill(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	backfillId := ctx.Param("backfillId")
+
+	backfill, err := b.service.GetBackfill(reqCtx, backfillId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toBackfill(*backfill))
+}
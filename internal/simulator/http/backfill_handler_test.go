@@ -0,0 +1,35 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	simulatorMock "asset-measurements-assignment/internal/domain/simulator/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackfillHandler_CreateBackfill(t *testing.T) {
+	service := simulatorMock.NewMockBackfillService(t)
+	router := gin.New()
+	NewBackfillHandler(service).RegisterRoutes(router)
+
+	// The cause of the validation error is returned to the client
+	service.EXPECT().StartBackfill(mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", simulator.ErrBackfillValidation, errors.New("speed must not be negative")))
+
+	req, _ := http.NewRequest(http.MethodPost, "/simulations/backfill",
+		bytes.NewBufferString(`{"assetIds":["1"],"from":"2024-01-01T00:00:00Z","to":"2024-01-02T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "speed must not be negative")
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// swagger:model
+type CreateBackfill struct {
+	// Assets to simulate with their current configurations
+	AssetIds []string `json:"assetIds" binding:"required,min=1,dive,required"`
+	// Window of the simulated measurements
+	// swagger:type string
+	From time.Time `json:"from" binding:"required"`
+	// swagger:type string
+	To time.Time `json:"to" binding:"required,gtfield=From"`
+	// Speed of the simulated time relative to the real time. Zero or omitted simulates the window as fast as possible.
+	Speed float64 `json:"speed,omitempty" binding:"gte=0"`
+}
+
+func (c CreateBackfill) toDomainRequest() simulator.BackfillRequest {
+	return simulator.BackfillRequest{
+		AssetIds: c.AssetIds,
+		From:     c.From,
+		To:       c.To,
+		Speed:    c.Speed,
+	}
+}
+
+// swagger:model
+type Backfill struct {
+	Id       string   `json:"id"`
+	AssetIds []string `json:"assetIds"`
+	// swagger:type string
+	From time.Time `json:"from"`
+	// swagger:type string
+	To    time.Time `json:"to"`
+	Speed float64   `json:"speed,omitempty"`
+
+	// State of the backfill: running, completed or failed
+	State string `json:"state"`
+	// swagger:type string
+	StartedAt time.Time `json:"startedAt"`
+	// swagger:type string
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Error of a failed backfill
+	Error string `json:"error,omitempty"`
+}
+
+func toBackfill(backfill simulator.Backfill) Backfill {
+	return Backfill{
+		Id:         backfill.Id,
+		AssetIds:   backfill.AssetIds,
+		From:       backfill.From,
+		To:         backfill.To,
+		Speed:      backfill.Speed,
+		State:      string(backfill.State),
+		StartedAt:  backfill.StartedAt,
+		FinishedAt: backfill.FinishedAt,
+		Error:      backfill.Error,
+	}
+}
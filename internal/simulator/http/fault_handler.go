@@ -0,0 +1,101 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/gin-gonic/gin"
+)
+
+type FaultHandler struct {
+	service simulator.FaultService
+}
+
+func NewFaultHandler(service simulator.FaultService) *FaultHandler {
+	return &FaultHandler{service: service}
+}
+
+func (f *FaultHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/assets/:assetId/faults", f.InjectFault)
+	router.GET("/assets/:assetId/faults", f.GetFaults)
+	router.DELETE("/assets/:assetId/faults", f.ClearFaults)
+}
+
+// swagger:route POST /assets/{assetId}/faults simulator injectFault
+// Switch the worker of the asset into a fault for a duration, to test the ingestion of faulty measurements.
+// ---
+//
+//	Parameters:
+//	 + name: faultRequest
+//	   in: body
+//	   required: true
+//	   type: CreateFault
+//
+//	 responses:
+//	   201: Fault
+//	   400: errorResponse
+//	   404: errorResponse
+//	   500: errorResponse
+func (f *FaultHandler) InjectFault(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	assetId := ctx.Param("assetId")
+
+	var request CreateFault
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	fault, err := f.service.InjectFault(reqCtx, assetId, request.toDomainFault())
+	switch {
+	case errors.Is(err, simulator.ErrFaultValidation):
+		ctx.JSON(badRequest(err))
+		return
+	case err != nil:
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toFault(*fault))
+}
+
+// swagger:route GET /assets/{assetId}/faults simulator getFaults
+// Get the faults of the worker of the asset which didn't end
+// ---
+//
+//	responses:
+//	  200: []Fault
+//	  500: errorResponse
+func (f *FaultHandler) GetFaults(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	assetId := ctx.Param("assetId")
+
+	faults, err := f.service.GetFaults(reqCtx, assetId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toFaults(faults))
+}
+
+// swagger:route DELETE /assets/{assetId}/faults simulator clearFaults
+// End all the faults of the worker of the asset
+// ---
+//
+//	responses:
+//	  204:
+//	  500: errorResponse
+func (f *FaultHandler) ClearFaults(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	assetId := ctx.Param("assetId")
+
+	err := f.service.ClearFaults(reqCtx, assetId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
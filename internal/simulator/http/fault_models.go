@@ -0,0 +1,64 @@
+package http
+
+import (
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// swagger:model
+type CreateFault struct {
+	// Type of the fault: stuck, drift, spikes, dropped, duplicated, outOfOrder, malformedJson or wrongContentType
+	Type string `json:"type" binding:"required,oneof=stuck drift spikes dropped duplicated outOfOrder malformedJson wrongContentType"`
+	// Duration of the fault in nanoseconds
+	Duration time.Duration `json:"duration" binding:"required,gt=0"`
+	// Fraction of the samples affected by the sample faults, between 0 and 1. Defaults to 0.1 for spikes and to 1 for
+	// the other faults.
+	Probability float64 `json:"probability,omitempty" binding:"gte=0,lte=1"`
+	// Magnitude of the drift in W per minute or of the spikes in W
+	Magnitude float64 `json:"magnitude,omitempty" binding:"required_if=Type drift,required_if=Type spikes"`
+}
+
+func (c CreateFault) toDomainFault() simulator.Fault {
+	return simulator.Fault{
+		Type:        simulator.FaultType(c.Type),
+		Duration:    c.Duration,
+		Probability: c.Probability,
+		Magnitude:   c.Magnitude,
+	}
+}
+
+// swagger:model
+type Fault struct {
+	AssetId     string        `json:"assetId"`
+	Type        string        `json:"type"`
+	Duration    time.Duration `json:"duration"`
+	Probability float64       `json:"probability,omitempty"`
+	Magnitude   float64       `json:"magnitude,omitempty"`
+
+	// swagger:type string
+	StartedAt time.Time `json:"startedAt"`
+	// swagger:type string
+	Until time.Time `json:"until"`
+}
+
+func toFault(fault simulator.ActiveFault) Fault {
+	return Fault{
+		AssetId:     fault.AssetId,
+		Type:        string(fault.Type),
+		Duration:    fault.Duration,
+		Probability: fault.Probability,
+		Magnitude:   fault.Magnitude,
+		StartedAt:   fault.StartedAt,
+		Until:       fault.Until,
+	}
+}
+
+func toFaults(faults []simulator.ActiveFault) []Fault {
+	result := make([]Fault, 0, len(faults))
+	for _, fault := range faults {
+		result = append(result, toFault(fault))
+	}
+
+	return result
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/gin-gonic/gin"
+)
+
+// maxRecordingSize limits the size of the uploaded recording.
+const maxRecordingSize = 64 << 20
+
+type RecordingHandler struct {
+	service simulator.RecordingService
+}
+
+func NewRecordingHandler(service simulator.RecordingService) *RecordingHandler {
+	return &RecordingHandler{service: service}
+}
+
+func (r *RecordingHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/recordings", r.CreateRecording)
+	router.GET("/recordings/:recordingId", r.GetRecording)
+}
+
+// swagger:route POST /recordings simulator createRecording
+// Upload a recorded time series, which can be replayed by the simulator.
+// ---
+// consumes:
+//   - application/x-ndjson
+//   - text/csv
+//
+// responses:
+//
+//	201: Recording
+//	400: errorResponse
+//	413: errorResponse
+//	415: errorResponse
+//	500: errorResponse
+func (r *RecordingHandler) CreateRecording(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	var params CreateRecordingParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxRecordingSize)
+	rows, err := measurements.ParseImport(ctx.GetHeader("Content-Type"), body)
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		_ = ctx.Error(measurements.ErrImportTooLarge)
+		return
+	case errors.Is(err, measurements.ErrUnsupportedImportFormat), errors.Is(err, measurements.ErrImportTooLarge):
+		_ = ctx.Error(err)
+		return
+	case err != nil:
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	recording, err := r.service.CreateRecording(reqCtx, params.Name, rows)
+	switch {
+	case errors.Is(err, simulator.ErrInvalidRecording):
+		// Report the invalid row
+		ctx.JSON(badRequest(err))
+		return
+	case err != nil:
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toRecording(*recording))
+}
+
+// swagger:route GET /recordings/{recordingId} simulator getRecording
+// Get the recording by id, without the measurements.
+// ---
+// responses:
+//
+//	200: Recording
+//	404: errorResponse
+//	500: errorResponse
+func (r *RecordingHandler) GetRecording(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	recordingId := ctx.Param("recordingId")
+
+	recording, err := r.service.GetRecording(reqCtx, recordingId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toRecording(*recording))
+}
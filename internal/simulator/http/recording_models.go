@@ -0,0 +1,48 @@
+package http
+
+import (
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// swagger:parameters createRecording
+type CreateRecordingParams struct {
+	// Name of the recording
+	// in: query
+	Name string `form:"name"`
+}
+
+// swagger:model
+type Recording struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// swagger:type string
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Number of recorded measurements
+	Measurements int `json:"measurements"`
+
+	// Time of the first and the last recorded measurement
+	// swagger:type string
+	From time.Time `json:"from"`
+	// swagger:type string
+	To time.Time `json:"to"`
+}
+
+func toRecording(recording simulator.Recording) Recording {
+	result := Recording{
+		Id:           recording.Id,
+		Name:         recording.Name,
+		CreatedAt:    recording.CreatedAt,
+		Measurements: len(recording.Measurements),
+	}
+
+	if len(recording.Measurements) > 0 {
+		result.From = recording.Measurements[0].Time
+		result.To = recording.Measurements[len(recording.Measurements)-1].Time
+	}
+
+	return result
+}
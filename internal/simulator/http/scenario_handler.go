@@ -0,0 +1,191 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/gin-gonic/gin"
+)
+
+type ScenarioHandler struct {
+	service simulator.ScenarioService
+}
+
+func NewScenarioHandler(service simulator.ScenarioService) *ScenarioHandler {
+	return &ScenarioHandler{service: service}
+}
+
+func (s *ScenarioHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/scenarios", s.CreateScenario)
+	router.GET("/scenarios", s.GetScenarios)
+	router.GET("/scenarios/:scenarioId", s.GetScenario)
+	router.PUT("/scenarios/:scenarioId", s.UpdateScenario)
+	router.DELETE("/scenarios/:scenarioId", s.DeleteScenario)
+	router.POST("/scenarios/:scenarioId/run", s.RunScenario)
+}
+
+// swagger:route POST /scenarios simulator createScenario
+// Create a scenario of timed events, which can be run on top of the simulated assets.
+// ---
+// consumes:
+//   - application/yaml
+//   - application/json
+//
+// responses:
+//
+//	201: Scenario
+//	400: errorResponse
+//	500: errorResponse
+func (s *ScenarioHandler) CreateScenario(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	var request CreateScenario
+	if err := bindScenario(ctx, &request); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	scenario, err := s.service.CreateScenario(reqCtx, request.toDomainScenario(""))
+	if err != nil {
+		handleScenarioError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toScenario(*scenario))
+}
+
+// swagger:route GET /scenarios simulator getScenarios
+// Get all scenarios
+// ---
+//
+//	responses:
+//	  200: []Scenario
+//	  500: errorResponse
+func (s *ScenarioHandler) GetScenarios(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	scenarios, err := s.service.GetScenarios(reqCtx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScenarios(scenarios))
+}
+
+// swagger:route GET /scenarios/{scenarioId} simulator getScenario
+// Get the scenario by id
+// ---
+//
+//	responses:
+//	  200: Scenario
+//	  404: errorResponse
+//	  500: errorResponse
+func (s *ScenarioHandler) GetScenario(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	scenarioId := ctx.Param("scenarioId")
+
+	scenario, err := s.service.GetScenario(reqCtx, scenarioId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScenario(*scenario))
+}
+
+// swagger:route PUT /scenarios/{scenarioId} simulator updateScenario
+// Replace the scenario. The assets already running the scenario keep the previous version until it is run again.
+// ---
+// consumes:
+//   - application/yaml
+//   - application/json
+//
+// responses:
+//
+//	200: Scenario
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+func (s *ScenarioHandler) UpdateScenario(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	scenarioId := ctx.Param("scenarioId")
+
+	var request CreateScenario
+	if err := bindScenario(ctx, &request); err != nil {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	scenario, err := s.service.UpdateScenario(reqCtx, request.toDomainScenario(scenarioId))
+	if err != nil {
+		handleScenarioError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScenario(*scenario))
+}
+
+// swagger:route DELETE /scenarios/{scenarioId} simulator deleteScenario
+// Delete the scenario. The assets already running the scenario keep running it.
+// ---
+//
+//	responses:
+//	  204:
+//	  404: errorResponse
+//	  500: errorResponse
+func (s *ScenarioHandler) DeleteScenario(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	scenarioId := ctx.Param("scenarioId")
+
+	err := s.service.DeleteScenario(reqCtx, scenarioId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// swagger:route POST /scenarios/{scenarioId}/run simulator runScenario
+// Run the scenario on top of the simulated assets of the scenario, replacing their workers.
+// ---
+//
+//	responses:
+//	  200: ScenarioRun
+//	  400: errorResponse
+//	  404: errorResponse
+//	  500: errorResponse
+func (s *ScenarioHandler) RunScenario(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	scenarioId := ctx.Param("scenarioId")
+
+	run, err := s.service.RunScenario(reqCtx, scenarioId)
+	if err != nil {
+		handleScenarioError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScenarioRun(*run))
+}
+
+// bindScenario binds the scenario in the YAML scenario format or as JSON.
+func bindScenario(ctx *gin.Context, request *CreateScenario) error {
+	switch ctx.ContentType() {
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return ctx.ShouldBindYAML(request)
+	default:
+		return ctx.ShouldBindJSON(request)
+	}
+}
+
+// handleScenarioError reports the reason of an invalid scenario.
+func handleScenarioError(ctx *gin.Context, err error) {
+	if errors.Is(err, simulator.ErrScenarioValidation) {
+		ctx.JSON(badRequest(err))
+		return
+	}
+
+	_ = ctx.Error(err)
+}
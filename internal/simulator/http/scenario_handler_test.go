@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	simulatorMock "asset-measurements-assignment/internal/domain/simulator/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScenarioHandler_CreateScenario(t *testing.T) {
+	power := 200.0
+	expected := simulator.Scenario{
+		Name:     "Passing cloud",
+		AssetIds: []string{"1", "2"},
+		Duration: time.Hour,
+		Loop:     true,
+		Events: []simulator.ScenarioEvent{
+			{At: 5 * time.Minute, Action: simulator.ActionSetPower, Power: &power, Duration: 10 * time.Minute},
+			{At: 20 * time.Minute, Action: simulator.ActionNoise, Noise: 50},
+			{At: 30 * time.Minute, Action: simulator.ActionConfig, Config: map[string]any{
+				"solar": map[string]any{"cloudCover": 0.8},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		contentType  string
+		requestBody  string
+		expectedCode int
+	}{
+		{
+			name:        "YAML scenario",
+			contentType: "application/yaml",
+			requestBody: `
+name: Passing cloud
+assetIds: ["1", "2"]
+duration: 1h
+loop: true
+events:
+  - at: 5m
+    action: setPower
+    power: 200
+    duration: 10m
+  - at: 20m
+    action: noise
+    noise: 50
+  - at: 30m
+    action: config
+    config:
+      solar:
+        cloudCover: 0.8
+`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "JSON scenario",
+			contentType: "application/json",
+			requestBody: fmt.Sprintf(`{"name":"Passing cloud","assetIds":["1","2"],"duration":%d,"loop":true,"events":[`+
+				`{"at":%d,"action":"setPower","power":200,"duration":%d},`+
+				`{"at":%d,"action":"noise","noise":50},`+
+				`{"at":%d,"action":"config","config":{"solar":{"cloudCover":0.8}}}]}`,
+				time.Hour, 5*time.Minute, 10*time.Minute, 20*time.Minute, 30*time.Minute),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "Missing power",
+			contentType: "application/yaml",
+			requestBody: `
+name: Outage
+assetIds: ["1"]
+events:
+  - at: 5m
+    action: setPower
+`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid action",
+			contentType: "application/yaml",
+			requestBody: `
+name: Outage
+assetIds: ["1"]
+events:
+  - at: 5m
+    action: explode
+`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := simulatorMock.NewMockScenarioService(t)
+			router := gin.New()
+			NewScenarioHandler(service).RegisterRoutes(router)
+
+			if tt.expectedCode == http.StatusCreated {
+				service.EXPECT().CreateScenario(mock.Anything, expected).Return(&expected, nil)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/scenarios", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// swagger:model
+type CreateScenario struct {
+	Name        string `json:"name" yaml:"name" binding:"required"`
+	Description string `json:"description,omitempty" yaml:"description"`
+
+	// Assets whose workers run the scenario
+	AssetIds []string `json:"assetIds" yaml:"assetIds" binding:"required,min=1,dive,required"`
+
+	// Duration of the scenario, after which the assets return to their configurations. Without it, the assets stay in
+	// the state of the last events.
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration" binding:"gte=0"`
+
+	// Start the scenario over at the end, requires the duration
+	Loop bool `json:"loop,omitempty" yaml:"loop" binding:"excluded_without=Duration"`
+
+	Events []ScenarioEvent `json:"events" yaml:"events" binding:"required,min=1,dive"`
+}
+
+// swagger:model
+type ScenarioEvent struct {
+	// Time of the event since the start of the scenario
+	At time.Duration `json:"at" yaml:"at" binding:"gte=0"`
+
+	// Action of the event: setPower, ramp, freeze, offline, noise or config
+	Action string `json:"action" yaml:"action" binding:"required,oneof=setPower ramp freeze offline noise config"`
+
+	// Duration of the event. Without it, the event lasts until the end of the scenario.
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration" binding:"gte=0,required_if=Action ramp"`
+
+	// Power in W set by setPower and reached by the ramp
+	Power *float64 `json:"power,omitempty" yaml:"power" binding:"required_if=Action setPower,required_if=Action ramp"`
+
+	// Power in W the ramp starts at, defaults to the power at the start of the ramp
+	From *float64 `json:"from,omitempty" yaml:"from"`
+
+	// Standard deviation of the noise in W
+	Noise float64 `json:"noise,omitempty" yaml:"noise" binding:"gte=0,required_if=Action noise"`
+
+	// Fields of the configuration changed by the config event
+	Config map[string]any `json:"config,omitempty" yaml:"config" binding:"required_if=Action config"`
+}
+
+func (c CreateScenario) toDomainScenario(scenarioId string) simulator.Scenario {
+	events := make([]simulator.ScenarioEvent, 0, len(c.Events))
+	for _, event := range c.Events {
+		events = append(events, simulator.ScenarioEvent{
+			At:       event.At,
+			Action:   simulator.ScenarioAction(event.Action),
+			Duration: event.Duration,
+			Power:    event.Power,
+			From:     event.From,
+			Noise:    event.Noise,
+			Config:   event.Config,
+		})
+	}
+
+	return simulator.Scenario{
+		Id:          scenarioId,
+		Name:        c.Name,
+		Description: c.Description,
+		AssetIds:    c.AssetIds,
+		Duration:    c.Duration,
+		Loop:        c.Loop,
+		Events:      events,
+	}
+}
+
+// swagger:model
+type Scenario struct {
+	Id string `json:"id"`
+	CreateScenario
+
+	// swagger:type string
+	CreatedAt time.Time `json:"createdAt"`
+	// swagger:type string
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func toScenario(scenario simulator.Scenario) Scenario {
+	events := make([]ScenarioEvent, 0, len(scenario.Events))
+	for _, event := range scenario.Events {
+		events = append(events, ScenarioEvent{
+			At:       event.At,
+			Action:   string(event.Action),
+			Duration: event.Duration,
+			Power:    event.Power,
+			From:     event.From,
+			Noise:    event.Noise,
+			Config:   event.Config,
+		})
+	}
+
+	return Scenario{
+		Id: scenario.Id,
+		CreateScenario: CreateScenario{
+			Name:        scenario.Name,
+			Description: scenario.Description,
+			AssetIds:    scenario.AssetIds,
+			Duration:    scenario.Duration,
+			Loop:        scenario.Loop,
+			Events:      events,
+		},
+		CreatedAt: scenario.CreatedAt,
+		UpdatedAt: scenario.UpdatedAt,
+	}
+}
+
+func toScenarios(scenarios []simulator.Scenario) []Scenario {
+	result := make([]Scenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		result = append(result, toScenario(scenario))
+	}
+
+	return result
+}
+
+// swagger:model
+type ScenarioRun struct {
+	ScenarioId string `json:"scenarioId"`
+	// Assets whose workers run the scenario
+	AssetIds []string `json:"assetIds"`
+	// swagger:type string
+	StartedAt time.Time `json:"startedAt"`
+}
+
+func toScenarioRun(run simulator.ScenarioRun) ScenarioRun {
+	return ScenarioRun{
+		ScenarioId: run.ScenarioId,
+		AssetIds:   run.AssetIds,
+		StartedAt:  run.StartedAt,
+	}
+}
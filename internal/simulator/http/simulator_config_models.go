@@ -17,15 +17,262 @@ type Configuration struct {
 	MaxPower            float64       `json:"maxPower"`
 	MinPower            float64       `json:"minPower"`
 	MaxPowerStep        float64       `json:"maxPowerStep"`
+	Seed                int64         `json:"seed,omitempty"`
+
+	Mode   string            `json:"mode,omitempty"`
+	Replay *ReplayParameters `json:"replay,omitempty"`
+
+	Solar     *SolarParameters   `json:"solar,omitempty"`
+	Wind      *WindParameters    `json:"wind,omitempty"`
+	Battery   *BatteryParameters `json:"battery,omitempty"`
+	Heater    *HeaterParameters  `json:"heater,omitempty"`
+	Motor     *MotorParameters   `json:"motor,omitempty"`
+	LoadShape *LoadShape         `json:"loadShape,omitempty"`
 }
 
 // swagger:model
 type CreateConfiguration struct {
 	Type                string        `json:"type" binding:"required,oneof=battery solar wind motor hydro_turbine heat_turbine"`
 	MeasurementInterval time.Duration `json:"measurementInterval" binding:"required,gte=100ms"`
-	MaxPower            float64       `json:"maxPower" binding:"required"`
-	MinPower            float64       `json:"minPower" binding:"required"`
-	MaxPowerStep        float64       `json:"maxPowerStep" binding:"required"`
+	// The replay doesn't use the power bounds
+	MaxPower float64 `json:"maxPower" binding:"required_unless=Mode replay"`
+	// The solar and wind generators don't use the minimum power, and the consumer profiles use it as the optional
+	// standby power. Only the random walk uses the power step.
+	MinPower     float64 `json:"minPower" binding:"required_unless=Type solar|required_unless=Type wind|required_unless=Type motor|required_unless=Type heater|required_unless=Mode replay"`
+	MaxPowerStep float64 `json:"maxPowerStep" binding:"required_unless=Type solar|required_unless=Type wind|required_unless=Type motor|required_unless=Type heater|required_unless=Mode replay"`
+
+	// Seed of the random numbers, so the simulation can be reproduced. A random seed is assigned if not set.
+	Seed int64 `json:"seed,omitempty"`
+
+	// Generate the measurements with the model of the asset type (default) or replay recorded measurements
+	Mode string `json:"mode,omitempty" binding:"omitempty,oneof=model replay"`
+	// Recorded measurements to replay, required in the replay mode
+	Replay *ReplayParameters `json:"replay,omitempty" binding:"required_if=Mode replay,omitempty"`
+
+	// Location and panels of a solar asset
+	Solar *SolarParameters `json:"solar,omitempty" binding:"omitempty"`
+	// Wind and power curve of a wind asset
+	Wind *WindParameters `json:"wind,omitempty" binding:"omitempty"`
+	// Storage of a battery
+	Battery *BatteryParameters `json:"battery,omitempty" binding:"omitempty"`
+	// Thermostat and room of a heater
+	Heater *HeaterParameters `json:"heater,omitempty" binding:"omitempty"`
+	// Start/stop cycles of a motor
+	Motor *MotorParameters `json:"motor,omitempty" binding:"omitempty"`
+	// Daily and weekly load shape of a consumer, replacing its model
+	LoadShape *LoadShape `json:"loadShape,omitempty" binding:"omitempty"`
+}
+
+// swagger:model
+type ReplayParameters struct {
+	// Source of the recorded measurements: an uploaded recording or the history of the asset service
+	Source string `json:"source" binding:"required,oneof=recording history"`
+	// Recording to replay from the recording source
+	RecordingId string `json:"recordingId,omitempty" binding:"required_if=Source recording"`
+	// Asset whose history is replayed, defaults to the simulated asset
+	AssetId string `json:"assetId,omitempty"`
+	// Time range of the replayed history, required for the history source
+	// swagger:type string
+	From time.Time `json:"from,omitempty" binding:"required_if=Source history"`
+	// swagger:type string
+	To time.Time `json:"to,omitempty" binding:"required_if=Source history"`
+	// Speed of the replay relative to the recorded speed, defaults to 1
+	Speed float64 `json:"speed,omitempty" binding:"gte=0"`
+	// Start the replay over after the last measurement
+	Loop bool `json:"loop,omitempty"`
+	// Move the replayed measurements to the time of the replay
+	ShiftToNow bool `json:"shiftToNow,omitempty"`
+}
+
+func (p *ReplayParameters) toDomainParameters() *simulator.ReplayParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.ReplayParameters{
+		Source:      simulator.ReplaySourceType(p.Source),
+		RecordingId: p.RecordingId,
+		AssetId:     p.AssetId,
+		From:        p.From,
+		To:          p.To,
+		Speed:       p.Speed,
+		Loop:        p.Loop,
+		ShiftToNow:  p.ShiftToNow,
+	}
+}
+
+// swagger:model
+type SolarParameters struct {
+	// Latitude of the panels in degrees, positive to the north
+	Latitude float64 `json:"latitude" binding:"gte=-90,lte=90"`
+	// Longitude of the panels in degrees, positive to the east
+	Longitude float64 `json:"longitude" binding:"gte=-180,lte=180"`
+	// Peak power of the panels in W, defaults to the absolute maxPower
+	Capacity float64 `json:"capacity,omitempty" binding:"gte=0"`
+	// Average fraction of the sky covered by clouds, between 0 and 1
+	CloudCover float64 `json:"cloudCover,omitempty" binding:"gte=0,lte=1"`
+	// Standard deviation of the cloud cover, between 0 and 1
+	CloudVariability float64 `json:"cloudVariability,omitempty" binding:"gte=0,lte=1"`
+}
+
+func (p *SolarParameters) toDomainParameters() *simulator.SolarParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.SolarParameters{
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		Capacity:         p.Capacity,
+		CloudCover:       p.CloudCover,
+		CloudVariability: p.CloudVariability,
+	}
+}
+
+// swagger:model
+type WindParameters struct {
+	// Average wind speed in m/s
+	MeanWindSpeed float64 `json:"meanWindSpeed,omitempty" binding:"gte=0"`
+	// Shape of the Weibull distribution of the wind speed
+	WeibullShape float64 `json:"weibullShape,omitempty" binding:"gte=0"`
+	// Time after which the wind speed mostly forgets its previous value
+	CorrelationTime time.Duration `json:"correlationTime,omitempty" binding:"gte=0"`
+	// Wind speed in m/s at which the turbine starts producing
+	CutInSpeed float64 `json:"cutInSpeed,omitempty" binding:"gte=0"`
+	// Wind speed in m/s at which the turbine reaches the rated power
+	RatedSpeed float64 `json:"ratedSpeed,omitempty" binding:"gte=0"`
+	// Wind speed in m/s at which the turbine stops to protect itself
+	CutOutSpeed float64 `json:"cutOutSpeed,omitempty" binding:"gte=0"`
+}
+
+func (p *WindParameters) toDomainParameters() *simulator.WindParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.WindParameters{
+		MeanWindSpeed:   p.MeanWindSpeed,
+		WeibullShape:    p.WeibullShape,
+		CorrelationTime: p.CorrelationTime,
+		CutInSpeed:      p.CutInSpeed,
+		RatedSpeed:      p.RatedSpeed,
+		CutOutSpeed:     p.CutOutSpeed,
+	}
+}
+
+// swagger:model
+type BatteryParameters struct {
+	// Energy the battery stores in Wh, defaults to one hour at the largest absolute power bound
+	Capacity float64 `json:"capacity,omitempty" binding:"gte=0"`
+	// Fraction of the charging energy which is stored, between 0 and 1
+	ChargeEfficiency float64 `json:"chargeEfficiency,omitempty" binding:"gte=0,lte=1"`
+	// Fraction of the stored energy which is delivered when discharging, between 0 and 1
+	DischargeEfficiency float64 `json:"dischargeEfficiency,omitempty" binding:"gte=0,lte=1"`
+	// State of energy in % at the start of the simulation, defaults to minStateOfEnergy
+	InitialStateOfEnergy float64 `json:"initialStateOfEnergy,omitempty" binding:"gte=0,lte=100"`
+	// State of energy in % below which the battery doesn't discharge
+	MinStateOfEnergy float64 `json:"minStateOfEnergy,omitempty" binding:"gte=0,lte=100"`
+	// State of energy in % above which the battery doesn't charge, defaults to 100
+	MaxStateOfEnergy float64 `json:"maxStateOfEnergy,omitempty" binding:"gte=0,lte=100"`
+	// Limits the power to cRate times the capacity
+	CRate float64 `json:"cRate,omitempty" binding:"gte=0"`
+}
+
+func (p *BatteryParameters) toDomainParameters() *simulator.BatteryParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.BatteryParameters{
+		Capacity:             p.Capacity,
+		ChargeEfficiency:     p.ChargeEfficiency,
+		DischargeEfficiency:  p.DischargeEfficiency,
+		InitialStateOfEnergy: p.InitialStateOfEnergy,
+		MinStateOfEnergy:     p.MinStateOfEnergy,
+		MaxStateOfEnergy:     p.MaxStateOfEnergy,
+		CRate:                p.CRate,
+	}
+}
+
+// swagger:model
+type HeaterParameters struct {
+	// Indoor temperature in °C the thermostat keeps, defaults to 21
+	SetPoint float64 `json:"setPoint,omitempty"`
+	// Width of the band around the set point in °C, in which the thermostat doesn't switch, defaults to 1
+	Hysteresis float64 `json:"hysteresis,omitempty" binding:"gte=0"`
+	// Average outdoor temperature in °C
+	OutdoorTemperature float64 `json:"outdoorTemperature"`
+	// Difference in °C between the average and the warmest outdoor temperature of the day
+	OutdoorAmplitude float64 `json:"outdoorAmplitude" binding:"gte=0"`
+	// Heat the room loses in W per °C of difference to the outdoor temperature
+	HeatLoss float64 `json:"heatLoss,omitempty" binding:"gte=0"`
+	// Time after which the room mostly cools down to the outdoor temperature, defaults to 10 hours
+	ThermalTimeConstant time.Duration `json:"thermalTimeConstant,omitempty" binding:"gte=0"`
+}
+
+func (p *HeaterParameters) toDomainParameters() *simulator.HeaterParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.HeaterParameters{
+		SetPoint:            p.SetPoint,
+		Hysteresis:          p.Hysteresis,
+		OutdoorTemperature:  p.OutdoorTemperature,
+		OutdoorAmplitude:    p.OutdoorAmplitude,
+		HeatLoss:            p.HeatLoss,
+		ThermalTimeConstant: p.ThermalTimeConstant,
+	}
+}
+
+// swagger:model
+type MotorParameters struct {
+	// Power in W of the running motor, defaults to maxPower divided by the inrush factor
+	RatedPower float64 `json:"ratedPower,omitempty" binding:"gte=0"`
+	// Average time the motor runs, defaults to 30 minutes
+	OnDuration time.Duration `json:"onDuration,omitempty" binding:"gte=0"`
+	// Average time the motor is stopped, defaults to 30 minutes
+	OffDuration time.Duration `json:"offDuration,omitempty" binding:"gte=0"`
+	// Peak power at the start relative to the rated power, defaults to 5
+	InrushFactor float64 `json:"inrushFactor,omitempty" binding:"omitempty,gte=1"`
+	// Time constant of the decay of the inrush peak, defaults to 2 seconds
+	InrushDuration time.Duration `json:"inrushDuration,omitempty" binding:"gte=0"`
+}
+
+func (p *MotorParameters) toDomainParameters() *simulator.MotorParameters {
+	if p == nil {
+		return nil
+	}
+
+	return &simulator.MotorParameters{
+		RatedPower:     p.RatedPower,
+		OnDuration:     p.OnDuration,
+		OffDuration:    p.OffDuration,
+		InrushFactor:   p.InrushFactor,
+		InrushDuration: p.InrushDuration,
+	}
+}
+
+// swagger:model
+type LoadShape struct {
+	// Fractions of the power range, spread evenly over the day starting at midnight UTC
+	Daily []float64 `json:"daily" binding:"required,min=1,dive,gte=0,lte=1"`
+	// Factors multiplying the daily fractions, from Monday to Sunday
+	Weekly []float64 `json:"weekly,omitempty" binding:"omitempty,len=7,dive,gte=0,lte=1"`
+	// Standard deviation of the random deviation from the shape, as a fraction of the power range
+	Noise float64 `json:"noise,omitempty" binding:"gte=0,lte=1"`
+}
+
+func (s *LoadShape) toDomainLoadShape() *simulator.LoadShape {
+	if s == nil {
+		return nil
+	}
+
+	return &simulator.LoadShape{
+		Daily:  s.Daily,
+		Weekly: s.Weekly,
+		Noise:  s.Noise,
+	}
 }
 
 func (c CreateConfiguration) toDomainConfiguration(assetId string) simulator.Configuration {
@@ -36,6 +283,15 @@ func (c CreateConfiguration) toDomainConfiguration(assetId string) simulator.Con
 		MaxPower:            c.MaxPower,
 		MinPower:            c.MinPower,
 		MaxPowerStep:        c.MaxPowerStep,
+		Seed:                c.Seed,
+		Mode:                simulator.SimulationMode(c.Mode),
+		Replay:              c.Replay.toDomainParameters(),
+		Solar:               c.Solar.toDomainParameters(),
+		Wind:                c.Wind.toDomainParameters(),
+		Battery:             c.Battery.toDomainParameters(),
+		Heater:              c.Heater.toDomainParameters(),
+		Motor:               c.Motor.toDomainParameters(),
+		LoadShape:           c.LoadShape.toDomainLoadShape(),
 	}
 	return cfg
 }
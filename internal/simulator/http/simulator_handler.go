@@ -15,7 +15,7 @@ func NewSimulatorConfigHandler(service simulator.ConfigService) *SimulatorConfig
 	return &SimulatorConfigHandler{service: service}
 }
 
-func (d *SimulatorConfigHandler) RegisterRoutes(router *gin.Engine) {
+func (d *SimulatorConfigHandler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/assets/:assetId/config", d.CreateAssetConfig)
 	router.GET("/assets/:assetId/config", d.GetCurrentAssetConfig)
 	router.DELETE("/assets/:assetId/config/:configId", d.DeleteConfiguration)
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/gin-gonic/gin"
+)
+
+type WorkerHandler struct {
+	service simulator.WorkerService
+}
+
+func NewWorkerHandler(service simulator.WorkerService) *WorkerHandler {
+	return &WorkerHandler{service: service}
+}
+
+func (w *WorkerHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/workers", w.GetWorkers)
+	router.POST("/workers/:workerId/pause", w.PauseWorker)
+	router.POST("/workers/:workerId/resume", w.ResumeWorker)
+	router.POST("/workers/:workerId/restart", w.RestartWorker)
+	router.POST("/workers/:workerId/tick", w.Tick)
+}
+
+// swagger:route GET /workers simulator getWorkers
+// Get the workers with their state, last measurement and number of publishing errors
+// ---
+//
+//	responses:
+//	  200: []Worker
+//	  500: errorResponse
+func (w *WorkerHandler) GetWorkers(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+
+	workers, err := w.service.GetWorkers(reqCtx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toWorkers(workers))
+}
+
+// swagger:route POST /workers/{workerId}/pause simulator pauseWorker
+// Pause the worker, which stops generating measurements until it is resumed
+// ---
+//
+//	responses:
+//	  200: Worker
+//	  404: errorResponse
+//	  409: errorResponse
+//	  500: errorResponse
+func (w *WorkerHandler) PauseWorker(ctx *gin.Context) {
+	w.control(ctx, w.service.PauseWorker)
+}
+
+// swagger:route POST /workers/{workerId}/resume simulator resumeWorker
+// Resume the paused worker
+// ---
+//
+//	responses:
+//	  200: Worker
+//	  404: errorResponse
+//	  409: errorResponse
+//	  500: errorResponse
+func (w *WorkerHandler) ResumeWorker(ctx *gin.Context) {
+	w.control(ctx, w.service.ResumeWorker)
+}
+
+// swagger:route POST /workers/{workerId}/restart simulator restartWorker
+// Restart the worker, or start it if it is stopped
+// ---
+//
+//	responses:
+//	  200: Worker
+//	  404: errorResponse
+//	  500: errorResponse
+func (w *WorkerHandler) RestartWorker(ctx *gin.Context) {
+	w.control(ctx, w.service.RestartWorker)
+}
+
+// swagger:route POST /workers/{workerId}/tick simulator tickWorker
+// Generate and publish one measurement of the worker on demand
+// ---
+//
+//	responses:
+//	  200: Measurement
+//	  204:
+//	  404: errorResponse
+//	  500: errorResponse
+func (w *WorkerHandler) Tick(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	workerId := ctx.Param("workerId")
+
+	measurement, err := w.service.Tick(reqCtx, workerId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	// The generator didn't produce a measurement, e.g. the asset is offline
+	if measurement == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, measurement)
+}
+
+// control changes the state of the worker and responds with its status.
+func (w *WorkerHandler) control(ctx *gin.Context, change func(ctx context.Context, workerId string) (*simulator.Worker, error)) {
+	reqCtx := ctx.Request.Context()
+	workerId := ctx.Param("workerId")
+
+	worker, err := change(reqCtx, workerId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toWorker(*worker))
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	simulatorMock "asset-measurements-assignment/internal/domain/simulator/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkerHandler(t *testing.T) {
+	measurement := measurements.Measurement{
+		Time:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Power: measurements.Power{Value: 100, Unit: measurements.UnitWatt},
+	}
+	worker := simulator.Worker{
+		Id:              "1",
+		State:           simulator.WorkerPaused,
+		Interval:        time.Second,
+		GeneratorType:   simulator.GeneratorProducer,
+		LastMeasurement: &measurement,
+		PublishErrors:   2,
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		responseBody string
+	}{
+		{
+			name:         "Get workers",
+			method:       http.MethodGet,
+			path:         "/workers",
+			expectedCode: http.StatusOK,
+			responseBody: `[{"id":"1","state":"paused","interval":1000000000,"generatorType":"producer",` +
+				`"lastMeasurement":{"power":{"value":100,"unit":"W"},"stateOfEnergy":null,"time":"2024-01-01T00:00:00Z"},"publishErrors":2}]`,
+		},
+		{
+			name:         "Pause worker",
+			method:       http.MethodPost,
+			path:         "/workers/1/pause",
+			expectedCode: http.StatusOK,
+			responseBody: `{"id":"1","state":"paused","interval":1000000000,"generatorType":"producer",` +
+				`"lastMeasurement":{"power":{"value":100,"unit":"W"},"stateOfEnergy":null,"time":"2024-01-01T00:00:00Z"},"publishErrors":2}`,
+		},
+		{
+			name:         "Tick worker",
+			method:       http.MethodPost,
+			path:         "/workers/1/tick",
+			expectedCode: http.StatusOK,
+			responseBody: `{"power":{"value":100,"unit":"W"},"stateOfEnergy":null,"time":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name:         "Tick offline worker",
+			method:       http.MethodPost,
+			path:         "/workers/1/tick",
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := simulatorMock.NewMockWorkerService(t)
+			router := gin.New()
+			NewWorkerHandler(service).RegisterRoutes(router)
+
+			switch tt.name {
+			case "Get workers":
+				service.EXPECT().GetWorkers(mock.Anything).Return([]simulator.Worker{worker}, nil)
+			case "Pause worker":
+				service.EXPECT().PauseWorker(mock.Anything, "1").Return(&worker, nil)
+			case "Tick worker":
+				service.EXPECT().Tick(mock.Anything, "1").Return(&measurement, nil)
+			case "Tick offline worker":
+				service.EXPECT().Tick(mock.Anything, "1").Return(nil, nil)
+			}
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code, w.Body.String())
+			if tt.responseBody != "" {
+				assert.JSONEq(t, tt.responseBody, w.Body.String())
+			}
+		})
+	}
+}
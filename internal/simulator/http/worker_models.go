@@ -0,0 +1,44 @@
+package http
+
+import (
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+)
+
+// swagger:model
+type Worker struct {
+	// ID of the worker, the ID of the simulated asset
+	Id string `json:"id"`
+	// State of the worker: running, paused or stopped
+	State string `json:"state"`
+	// Measurement interval in nanoseconds
+	Interval time.Duration `json:"interval"`
+	// Kind of the generator: solar, wind, heater, motor, loadShape, battery, producer, consumer, replay or scenario
+	GeneratorType string `json:"generatorType"`
+	// Last measurement generated by the worker
+	LastMeasurement *measurements.Measurement `json:"lastMeasurement,omitempty"`
+	// Number of measurements the worker failed to publish
+	PublishErrors int64 `json:"publishErrors"`
+}
+
+func toWorker(worker simulator.Worker) Worker {
+	return Worker{
+		Id:              worker.Id,
+		State:           string(worker.State),
+		Interval:        worker.Interval,
+		GeneratorType:   string(worker.GeneratorType),
+		LastMeasurement: worker.LastMeasurement,
+		PublishErrors:   worker.PublishErrors,
+	}
+}
+
+func toWorkers(workers []simulator.Worker) []Worker {
+	result := make([]Worker, 0, len(workers))
+	for _, worker := range workers {
+		result = append(result, toWorker(worker))
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package inprocess
+
+import (
+	"context"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/pkg/infrastructure/bus"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const measurementRoutingKey = "measurement"
+
+// MeasurementPublisher publishes the measurements on the in-process bus, encoded the same way as for RabbitMQ.
+type MeasurementPublisher struct {
+	obs     observability.Observability
+	bus     *bus.Bus
+	encoder *messages.MeasurementEncoder
+}
+
+func NewMeasurementPublisher(obs observability.Observability, bus *bus.Bus, encoding messages.Encoding, producer messages.Producer) *MeasurementPublisher {
+	return &MeasurementPublisher{
+		obs:     obs.WithSpanKind(trace.SpanKindProducer),
+		bus:     bus,
+		encoder: messages.NewMeasurementEncoder(encoding, producer),
+	}
+}
+
+// Publish wraps the measurement in a versioned envelope and publishes it on the bus.
+func (p *MeasurementPublisher) Publish(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+	return p.PublishMessage(ctx, measurement, assetId, nil)
+}
+
+// PublishMessage publishes the message of the measurement modified by modify, if not nil, e.g. to publish malformed
+// messages.
+func (p *MeasurementPublisher) PublishMessage(ctx context.Context, measurement measurements.Measurement, assetId string, modify func(message *messages.Message)) error {
+	ctx, cancel, logger := p.obs.LogSpan(ctx, "measurement.publisher.Publish")
+	defer cancel()
+
+	message, err := p.encoder.Encode(assetId, measurement)
+	if err != nil {
+		return err
+	}
+
+	if modify != nil {
+		modify(message)
+	}
+
+	logger.Debug("Publishing measurement", zap.String("messageId", message.MessageId), zap.String("assetId", assetId))
+	return p.bus.Publish(ctx, measurementRoutingKey, *message)
+}
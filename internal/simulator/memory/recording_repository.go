@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// RecordingRepository keeps the recordings in memory. It is meant for tests and demos.
+type RecordingRepository struct {
+	obs observability.Observability
+
+	mu         sync.RWMutex
+	recordings map[string]simulator.Recording
+}
+
+func NewRecordingRepository(obs observability.Observability) *RecordingRepository {
+	return &RecordingRepository{
+		obs:        obs,
+		recordings: map[string]simulator.Recording{},
+	}
+}
+
+// CreateRecording stores the recording with a new ID.
+func (r *RecordingRepository) CreateRecording(ctx context.Context, recording simulator.Recording) (*simulator.Recording, error) {
+	_, cancel := r.obs.Span(ctx, "recording.repository.CreateRecording", zap.String("name", recording.Name))
+	defer cancel()
+
+	recording.Id = uuid.New().String()
+	recording.CreatedAt = time.Now()
+	recording.Measurements = slices.Clone(recording.Measurements)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recordings[recording.Id] = recording
+	return &recording, nil
+}
+
+// GetRecording returns the recording with the given ID.
+func (r *RecordingRepository) GetRecording(ctx context.Context, recordingId string) (*simulator.Recording, error) {
+	_, cancel := r.obs.Span(ctx, "recording.repository.GetRecording", zap.String("recordingId", recordingId))
+	defer cancel()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	recording, ok := r.recordings[recordingId]
+	if !ok {
+		return nil, simulator.ErrRecordingNotFound
+	}
+
+	recording.Measurements = slices.Clone(recording.Measurements)
+	return &recording, nil
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// ScenarioRepository keeps the scenarios in memory. It is meant for tests and demos.
+type ScenarioRepository struct {
+	obs observability.Observability
+
+	mu sync.RWMutex
+	// Scenarios in the order they were created
+	scenarios []simulator.Scenario
+}
+
+func NewScenarioRepository(obs observability.Observability) *ScenarioRepository {
+	return &ScenarioRepository{
+		obs: obs,
+	}
+}
+
+// CreateScenario stores the scenario with a new ID.
+func (r *ScenarioRepository) CreateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	_, cancel := r.obs.Span(ctx, "scenario.repository.CreateScenario", zap.String("name", scenario.Name))
+	defer cancel()
+
+	scenario = cloneScenario(scenario)
+	scenario.Id = uuid.New().String()
+	scenario.CreatedAt = time.Now()
+	scenario.UpdatedAt = scenario.CreatedAt
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scenarios = append(r.scenarios, scenario)
+	return &scenario, nil
+}
+
+// GetScenario returns the scenario with the given ID.
+func (r *ScenarioRepository) GetScenario(ctx context.Context, scenarioId string) (*simulator.Scenario, error) {
+	_, cancel := r.obs.Span(ctx, "scenario.repository.GetScenario", zap.String("scenarioId", scenarioId))
+	defer cancel()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	index := r.indexOf(scenarioId)
+	if index < 0 {
+		return nil, simulator.ErrScenarioNotFound
+	}
+
+	scenario := cloneScenario(r.scenarios[index])
+	return &scenario, nil
+}
+
+// GetScenarios returns all scenarios in the order they were created.
+func (r *ScenarioRepository) GetScenarios(ctx context.Context) ([]simulator.Scenario, error) {
+	_, cancel := r.obs.Span(ctx, "scenario.repository.GetScenarios")
+	defer cancel()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scenarios := make([]simulator.Scenario, 0, len(r.scenarios))
+	for _, scenario := range r.scenarios {
+		scenarios = append(scenarios, cloneScenario(scenario))
+	}
+
+	return scenarios, nil
+}
+
+// UpdateScenario replaces the scenario with the ID of the given scenario.
+func (r *ScenarioRepository) UpdateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	_, cancel := r.obs.Span(ctx, "scenario.repository.UpdateScenario", zap.String("scenarioId", scenario.Id))
+	defer cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.indexOf(scenario.Id)
+	if index < 0 {
+		return nil, simulator.ErrScenarioNotFound
+	}
+
+	scenario = cloneScenario(scenario)
+	scenario.CreatedAt = r.scenarios[index].CreatedAt
+	scenario.UpdatedAt = time.Now()
+	r.scenarios[index] = scenario
+
+	return &scenario, nil
+}
+
+func (r *ScenarioRepository) DeleteScenario(ctx context.Context, scenarioId string) error {
+	_, cancel := r.obs.Span(ctx, "scenario.repository.DeleteScenario", zap.String("scenarioId", scenarioId))
+	defer cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.indexOf(scenarioId)
+	if index < 0 {
+		return simulator.ErrScenarioNotFound
+	}
+
+	r.scenarios = slices.Delete(r.scenarios, index, index+1)
+	return nil
+}
+
+func (r *ScenarioRepository) indexOf(scenarioId string) int {
+	return slices.IndexFunc(r.scenarios, func(scenario simulator.Scenario) bool {
+		return scenario.Id == scenarioId
+	})
+}
+
+// cloneScenario copies the slices of the scenario, so the stored scenario can't be changed by the callers.
+func cloneScenario(scenario simulator.Scenario) simulator.Scenario {
+	scenario.AssetIds = slices.Clone(scenario.AssetIds)
+	scenario.Events = slices.Clone(scenario.Events)
+	return scenario
+}
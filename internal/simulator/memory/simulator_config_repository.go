@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+)
+
+// SimulatorConfigurationRepository keeps the simulator configurations in memory. It is meant for tests and demos.
+type SimulatorConfigurationRepository struct {
+	obs observability.Observability
+
+	mu sync.RWMutex
+	// Configurations in the order they were created
+	configurations []simulator.Configuration
+	// Latest version of the configuration of each asset, including the deleted configurations
+	versions map[string]int
+}
+
+func NewSimulatorConfigurationRepository(obs observability.Observability) *SimulatorConfigurationRepository {
+	return &SimulatorConfigurationRepository{
+		obs:      obs,
+		versions: map[string]int{},
+	}
+}
+
+// GetAssetConfiguration returns the latest configuration for the asset with the given ID.
+func (s *SimulatorConfigurationRepository) GetAssetConfiguration(ctx context.Context, assetId string) (*simulator.Configuration, error) {
+	_, cancel := s.obs.Span(ctx, "configuration.repository.GetAssetConfiguration", zap.String("assetId", assetId))
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Configurations are ordered by version
+	for i := len(s.configurations) - 1; i >= 0; i-- {
+		if s.configurations[i].AssetId == assetId {
+			cfg := s.configurations[i]
+			return &cfg, nil
+		}
+	}
+
+	return nil, simulator.ErrConfigNotFound
+}
+
+// GetConfigurations returns configurations for all assets.
+func (s *SimulatorConfigurationRepository) GetConfigurations(ctx context.Context) ([]simulator.Configuration, error) {
+	_, cancel := s.obs.Span(ctx, "configuration.repository.GetConfigurations")
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var configs []simulator.Configuration
+	configs = append(configs, s.configurations...)
+	return configs, nil
+}
+
+// CreateConfiguration stores the configuration with a new ID and the next version of the asset's configuration.
+func (s *SimulatorConfigurationRepository) CreateConfiguration(ctx context.Context, configuration simulator.Configuration) (*simulator.Configuration, error) {
+	_, cancel := s.obs.Span(ctx, "configuration.repository.CreateConfiguration", zap.Any("configuration", configuration))
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versions[configuration.AssetId]++
+	configuration.Id = uuid.New().String()
+	configuration.Version = strconv.Itoa(s.versions[configuration.AssetId])
+	s.configurations = append(s.configurations, configuration)
+
+	return &configuration, nil
+}
+
+func (s *SimulatorConfigurationRepository) DeleteConfiguration(ctx context.Context, configurationId string) error {
+	_, cancel := s.obs.Span(ctx, "configuration.repository.DeleteConfiguration", zap.String("configurationId", configurationId))
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, configuration := range s.configurations {
+		if configuration.Id == configurationId {
+			s.configurations = append(s.configurations[:i], s.configurations[i+1:]...)
+			return nil
+		}
+	}
+
+	return simulator.ErrConfigNotFound
+}
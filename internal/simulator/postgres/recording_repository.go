@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/measurements"
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Recording represents a recorded time series, which can be replayed by the simulator.
+type Recording struct {
+	ID        string `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// Name of the recording
+	Name string
+
+	// Measurements ordered by time
+	Measurements []measurements.Measurement `gorm:"type:jsonb;serializer:json"`
+}
+
+func (r *Recording) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New().String()
+	return nil
+}
+
+type RecordingRepository struct {
+	obs observability.Observability
+	db  *gorm.DB
+}
+
+func NewRecordingRepository(obs observability.Observability, db *gorm.DB) *RecordingRepository {
+	return &RecordingRepository{
+		obs: obs,
+		db:  db,
+	}
+}
+
+// CreateRecording stores the recording with a new ID.
+func (r *RecordingRepository) CreateRecording(ctx context.Context, recording simulator.Recording) (*simulator.Recording, error) {
+	ctx, cancel := r.obs.Span(ctx, "recording.repository.CreateRecording", zap.String("name", recording.Name))
+	defer cancel()
+
+	dbRecording := Recording{
+		Name:         recording.Name,
+		Measurements: recording.Measurements,
+	}
+
+	result := r.db.WithContext(ctx).Create(&dbRecording)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	created := toRecording(dbRecording)
+	return &created, nil
+}
+
+// GetRecording returns the recording with the given ID.
+func (r *RecordingRepository) GetRecording(ctx context.Context, recordingId string) (*simulator.Recording, error) {
+	ctx, cancel := r.obs.Span(ctx, "recording.repository.GetRecording", zap.String("recordingId", recordingId))
+	defer cancel()
+
+	var dbRecording Recording
+	result := r.db.WithContext(ctx).Where("id = ?", recordingId).Limit(1).Find(&dbRecording)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, simulator.ErrRecordingNotFound
+	}
+
+	recording := toRecording(dbRecording)
+	return &recording, nil
+}
+
+func toRecording(dbRecording Recording) simulator.Recording {
+	return simulator.Recording{
+		Id:           dbRecording.ID,
+		Name:         dbRecording.Name,
+		CreatedAt:    dbRecording.CreatedAt,
+		Measurements: dbRecording.Measurements,
+	}
+}
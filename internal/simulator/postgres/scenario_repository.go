@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"asset-measurements-assignment/internal/domain/simulator"
+	"github.com/google/uuid"
+	"github.com/xBlaz3kx/DevX/observability"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Scenario represents a script of timed events played on top of the simulator generators.
+type Scenario struct {
+	ID        string `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Name and description of the scenario
+	Name        string
+	Description string
+
+	// AssetIds are the assets whose workers run the scenario
+	AssetIds []string `gorm:"type:jsonb;serializer:json"`
+
+	// Duration of the scenario, zero if it doesn't end
+	Duration time.Duration
+
+	// Loop starts the scenario over at the end
+	Loop bool
+
+	// Events of the scenario
+	Events []simulator.ScenarioEvent `gorm:"type:jsonb;serializer:json"`
+}
+
+func (s *Scenario) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New().String()
+	return nil
+}
+
+type ScenarioRepository struct {
+	obs observability.Observability
+	db  *gorm.DB
+}
+
+func NewScenarioRepository(obs observability.Observability, db *gorm.DB) *ScenarioRepository {
+	return &ScenarioRepository{
+		obs: obs,
+		db:  db,
+	}
+}
+
+// CreateScenario stores the scenario with a new ID.
+func (r *ScenarioRepository) CreateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	ctx, cancel := r.obs.Span(ctx, "scenario.repository.CreateScenario", zap.String("name", scenario.Name))
+	defer cancel()
+
+	dbScenario := toDBScenario(scenario)
+	result := r.db.WithContext(ctx).Create(&dbScenario)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	created := toScenario(dbScenario)
+	return &created, nil
+}
+
+// GetScenario returns the scenario with the given ID.
+func (r *ScenarioRepository) GetScenario(ctx context.Context, scenarioId string) (*simulator.Scenario, error) {
+	ctx, cancel := r.obs.Span(ctx, "scenario.repository.GetScenario", zap.String("scenarioId", scenarioId))
+	defer cancel()
+
+	var dbScenario Scenario
+	result := r.db.WithContext(ctx).Where("id = ?", scenarioId).Limit(1).Find(&dbScenario)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, simulator.ErrScenarioNotFound
+	}
+
+	scenario := toScenario(dbScenario)
+	return &scenario, nil
+}
+
+// GetScenarios returns all scenarios in the order they were created.
+func (r *ScenarioRepository) GetScenarios(ctx context.Context) ([]simulator.Scenario, error) {
+	ctx, cancel := r.obs.Span(ctx, "scenario.repository.GetScenarios")
+	defer cancel()
+
+	var dbScenarios []Scenario
+	result := r.db.WithContext(ctx).Order("created_at").Find(&dbScenarios)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	scenarios := make([]simulator.Scenario, 0, len(dbScenarios))
+	for _, dbScenario := range dbScenarios {
+		scenarios = append(scenarios, toScenario(dbScenario))
+	}
+
+	return scenarios, nil
+}
+
+// UpdateScenario replaces the scenario with the ID of the given scenario.
+func (r *ScenarioRepository) UpdateScenario(ctx context.Context, scenario simulator.Scenario) (*simulator.Scenario, error) {
+	ctx, cancel := r.obs.Span(ctx, "scenario.repository.UpdateScenario", zap.String("scenarioId", scenario.Id))
+	defer cancel()
+
+	var dbScenario Scenario
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", scenario.Id).Limit(1).Find(&dbScenario)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return simulator.ErrScenarioNotFound
+		}
+
+		updated := toDBScenario(scenario)
+		updated.ID = dbScenario.ID
+		updated.CreatedAt = dbScenario.CreatedAt
+		dbScenario = updated
+
+		return tx.Save(&dbScenario).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated := toScenario(dbScenario)
+	return &updated, nil
+}
+
+func (r *ScenarioRepository) DeleteScenario(ctx context.Context, scenarioId string) error {
+	ctx, cancel := r.obs.Span(ctx, "scenario.repository.DeleteScenario", zap.String("scenarioId", scenarioId))
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&Scenario{}, "id = ?", scenarioId)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return simulator.ErrScenarioNotFound
+	}
+
+	return nil
+}
+
+func toDBScenario(scenario simulator.Scenario) Scenario {
+	return Scenario{
+		Name:        scenario.Name,
+		Description: scenario.Description,
+		AssetIds:    scenario.AssetIds,
+		Duration:    scenario.Duration,
+		Loop:        scenario.Loop,
+		Events:      scenario.Events,
+	}
+}
+
+func toScenario(dbScenario Scenario) simulator.Scenario {
+	return simulator.Scenario{
+		Id:          dbScenario.ID,
+		Name:        dbScenario.Name,
+		Description: dbScenario.Description,
+		CreatedAt:   dbScenario.CreatedAt,
+		UpdatedAt:   dbScenario.UpdatedAt,
+		AssetIds:    dbScenario.AssetIds,
+		Duration:    dbScenario.Duration,
+		Loop:        dbScenario.Loop,
+		Events:      dbScenario.Events,
+	}
+}
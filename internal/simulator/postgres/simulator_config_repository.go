@@ -41,6 +41,33 @@ type SimulatorConfiguration struct {
 
 	// MaxPowerStep is the maximum step between power values
 	MaxPowerStep float64
+
+	// Seed of the random numbers of the generator
+	Seed int64
+
+	// Mode of the simulation, model or replay
+	Mode string
+
+	// Replay parameters in the replay mode
+	Replay *simulator.ReplayParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Solar parameters of a solar asset
+	Solar *simulator.SolarParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Wind parameters of a wind asset
+	Wind *simulator.WindParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Battery parameters of a battery
+	Battery *simulator.BatteryParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Heater parameters of a heater
+	Heater *simulator.HeaterParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Motor parameters of a motor
+	Motor *simulator.MotorParameters `gorm:"type:jsonb;serializer:json"`
+
+	// Load shape of a consumer
+	LoadShape *simulator.LoadShape `gorm:"type:jsonb;serializer:json"`
 }
 
 func (u *SimulatorConfiguration) BeforeCreate(tx *gorm.DB) (err error) {
@@ -155,6 +182,15 @@ func toDBConfiguration(config simulator.Configuration) SimulatorConfiguration {
 		MaxPower:            config.MaxPower,
 		MinPower:            config.MinPower,
 		MaxPowerStep:        config.MaxPowerStep,
+		Seed:                config.Seed,
+		Mode:                string(config.Mode),
+		Replay:              config.Replay,
+		Solar:               config.Solar,
+		Wind:                config.Wind,
+		Battery:             config.Battery,
+		Heater:              config.Heater,
+		Motor:               config.Motor,
+		LoadShape:           config.LoadShape,
 	}
 }
 
@@ -168,5 +204,14 @@ func toConfiguration(dbConfig SimulatorConfiguration) simulator.Configuration {
 		MaxPower:            dbConfig.MaxPower,
 		MinPower:            dbConfig.MinPower,
 		MaxPowerStep:        dbConfig.MaxPowerStep,
+		Seed:                dbConfig.Seed,
+		Mode:                simulator.SimulationMode(dbConfig.Mode),
+		Replay:              dbConfig.Replay,
+		Solar:               dbConfig.Solar,
+		Wind:                dbConfig.Wind,
+		Battery:             dbConfig.Battery,
+		Heater:              dbConfig.Heater,
+		Motor:               dbConfig.Motor,
+		LoadShape:           dbConfig.LoadShape,
 	}
 }
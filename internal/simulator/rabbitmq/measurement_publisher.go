@@ -2,10 +2,14 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
+	"sync"
+	"time"
 
 	"asset-measurements-assignment/internal/domain/measurements"
 	rabbitmq2 "asset-measurements-assignment/internal/pkg/infrastructure/rabbitmq"
+	"asset-measurements-assignment/internal/pkg/messages"
+	"asset-measurements-assignment/internal/pkg/metrics"
+	"github.com/pkg/errors"
 	"github.com/wagslane/go-rabbitmq"
 	"github.com/xBlaz3kx/DevX/observability"
 	"go.opentelemetry.io/otel/trace"
@@ -17,20 +21,38 @@ const (
 	measurementExchange     = "measurement"
 )
 
+var ErrPublisherClosed = errors.New("publisher is closed")
+
 type MeasurementPublisher struct {
 	obs       observability.Observability
 	publisher *rabbitmq.Publisher
+	encoder   *messages.MeasurementEncoder
+	metrics   *metrics.PublisherMetrics
+
+	// Publishes waiting for the broker confirmation
+	mu      sync.RWMutex
+	closed  bool
+	pending sync.WaitGroup
 }
 
-func NewMeasurementPublisher(obs observability.Observability, conn *rabbitmq.Conn) (*MeasurementPublisher, error) {
+// NewMeasurementPublisher creates a publisher on the shared connection. The state of its channel is tracked in state.
+func NewMeasurementPublisher(
+	obs observability.Observability,
+	conn *rabbitmq.Conn,
+	state *rabbitmq2.State,
+	encoding messages.Encoding,
+	producer messages.Producer,
+	metrics *metrics.PublisherMetrics,
+) (*MeasurementPublisher, error) {
 	publisher, err := rabbitmq.NewPublisher(
 		conn,
 		// Enable publisher logging
-		rabbitmq.WithPublisherOptionsLogger(rabbitmq2.NewLogger(obs)),
+		rabbitmq.WithPublisherOptionsLogger(state.Logger(rabbitmq2.NewLogger(obs))),
 		rabbitmq.WithPublisherOptionsExchangeName(measurementExchange),
 		rabbitmq.WithPublisherOptionsExchangeDurable,
 		rabbitmq.WithPublisherOptionsExchangeKind("topic"),
 		rabbitmq.WithPublisherOptionsExchangeDeclare,
+		rabbitmq.WithPublisherOptionsConfirm,
 	)
 	if err != nil {
 		return nil, err
@@ -39,38 +61,98 @@ func NewMeasurementPublisher(obs observability.Observability, conn *rabbitmq.Con
 	return &MeasurementPublisher{
 		obs:       obs.WithSpanKind(trace.SpanKindProducer),
 		publisher: publisher,
+		encoder:   messages.NewMeasurementEncoder(encoding, producer),
+		metrics:   metrics,
 	}, nil
 }
 
+// Publish wraps the measurement in a versioned envelope, publishes it with the configured encoding and waits for the
+// broker to confirm it.
 func (p *MeasurementPublisher) Publish(ctx context.Context, measurement measurements.Measurement, assetId string) error {
+	return p.PublishMessage(ctx, measurement, assetId, nil)
+}
+
+// PublishMessage publishes the message of the measurement modified by modify, if not nil, e.g. to publish malformed
+// messages.
+func (p *MeasurementPublisher) PublishMessage(ctx context.Context, measurement measurements.Measurement, assetId string, modify func(message *messages.Message)) error {
 	ctx, cancel, logger := p.obs.LogSpan(ctx, "measurement.publisher.Publish")
 	defer cancel()
-	logger.Info("Publishing measurement", zap.Any("measurement", measurement), zap.String("assetId", assetId))
 
-	// Marshal the measurement to JSON
-	marshal, err := json.Marshal(measurement)
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		p.metrics.Failed(metrics.ReasonPublisherClosed)
+		return ErrPublisherClosed
+	}
+	p.pending.Add(1)
+	p.mu.RUnlock()
+	defer p.pending.Done()
+
+	start := time.Now()
+	message, err := p.encoder.Encode(assetId, measurement)
 	if err != nil {
+		p.metrics.Failed(metrics.ReasonEncodingFailed)
 		return err
 	}
 
-	// Add the assetId to the headers
-	headers := rabbitmq.Table{
-		"assetId": assetId,
+	if modify != nil {
+		modify(message)
 	}
 
+	logger.Info("Publishing measurement", zap.Any("measurement", measurement), zap.String("messageId", message.MessageId), zap.String("assetId", assetId))
+
 	// Publish the measurement
-	err = p.publisher.PublishWithContext(
+	confirmations, err := p.publisher.PublishWithDeferredConfirmWithContext(
 		ctx,
-		marshal,
+		message.Body,
 		[]string{measurementPublishTopic},
-		rabbitmq.WithPublishOptionsContentType("application/json"),
-		rabbitmq.WithPublishOptionsHeaders(headers),
+		rabbitmq.WithPublishOptionsContentType(message.ContentType),
+		rabbitmq.WithPublishOptionsMessageID(message.MessageId),
+		rabbitmq.WithPublishOptionsHeaders(message.Headers),
 		rabbitmq.WithPublishOptionsExchange(measurementExchange),
 	)
-	return err
+	if err != nil {
+		p.metrics.Failed(metrics.ReasonPublishFailed)
+		return err
+	}
+
+	for _, confirmation := range confirmations {
+		acked, err := confirmation.WaitContext(ctx)
+		if err != nil {
+			p.metrics.Failed(metrics.ReasonNotConfirmed)
+			return errors.Wrap(err, "measurement was not confirmed")
+		}
+
+		if !acked {
+			p.metrics.Failed(metrics.ReasonRejected)
+			return errors.New("measurement was rejected by the broker")
+		}
+	}
+
+	p.metrics.Published(time.Since(start))
+	return nil
 }
 
-func (p *MeasurementPublisher) Close() error {
+// Close stops accepting measurements, waits until the pending measurements are confirmed or the context is done,
+// and closes the channel.
+func (p *MeasurementPublisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.pending.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = errors.Wrap(ctx.Err(), "pending measurements were not confirmed")
+	}
+
 	p.publisher.Close()
-	return nil
+	return err
 }